@@ -0,0 +1,110 @@
+/*
+Adminapi serves the admin REST API behind API Gateway. It lets admins manage members, prayers and the block list over
+HTTP instead of texting admin commands. See the adminapi package for the available endpoints.
+*/
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/4JesusApps/prayertexter/internal/adminapi"
+	"github.com/4JesusApps/prayertexter/internal/awscfg"
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/4JesusApps/prayertexter/internal/repository"
+	"github.com/4JesusApps/prayertexter/internal/service"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/pinpointsmsvoicev2"
+)
+
+// MUST BE SET by go build -ldflags "-X main.version=999" like 0.6.14-0-g26fe727 or 0.6.14-2-g9118702-dirty.
+var version string // do not remove or modify
+
+func handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	slog.InfoContext(ctx, "running adminapi", "version", version, "method", req.HTTPMethod, "path", req.Path)
+
+	cfg := config.Load()
+
+	awsCfg, err := awscfg.GetAwsConfig(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to get aws config", "error", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, err
+	}
+
+	ddbClnt := dynamodb.NewFromConfig(awsCfg)
+	smsClnt := pinpointsmsvoicev2.NewFromConfig(awsCfg)
+
+	members := repository.NewMemberRepository(ddbClnt, cfg.AWS.DB.MemberTable, cfg.AWS.DB.Timeout)
+	prayers := repository.NewPrayerRepository(
+		ddbClnt,
+		members,
+		cfg.AWS.DB.ActivePrayerTable,
+		cfg.AWS.DB.QueuedPrayerTable,
+		cfg.AWS.DB.Timeout,
+	)
+	blocked := repository.NewBlockedPhonesRepository(
+		ddbClnt, cfg.AWS.DB.BlockedPhonesTable, cfg.AWS.DB.Timeout,
+	)
+	intercessors := repository.NewIntercessorPhonesRepository(
+		ddbClnt, cfg.AWS.DB.IntercessorPhonesTable, cfg.AWS.DB.Timeout,
+	)
+
+	optedOut := repository.NewOptedOutPhonesRepository(ddbClnt, cfg.AWS.DB.OptedOutPhonesTable, cfg.AWS.DB.Timeout)
+	deferred := repository.NewDeferredMessageRepository(ddbClnt, cfg.AWS.DB.DeferredMessageTable, cfg.AWS.DB.Timeout)
+	transcripts := repository.NewTranscriptRepository(ddbClnt, cfg.AWS.DB.MessagesTable, cfg.AWS.DB.Timeout)
+	outbound, err := messaging.NewOutbound(
+		cfg,
+		smsClnt,
+		http.DefaultClient,
+		optedOut,
+		deferred,
+		repository.NewSendCountRepository(ddbClnt, cfg.AWS.DB.SendCountTable, cfg.AWS.DB.Timeout),
+		transcripts,
+	)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to create message sender", "error", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, err
+	}
+	defer outbound.Metrics.Log(ctx)
+	sender := outbound.Sender
+
+	profanityLists := repository.NewProfanityListsRepository(
+		ddbClnt, cfg.AWS.DB.ProfanityListsTable, cfg.AWS.DB.Timeout,
+	)
+	profanity, err := service.LoadProfanity(ctx, profanityLists, cfg.Profanity)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to load profanity lists", "error", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, err
+	}
+
+	consents := repository.NewConsentRecordRepository(ddbClnt, cfg.AWS.DB.ConsentRecordTable, cfg.AWS.DB.Timeout)
+	memberSvc := service.NewMemberService(members, intercessors, prayers, consents, sender, profanity, cfg)
+	completed := repository.NewCompletedPrayerRepository(ddbClnt, cfg.AWS.DB.CompletedPrayerTable, cfg.AWS.DB.Timeout)
+	pending := repository.NewPendingPrayerRepository(
+		ddbClnt, members, cfg.AWS.DB.PendingPrayerTable, cfg.AWS.DB.Timeout,
+	)
+	history := repository.NewRequestHistoryRepository(ddbClnt, cfg.AWS.DB.RequestHistoryTable, cfg.AWS.DB.Timeout)
+	prayerSvc := service.NewPrayerService(
+		members, intercessors, prayers, completed, pending, history, sender, profanity, cfg,
+	)
+	audit := repository.NewAuditRepository(ddbClnt, cfg.AWS.DB.AdminAuditTable, cfg.AWS.DB.Timeout)
+	adminSvc := service.NewAdminService(
+		members, blocked, prayers, audit, consents, sender, memberSvc, prayerSvc, cfg,
+	)
+	receipts := repository.NewDeliveryReceiptRepository(ddbClnt, cfg.AWS.DB.DeliveryReceiptTable, cfg.AWS.DB.Timeout)
+	privacySvc := service.NewPrivacyService(
+		members, prayers, pending, completed, history, transcripts, deferred, receipts, sender, memberSvc, prayerSvc,
+		cfg,
+	)
+	api := adminapi.NewAPI(prayerSvc, adminSvc, privacySvc, cfg.AdminAPI)
+
+	return api.Handle(ctx, req), nil
+}
+
+func main() {
+	lambda.Start(handler)
+}
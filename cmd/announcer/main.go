@@ -2,28 +2,134 @@
 Announcer is a helper application for the main application prayertexter. Announcer can be used to send announcements
 to all prayertexter members. This could be for general updates, taking down or turning up prayertexter, or alerts for
 things such as outages or service restoration.
+
+The request body is JSON with the announcement text and, optionally, the phones to send it to. Phones may be written in
+any format and are normalized to E.164. When no phones are given the announcement goes to every signed up member.
+
+	{"message": "PrayerTexter will be down for maintenance tonight.", "phones": ["+44 7911 123456"]}
 */
 package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 
+	"github.com/4JesusApps/prayertexter/internal/awscfg"
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/4JesusApps/prayertexter/internal/repository"
+	"github.com/4JesusApps/prayertexter/internal/service"
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/pinpointsmsvoicev2"
 )
 
 // MUST BE SET by go build -ldflags "-X main.version=999" like 0.6.14-0-g26fe727 or 0.6.14-2-g9118702-dirty.
 var version string // do not remove or modify
 
-//nolint:revive // IGNORING UNUSED CTX AND REQ VARIABLES FOR NOW; REMOVE ONCE THIS FUNCTION IS IMPLEMENTED
+type announcement struct {
+	Message string   `json:"message"`
+	Phones  []string `json:"phones"`
+}
+
 func handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	slog.InfoContext(ctx, "running announcer", "version", version)
-	// Place holder for future code.
-	// Don't forget to remove the above nolint when this is implemented.
 
-	return events.APIGatewayProxyResponse{StatusCode: http.StatusOK, Body: "Success"}, nil
+	var ann announcement
+	if err := json.Unmarshal([]byte(req.Body), &ann); err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to parse announcement", "error", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusBadRequest}, nil
+	}
+
+	cfg := config.Load()
+
+	awsCfg, err := awscfg.GetAwsConfig(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to get aws config", "error", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, err
+	}
+
+	ddbClnt := dynamodb.NewFromConfig(awsCfg)
+	smsClnt := pinpointsmsvoicev2.NewFromConfig(awsCfg)
+
+	members := repository.NewMemberRepository(ddbClnt, cfg.AWS.DB.MemberTable, cfg.AWS.DB.Timeout)
+	prayers := repository.NewPrayerRepository(
+		ddbClnt,
+		members,
+		cfg.AWS.DB.ActivePrayerTable,
+		cfg.AWS.DB.QueuedPrayerTable,
+		cfg.AWS.DB.Timeout,
+	)
+	blocked := repository.NewBlockedPhonesRepository(
+		ddbClnt, cfg.AWS.DB.BlockedPhonesTable, cfg.AWS.DB.Timeout,
+	)
+	intercessors := repository.NewIntercessorPhonesRepository(
+		ddbClnt, cfg.AWS.DB.IntercessorPhonesTable, cfg.AWS.DB.Timeout,
+	)
+
+	optedOut := repository.NewOptedOutPhonesRepository(ddbClnt, cfg.AWS.DB.OptedOutPhonesTable, cfg.AWS.DB.Timeout)
+	deferred := repository.NewDeferredMessageRepository(ddbClnt, cfg.AWS.DB.DeferredMessageTable, cfg.AWS.DB.Timeout)
+	transcripts := repository.NewTranscriptRepository(ddbClnt, cfg.AWS.DB.MessagesTable, cfg.AWS.DB.Timeout)
+	outbound, err := messaging.NewOutbound(
+		cfg,
+		smsClnt,
+		http.DefaultClient,
+		optedOut,
+		deferred,
+		repository.NewSendCountRepository(ddbClnt, cfg.AWS.DB.SendCountTable, cfg.AWS.DB.Timeout),
+		transcripts,
+	)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to create message sender", "error", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, err
+	}
+	defer outbound.Metrics.Log(ctx)
+	sender := outbound.Sender
+
+	profanityLists := repository.NewProfanityListsRepository(
+		ddbClnt, cfg.AWS.DB.ProfanityListsTable, cfg.AWS.DB.Timeout,
+	)
+	profanity, err := service.LoadProfanity(ctx, profanityLists, cfg.Profanity)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to load profanity lists", "error", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, err
+	}
+
+	consents := repository.NewConsentRecordRepository(ddbClnt, cfg.AWS.DB.ConsentRecordTable, cfg.AWS.DB.Timeout)
+	memberSvc := service.NewMemberService(members, intercessors, prayers, consents, sender, profanity, cfg)
+	completed := repository.NewCompletedPrayerRepository(ddbClnt, cfg.AWS.DB.CompletedPrayerTable, cfg.AWS.DB.Timeout)
+	pending := repository.NewPendingPrayerRepository(
+		ddbClnt, members, cfg.AWS.DB.PendingPrayerTable, cfg.AWS.DB.Timeout,
+	)
+	history := repository.NewRequestHistoryRepository(ddbClnt, cfg.AWS.DB.RequestHistoryTable, cfg.AWS.DB.Timeout)
+	prayerSvc := service.NewPrayerService(
+		members, intercessors, prayers, completed, pending, history, sender, profanity, cfg,
+	)
+	audit := repository.NewAuditRepository(ddbClnt, cfg.AWS.DB.AdminAuditTable, cfg.AWS.DB.Timeout)
+	adminSvc := service.NewAdminService(
+		members, blocked, prayers, audit, consents, sender, memberSvc, prayerSvc, cfg,
+	)
+
+	sent, err := adminSvc.Announce(ctx, domain.AuditSystemActor, ann.Message, ann.Phones)
+	if errors.Is(err, service.ErrInvalidPhone) || errors.Is(err, service.ErrEmptyAnnouncement) {
+		slog.ErrorContext(ctx, "lambda handler: invalid announcement", "error", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusBadRequest, Body: err.Error()}, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to send announcement", "error", err, "sent", sent)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, err
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Body:       fmt.Sprintf("Sent announcement to %d phones", sent),
+	}, nil
 }
 
 func main() {
@@ -0,0 +1,65 @@
+/*
+Dashboard serves the read-only ministry dashboard. It runs as a Lambda behind API Gateway when started by the Lambda
+runtime, and otherwise listens on the configured address so that it can be run locally.
+*/
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/awscfg"
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/dashboard"
+	"github.com/4JesusApps/prayertexter/internal/repository"
+	"github.com/4JesusApps/prayertexter/internal/service"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// MUST BE SET by go build -ldflags "-X main.version=999" like 0.6.14-0-g26fe727 or 0.6.14-2-g9118702-dirty.
+var version string // do not remove or modify
+
+const readHeaderTimeout = 10 * time.Second
+
+func main() {
+	ctx := context.Background()
+	slog.InfoContext(ctx, "running dashboard", "version", version)
+
+	cfg := config.Load()
+
+	awsCfg, err := awscfg.GetAwsConfig(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get aws config", "error", err)
+		os.Exit(1)
+	}
+
+	ddbClnt := dynamodb.NewFromConfig(awsCfg)
+
+	members := repository.NewMemberRepository(ddbClnt, cfg.AWS.DB.MemberTable, cfg.AWS.DB.Timeout)
+	prayers := repository.NewPrayerRepository(
+		ddbClnt,
+		members,
+		cfg.AWS.DB.ActivePrayerTable,
+		cfg.AWS.DB.QueuedPrayerTable,
+		cfg.AWS.DB.Timeout,
+	)
+	completed := repository.NewCompletedPrayerRepository(ddbClnt, cfg.AWS.DB.CompletedPrayerTable, cfg.AWS.DB.Timeout)
+
+	dash := dashboard.NewDashboard(service.NewReportService(members, prayers, completed), cfg.Dashboard)
+
+	if os.Getenv("AWS_LAMBDA_RUNTIME_API") != "" {
+		lambda.Start(dash.HandleLambda)
+		return
+	}
+
+	slog.InfoContext(ctx, "dashboard listening", "addr", cfg.Dashboard.Addr)
+	server := &http.Server{Addr: cfg.Dashboard.Addr, Handler: dash, ReadHeaderTimeout: readHeaderTimeout}
+	if err = server.ListenAndServe(); err != nil {
+		slog.ErrorContext(ctx, "dashboard stopped", "error", err)
+		os.Exit(1)
+	}
+}
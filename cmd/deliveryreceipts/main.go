@@ -0,0 +1,105 @@
+/*
+Deliveryreceipts consumes SMS delivery status events that End User Messaging SMS publishes to SNS. It records the
+status of every outbound text message and deactivates members whose phones repeatedly fail to receive texts.
+*/
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/4JesusApps/prayertexter/internal/awscfg"
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/4JesusApps/prayertexter/internal/repository"
+	"github.com/4JesusApps/prayertexter/internal/service"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/pinpointsmsvoicev2"
+)
+
+var version string // do not remove or modify
+
+func handler(ctx context.Context, snsEvent events.SNSEvent) {
+	slog.InfoContext(ctx, "running deliveryreceipts", "version", version)
+
+	cfg := config.Load()
+
+	awsCfg, err := awscfg.GetAwsConfig(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to get aws config", "error", err)
+		return
+	}
+
+	ddbClnt := dynamodb.NewFromConfig(awsCfg)
+	smsClnt := pinpointsmsvoicev2.NewFromConfig(awsCfg)
+
+	members := repository.NewMemberRepository(ddbClnt, cfg.AWS.DB.MemberTable, cfg.AWS.DB.Timeout)
+	prayers := repository.NewPrayerRepository(
+		ddbClnt,
+		members,
+		cfg.AWS.DB.ActivePrayerTable,
+		cfg.AWS.DB.QueuedPrayerTable,
+		cfg.AWS.DB.Timeout,
+	)
+	intercessors := repository.NewIntercessorPhonesRepository(
+		ddbClnt, cfg.AWS.DB.IntercessorPhonesTable, cfg.AWS.DB.Timeout,
+	)
+	receipts := repository.NewDeliveryReceiptRepository(
+		ddbClnt, cfg.AWS.DB.DeliveryReceiptTable, cfg.AWS.DB.Timeout,
+	)
+
+	optedOut := repository.NewOptedOutPhonesRepository(ddbClnt, cfg.AWS.DB.OptedOutPhonesTable, cfg.AWS.DB.Timeout)
+	deferred := repository.NewDeferredMessageRepository(ddbClnt, cfg.AWS.DB.DeferredMessageTable, cfg.AWS.DB.Timeout)
+	transcripts := repository.NewTranscriptRepository(ddbClnt, cfg.AWS.DB.MessagesTable, cfg.AWS.DB.Timeout)
+	outbound, err := messaging.NewOutbound(
+		cfg,
+		smsClnt,
+		http.DefaultClient,
+		optedOut,
+		deferred,
+		repository.NewSendCountRepository(ddbClnt, cfg.AWS.DB.SendCountTable, cfg.AWS.DB.Timeout),
+		transcripts,
+	)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to create message sender", "error", err)
+		return
+	}
+	defer outbound.Metrics.Log(ctx)
+	sender := outbound.Sender
+
+	profanityLists := repository.NewProfanityListsRepository(
+		ddbClnt, cfg.AWS.DB.ProfanityListsTable, cfg.AWS.DB.Timeout,
+	)
+	profanity, err := service.LoadProfanity(ctx, profanityLists, cfg.Profanity)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to load profanity lists", "error", err)
+		return
+	}
+
+	consents := repository.NewConsentRecordRepository(ddbClnt, cfg.AWS.DB.ConsentRecordTable, cfg.AWS.DB.Timeout)
+	memberSvc := service.NewMemberService(members, intercessors, prayers, consents, sender, profanity, cfg)
+	deliverySvc := service.NewDeliveryService(members, receipts, memberSvc, cfg)
+
+	for _, record := range snsEvent.Records {
+		var receipt domain.DeliveryReceipt
+		receipt, err = messaging.ParsePinpointDeliveryEvent(record.SNS.Message)
+		if err != nil {
+			slog.ErrorContext(ctx, "lambda handler: failed to parse delivery event", "error", err,
+				"messageid", record.SNS.MessageID)
+			continue
+		}
+
+		if err = deliverySvc.RecordReceipt(ctx, receipt); err != nil {
+			slog.ErrorContext(ctx, "lambda handler: failed to record delivery receipt", "error", err,
+				"messageid", receipt.MessageID, "phone", receipt.Phone)
+		}
+	}
+}
+
+func main() {
+	lambda.Start(handler)
+}
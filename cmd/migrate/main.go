@@ -0,0 +1,91 @@
+/*
+Migrate upgrades the items in prayertexter's DynamoDB tables to the latest version of each table's schema. Items are
+also upgraded as prayertexter reads them, so migrating is not needed for prayertexter to work, but it lets old upgrades
+be removed and keeps exported data current. Run it locally with AWS credentials and the same PRAY_CONF_ environment
+variables as prayertexter, after the version that added the upgrades is deployed:
+
+	go run ./cmd/migrate [-table Member] [-dry-run] [-start <key>]
+
+Every table with a schema is migrated unless -table names one of them. -dry-run reports how many items would be
+upgraded without saving any. After each page of items the key of the last one is logged; if a migration is interrupted,
+run it again for the same table with -start set to the last key logged to carry on from there. Running a migration
+again from the start is also safe, as items that are already upgraded are skipped.
+*/
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/4JesusApps/prayertexter/internal/awscfg"
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/repository"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// versionedTable is a table whose items have a schema to migrate.
+type versionedTable struct {
+	name     string
+	keyField string
+	schema   repository.Schema
+}
+
+func main() {
+	table := flag.String("table", "", "migrate only this table")
+	dryRun := flag.Bool("dry-run", false, "report what would be upgraded without saving anything")
+	start := flag.String("start", "", "resume after this key, as logged by an interrupted migration of -table")
+	flag.Parse()
+
+	ctx := context.Background()
+	cfg := config.Load()
+
+	tables := []versionedTable{
+		{cfg.AWS.DB.MemberTable, "Phone", repository.MemberSchema},
+		{cfg.AWS.DB.ActivePrayerTable, "IntercessorPhone", repository.PrayerSchema},
+		{cfg.AWS.DB.QueuedPrayerTable, "IntercessorPhone", repository.PrayerSchema},
+		{cfg.AWS.DB.PendingPrayerTable, "ID", repository.PendingPrayerSchema},
+	}
+	if *table != "" {
+		tables = selectTable(tables, *table)
+		if tables == nil {
+			slog.ErrorContext(ctx, "migrate: table has no schema to migrate", "table", *table)
+			os.Exit(1)
+		}
+	} else if *start != "" {
+		slog.ErrorContext(ctx, "migrate: -start needs -table")
+		os.Exit(1)
+	}
+
+	awsCfg, err := awscfg.GetAwsConfig(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "migrate: failed to get aws config", "error", err)
+		os.Exit(1)
+	}
+	ddbClnt := dynamodb.NewFromConfig(awsCfg)
+
+	for _, tbl := range tables {
+		migration := repository.NewMigration(ddbClnt, tbl.name, tbl.keyField, tbl.schema, cfg.AWS.DB.Timeout)
+		var result repository.MigrationResult
+		result, err = migration.Run(ctx, *start, *dryRun, func(key string) {
+			slog.InfoContext(ctx, "migrated page", "table", tbl.name, "last", key)
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "migrate: failed to migrate table", "table", tbl.name, "scanned", result.Scanned,
+				"upgraded", result.Upgraded, "error", err)
+			os.Exit(1)
+		}
+		slog.InfoContext(ctx, "migrated table", "table", tbl.name, "version", tbl.schema.Version(),
+			"dryRun", *dryRun, "scanned", result.Scanned, "upgraded", result.Upgraded, "skipped", result.Skipped)
+	}
+}
+
+func selectTable(tables []versionedTable, name string) []versionedTable {
+	for _, tbl := range tables {
+		if tbl.name == name {
+			return []versionedTable{tbl}
+		}
+	}
+	return nil
+}
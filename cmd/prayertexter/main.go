@@ -2,12 +2,13 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"encoding/base64"
 	"log/slog"
+	"net/http"
+	"strings"
 
 	"github.com/4JesusApps/prayertexter/internal/awscfg"
 	"github.com/4JesusApps/prayertexter/internal/config"
-	"github.com/4JesusApps/prayertexter/internal/domain"
 	"github.com/4JesusApps/prayertexter/internal/messaging"
 	"github.com/4JesusApps/prayertexter/internal/repository"
 	"github.com/4JesusApps/prayertexter/internal/service"
@@ -19,28 +20,39 @@ import (
 
 var version string // do not remove or modify
 
-func handler(ctx context.Context, snsEvent events.SNSEvent) {
+// inboundEvent is either the SNS event End User Messaging SMS publishes for the pinpoint provider or the API Gateway
+// request an http based provider makes. Lambda decodes both into the same struct and only one of them is populated.
+type inboundEvent struct {
+	Records []events.SNSEventRecord `json:"Records"`
+	events.APIGatewayProxyRequest
+}
+
+func handler(ctx context.Context, event inboundEvent) (events.APIGatewayProxyResponse, error) {
 	slog.InfoContext(ctx, "running prayertexter", "version", version)
 
-	if len(snsEvent.Records) > 1 {
-		for _, record := range snsEvent.Records {
-			slog.ErrorContext(ctx, "lambda handler: there are more than 1 SNS records! This is unexpected and only "+
-				"the first record will be handled", "message", record.SNS.Message, "messageid", record.SNS.MessageID)
-		}
+	cfg := config.Load()
+
+	payload, status := inboundPayload(ctx, cfg.AWS.SMS, event)
+	if status != http.StatusOK {
+		return events.APIGatewayProxyResponse{StatusCode: status}, nil
 	}
 
-	var msg domain.TextMessage
-	if err := json.Unmarshal([]byte(snsEvent.Records[0].SNS.Message), &msg); err != nil {
-		slog.ErrorContext(ctx, "lambda handler: failed to unmarshal api gateway request", "error", err)
-		return
+	parse, err := messaging.NewInboundParser(cfg.AWS.SMS.Provider)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to get inbound parser", "error", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, nil
 	}
 
-	cfg := config.Load()
+	msg, err := parse(payload)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to parse inbound message", "error", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusBadRequest}, nil
+	}
 
 	awsCfg, err := awscfg.GetAwsConfig(ctx)
 	if err != nil {
 		slog.ErrorContext(ctx, "lambda handler: failed to get aws config", "error", err)
-		return
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, nil
 	}
 
 	ddbClnt := dynamodb.NewFromConfig(awsCfg)
@@ -49,6 +61,7 @@ func handler(ctx context.Context, snsEvent events.SNSEvent) {
 	members := repository.NewMemberRepository(ddbClnt, cfg.AWS.DB.MemberTable, cfg.AWS.DB.Timeout)
 	prayers := repository.NewPrayerRepository(
 		ddbClnt,
+		members,
 		cfg.AWS.DB.ActivePrayerTable,
 		cfg.AWS.DB.QueuedPrayerTable,
 		cfg.AWS.DB.Timeout,
@@ -60,16 +73,118 @@ func handler(ctx context.Context, snsEvent events.SNSEvent) {
 		ddbClnt, cfg.AWS.DB.IntercessorPhonesTable, cfg.AWS.DB.Timeout,
 	)
 
-	sender := messaging.NewPinpointSender(smsClnt, cfg.AWS.SMS.PhonePool, cfg.AWS.SMS.Timeout)
+	optedOut := repository.NewOptedOutPhonesRepository(ddbClnt, cfg.AWS.DB.OptedOutPhonesTable, cfg.AWS.DB.Timeout)
+	deferred := repository.NewDeferredMessageRepository(ddbClnt, cfg.AWS.DB.DeferredMessageTable, cfg.AWS.DB.Timeout)
+	transcripts := repository.NewTranscriptRepository(ddbClnt, cfg.AWS.DB.MessagesTable, cfg.AWS.DB.Timeout)
+	outbound, err := messaging.NewOutbound(
+		cfg,
+		smsClnt,
+		http.DefaultClient,
+		optedOut,
+		deferred,
+		repository.NewSendCountRepository(ddbClnt, cfg.AWS.DB.SendCountTable, cfg.AWS.DB.Timeout),
+		transcripts,
+	)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to create message sender", "error", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, nil
+	}
+	defer outbound.Metrics.Log(ctx)
+	sender := outbound.Sender
 
-	memberSvc := service.NewMemberService(members, intercessors, prayers, sender, cfg)
-	prayerSvc := service.NewPrayerService(members, intercessors, prayers, sender, cfg)
-	adminSvc := service.NewAdminService(members, blocked, sender, memberSvc)
-	router := service.NewRouter(members, blocked, memberSvc, prayerSvc, adminSvc)
+	profanityLists := repository.NewProfanityListsRepository(
+		ddbClnt, cfg.AWS.DB.ProfanityListsTable, cfg.AWS.DB.Timeout,
+	)
+	profanity, err := service.LoadProfanity(ctx, profanityLists, cfg.Profanity)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to load profanity lists", "error", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, nil
+	}
+
+	consents := repository.NewConsentRecordRepository(ddbClnt, cfg.AWS.DB.ConsentRecordTable, cfg.AWS.DB.Timeout)
+	memberSvc := service.NewMemberService(members, intercessors, prayers, consents, sender, profanity, cfg)
+	completed := repository.NewCompletedPrayerRepository(ddbClnt, cfg.AWS.DB.CompletedPrayerTable, cfg.AWS.DB.Timeout)
+	pending := repository.NewPendingPrayerRepository(
+		ddbClnt, members, cfg.AWS.DB.PendingPrayerTable, cfg.AWS.DB.Timeout,
+	)
+	history := repository.NewRequestHistoryRepository(ddbClnt, cfg.AWS.DB.RequestHistoryTable, cfg.AWS.DB.Timeout)
+	prayerSvc := service.NewPrayerService(
+		members, intercessors, prayers, completed, pending, history, sender, profanity, cfg,
+	)
+	audit := repository.NewAuditRepository(ddbClnt, cfg.AWS.DB.AdminAuditTable, cfg.AWS.DB.Timeout)
+	adminSvc := service.NewAdminService(
+		members, blocked, prayers, audit, consents, sender, memberSvc, prayerSvc, cfg,
+	)
+	receipts := repository.NewDeliveryReceiptRepository(ddbClnt, cfg.AWS.DB.DeliveryReceiptTable, cfg.AWS.DB.Timeout)
+	privacySvc := service.NewPrivacyService(
+		members, prayers, pending, completed, history, transcripts, deferred, receipts, sender, memberSvc, prayerSvc,
+		cfg,
+	)
+	router := service.NewRouter(
+		members, blocked, optedOut, transcripts, memberSvc, prayerSvc, adminSvc, privacySvc, cfg,
+	)
 
 	if err = router.Handle(ctx, msg); err != nil {
-		return
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, nil
+	}
+
+	return events.APIGatewayProxyResponse{StatusCode: http.StatusOK}, nil
+}
+
+// inboundPayload returns the raw inbound message from event along with http.StatusOK, or the status to respond with
+// when there is nothing to handle. API Gateway requests must be signed by the configured provider, otherwise anyone
+// could text as any phone through the public endpoint.
+func inboundPayload(ctx context.Context, cfg config.SMSConfig, event inboundEvent) (string, int) {
+	if len(event.Records) > 0 {
+		if len(event.Records) > 1 {
+			for _, record := range event.Records {
+				slog.ErrorContext(ctx, "lambda handler: there are more than 1 SNS records! This is unexpected and "+
+					"only the first record will be handled", "message", record.SNS.Message, "messageid",
+					record.SNS.MessageID)
+			}
+		}
+		return event.Records[0].SNS.Message, http.StatusOK
+	}
+
+	body := event.Body
+	if event.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(body)
+		if err != nil {
+			slog.ErrorContext(ctx, "lambda handler: failed to decode api gateway request", "error", err)
+			return "", http.StatusBadRequest
+		}
+		body = string(decoded)
+	}
+
+	if !authenticInbound(cfg, event.APIGatewayProxyRequest, body) {
+		slog.WarnContext(ctx, "lambda handler: rejected unauthenticated inbound request", "provider", cfg.Provider)
+		return "", http.StatusUnauthorized
+	}
+	return body, http.StatusOK
+}
+
+// authenticInbound reports whether req was sent by the configured provider. The Twilio signature covers the url Twilio
+// requested, which is rebuilt from the API Gateway domain, stage and path; this does not hold behind a custom domain.
+func authenticInbound(cfg config.SMSConfig, req events.APIGatewayProxyRequest, body string) bool {
+	switch cfg.Provider {
+	case messaging.ProviderTwilio:
+		rawURL := "https://" + req.RequestContext.DomainName + "/" + req.RequestContext.Stage + req.Path
+		return messaging.VerifyTwilioSignature(cfg.Twilio.AuthToken, rawURL, body, header(req, "X-Twilio-Signature"))
+	case messaging.ProviderWebhook:
+		return messaging.VerifyWebhookToken(cfg.Webhook.Token, header(req, "Authorization"))
+	default:
+		// End User Messaging SMS only delivers inbound messages through SNS.
+		return false
+	}
+}
+
+func header(req events.APIGatewayProxyRequest, name string) string {
+	for key, val := range req.Headers {
+		if strings.EqualFold(key, name) {
+			return val
+		}
 	}
+	return ""
 }
 
 func main() {
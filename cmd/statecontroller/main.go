@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"log/slog"
+	"net/http"
 
+	"github.com/4JesusApps/prayertexter/internal/apperr"
 	"github.com/4JesusApps/prayertexter/internal/awscfg"
 	"github.com/4JesusApps/prayertexter/internal/config"
 	"github.com/4JesusApps/prayertexter/internal/messaging"
@@ -16,7 +18,15 @@ import (
 
 var version string // do not remove or modify
 
-func handler(ctx context.Context) {
+// jobSendDeferred is the job of the frequent schedule that only drains deferred messages. Any other invocation runs
+// every job.
+const jobSendDeferred = "senddeferred"
+
+type event struct {
+	Job string `json:"job"`
+}
+
+func handler(ctx context.Context, evt event) {
 	slog.InfoContext(ctx, "running statecontroller", "version", version)
 
 	cfg := config.Load()
@@ -33,6 +43,7 @@ func handler(ctx context.Context) {
 	members := repository.NewMemberRepository(ddbClnt, cfg.AWS.DB.MemberTable, cfg.AWS.DB.Timeout)
 	prayers := repository.NewPrayerRepository(
 		ddbClnt,
+		members,
 		cfg.AWS.DB.ActivePrayerTable,
 		cfg.AWS.DB.QueuedPrayerTable,
 		cfg.AWS.DB.Timeout,
@@ -40,11 +51,77 @@ func handler(ctx context.Context) {
 	intercessors := repository.NewIntercessorPhonesRepository(
 		ddbClnt, cfg.AWS.DB.IntercessorPhonesTable, cfg.AWS.DB.Timeout,
 	)
+	blocked := repository.NewBlockedPhonesRepository(ddbClnt, cfg.AWS.DB.BlockedPhonesTable, cfg.AWS.DB.Timeout)
+	audit := repository.NewAuditRepository(ddbClnt, cfg.AWS.DB.AdminAuditTable, cfg.AWS.DB.Timeout)
 
-	sender := messaging.NewPinpointSender(smsClnt, cfg.AWS.SMS.PhonePool, cfg.AWS.SMS.Timeout)
+	optedOut := repository.NewOptedOutPhonesRepository(ddbClnt, cfg.AWS.DB.OptedOutPhonesTable, cfg.AWS.DB.Timeout)
+	deferred := repository.NewDeferredMessageRepository(ddbClnt, cfg.AWS.DB.DeferredMessageTable, cfg.AWS.DB.Timeout)
+	transcripts := repository.NewTranscriptRepository(ddbClnt, cfg.AWS.DB.MessagesTable, cfg.AWS.DB.Timeout)
+	outbound, err := messaging.NewOutbound(
+		cfg,
+		smsClnt,
+		http.DefaultClient,
+		optedOut,
+		deferred,
+		repository.NewSendCountRepository(ddbClnt, cfg.AWS.DB.SendCountTable, cfg.AWS.DB.Timeout),
+		transcripts,
+	)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to create message sender", "error", err)
+		return
+	}
+	defer outbound.Metrics.Log(ctx)
+	if evt.Job == jobSendDeferred {
+		sendDeferred(ctx, outbound)
+		return
+	}
+	sender := outbound.Sender
 
-	prayerSvc := service.NewPrayerService(members, intercessors, prayers, sender, cfg)
+	profanityLists := repository.NewProfanityListsRepository(
+		ddbClnt, cfg.AWS.DB.ProfanityListsTable, cfg.AWS.DB.Timeout,
+	)
+	profanity, err := service.LoadProfanity(ctx, profanityLists, cfg.Profanity)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to load profanity lists", "error", err)
+		return
+	}
+
+	consents := repository.NewConsentRecordRepository(ddbClnt, cfg.AWS.DB.ConsentRecordTable, cfg.AWS.DB.Timeout)
+	memberSvc := service.NewMemberService(members, intercessors, prayers, consents, sender, profanity, cfg)
+	completed := repository.NewCompletedPrayerRepository(ddbClnt, cfg.AWS.DB.CompletedPrayerTable, cfg.AWS.DB.Timeout)
+	pending := repository.NewPendingPrayerRepository(
+		ddbClnt, members, cfg.AWS.DB.PendingPrayerTable, cfg.AWS.DB.Timeout,
+	)
+	history := repository.NewRequestHistoryRepository(ddbClnt, cfg.AWS.DB.RequestHistoryTable, cfg.AWS.DB.Timeout)
+	prayerSvc := service.NewPrayerService(
+		members, intercessors, prayers, completed, pending, history, sender, profanity, cfg,
+	)
+	adminSvc := service.NewAdminService(
+		members, blocked, prayers, audit, consents, sender, memberSvc, prayerSvc, cfg,
+	)
 	prayerSvc.RunScheduledJobs(ctx)
+
+	if err = memberSvc.HandleStaleSignUps(ctx); err != nil {
+		apperr.LogError(ctx, err, "failed job", "job", "Handle Stale Sign Ups")
+	} else {
+		slog.InfoContext(ctx, "finished job", "job", "Handle Stale Sign Ups")
+	}
+
+	if err = adminSvc.ExpireBlocks(ctx); err != nil {
+		apperr.LogError(ctx, err, "failed job", "job", "Expire Blocks")
+	} else {
+		slog.InfoContext(ctx, "finished job", "job", "Expire Blocks")
+	}
+
+	sendDeferred(ctx, outbound)
+}
+
+func sendDeferred(ctx context.Context, outbound *messaging.Outbound) {
+	if err := outbound.Limiter.SendDeferred(ctx, outbound.Deferred); err != nil {
+		apperr.LogError(ctx, err, "failed job", "job", "Send Deferred Messages")
+	} else {
+		slog.InfoContext(ctx, "finished job", "job", "Send Deferred Messages")
+	}
 }
 
 func main() {
@@ -4,6 +4,15 @@ sudo docker compose -f dev/dynamodb/compose.yaml down
 sudo docker compose -f dev/dynamodb/compose.yaml up -d
 sleep 5
 aws dynamodb create-table --cli-input-json file://dev/dynamodb/activeprayer-table.json --endpoint-url http://localhost:8000
+aws dynamodb create-table --cli-input-json file://dev/dynamodb/adminaudit-table.json --endpoint-url http://localhost:8000
+aws dynamodb create-table --cli-input-json file://dev/dynamodb/completedprayer-table.json --endpoint-url http://localhost:8000
+aws dynamodb create-table --cli-input-json file://dev/dynamodb/consentrecord-table.json --endpoint-url http://localhost:8000
+aws dynamodb create-table --cli-input-json file://dev/dynamodb/deferredmessage-table.json --endpoint-url http://localhost:8000
+aws dynamodb create-table --cli-input-json file://dev/dynamodb/deliveryreceipt-table.json --endpoint-url http://localhost:8000
 aws dynamodb create-table --cli-input-json file://dev/dynamodb/general-table.json --endpoint-url http://localhost:8000
 aws dynamodb create-table --cli-input-json file://dev/dynamodb/member-table.json --endpoint-url http://localhost:8000
-aws dynamodb create-table --cli-input-json file://dev/dynamodb/queuedprayer-table.json --endpoint-url http://localhost:8000
\ No newline at end of file
+aws dynamodb create-table --cli-input-json file://dev/dynamodb/messages-table.json --endpoint-url http://localhost:8000
+aws dynamodb create-table --cli-input-json file://dev/dynamodb/pendingprayer-table.json --endpoint-url http://localhost:8000
+aws dynamodb create-table --cli-input-json file://dev/dynamodb/queuedprayer-table.json --endpoint-url http://localhost:8000
+aws dynamodb create-table --cli-input-json file://dev/dynamodb/requesthistory-table.json --endpoint-url http://localhost:8000
+aws dynamodb create-table --cli-input-json file://dev/dynamodb/sendcount-table.json --endpoint-url http://localhost:8000
\ No newline at end of file
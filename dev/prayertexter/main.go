@@ -6,13 +6,11 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 	"net/http"
 
 	"github.com/4JesusApps/prayertexter/internal/awscfg"
 	"github.com/4JesusApps/prayertexter/internal/config"
-	"github.com/4JesusApps/prayertexter/internal/domain"
 	"github.com/4JesusApps/prayertexter/internal/messaging"
 	"github.com/4JesusApps/prayertexter/internal/repository"
 	"github.com/4JesusApps/prayertexter/internal/service"
@@ -28,13 +26,19 @@ var version string // do not remove or modify
 func handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	slog.InfoContext(ctx, "running prayertexter", "version", version)
 
-	var msg domain.TextMessage
-	if err := json.Unmarshal([]byte(req.Body), &msg); err != nil {
-		slog.ErrorContext(ctx, "lambda handler: failed to unmarshal api gateway request", "error", err)
+	cfg := config.Load()
+
+	parse, err := messaging.NewInboundParser(cfg.AWS.SMS.Provider)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to get inbound parser", "error", err)
 		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, err
 	}
 
-	cfg := config.Load()
+	msg, err := parse(req.Body)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to parse api gateway request", "error", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusBadRequest}, err
+	}
 
 	awsCfg, err := awscfg.GetAwsConfig(ctx)
 	if err != nil {
@@ -48,6 +52,7 @@ func handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.API
 	members := repository.NewMemberRepository(ddbClnt, cfg.AWS.DB.MemberTable, cfg.AWS.DB.Timeout)
 	prayers := repository.NewPrayerRepository(
 		ddbClnt,
+		members,
 		cfg.AWS.DB.ActivePrayerTable,
 		cfg.AWS.DB.QueuedPrayerTable,
 		cfg.AWS.DB.Timeout,
@@ -59,12 +64,56 @@ func handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.API
 		ddbClnt, cfg.AWS.DB.IntercessorPhonesTable, cfg.AWS.DB.Timeout,
 	)
 
-	sender := messaging.NewPinpointSender(smsClnt, cfg.AWS.SMS.PhonePool, cfg.AWS.SMS.Timeout)
+	optedOut := repository.NewOptedOutPhonesRepository(ddbClnt, cfg.AWS.DB.OptedOutPhonesTable, cfg.AWS.DB.Timeout)
+	deferred := repository.NewDeferredMessageRepository(ddbClnt, cfg.AWS.DB.DeferredMessageTable, cfg.AWS.DB.Timeout)
+	transcripts := repository.NewTranscriptRepository(ddbClnt, cfg.AWS.DB.MessagesTable, cfg.AWS.DB.Timeout)
+	outbound, err := messaging.NewOutbound(
+		cfg,
+		smsClnt,
+		http.DefaultClient,
+		optedOut,
+		deferred,
+		repository.NewSendCountRepository(ddbClnt, cfg.AWS.DB.SendCountTable, cfg.AWS.DB.Timeout),
+		transcripts,
+	)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to create message sender", "error", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, err
+	}
+	defer outbound.Metrics.Log(ctx)
+	sender := outbound.Sender
+
+	profanityLists := repository.NewProfanityListsRepository(
+		ddbClnt, cfg.AWS.DB.ProfanityListsTable, cfg.AWS.DB.Timeout,
+	)
+	profanity, err := service.LoadProfanity(ctx, profanityLists, cfg.Profanity)
+	if err != nil {
+		slog.ErrorContext(ctx, "lambda handler: failed to load profanity lists", "error", err)
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, err
+	}
 
-	memberSvc := service.NewMemberService(members, intercessors, prayers, sender, cfg)
-	prayerSvc := service.NewPrayerService(members, intercessors, prayers, sender, cfg)
-	adminSvc := service.NewAdminService(members, blocked, sender, memberSvc)
-	router := service.NewRouter(members, blocked, memberSvc, prayerSvc, adminSvc)
+	consents := repository.NewConsentRecordRepository(ddbClnt, cfg.AWS.DB.ConsentRecordTable, cfg.AWS.DB.Timeout)
+	memberSvc := service.NewMemberService(members, intercessors, prayers, consents, sender, profanity, cfg)
+	completed := repository.NewCompletedPrayerRepository(ddbClnt, cfg.AWS.DB.CompletedPrayerTable, cfg.AWS.DB.Timeout)
+	pending := repository.NewPendingPrayerRepository(
+		ddbClnt, members, cfg.AWS.DB.PendingPrayerTable, cfg.AWS.DB.Timeout,
+	)
+	history := repository.NewRequestHistoryRepository(ddbClnt, cfg.AWS.DB.RequestHistoryTable, cfg.AWS.DB.Timeout)
+	prayerSvc := service.NewPrayerService(
+		members, intercessors, prayers, completed, pending, history, sender, profanity, cfg,
+	)
+	audit := repository.NewAuditRepository(ddbClnt, cfg.AWS.DB.AdminAuditTable, cfg.AWS.DB.Timeout)
+	adminSvc := service.NewAdminService(
+		members, blocked, prayers, audit, consents, sender, memberSvc, prayerSvc, cfg,
+	)
+	receipts := repository.NewDeliveryReceiptRepository(ddbClnt, cfg.AWS.DB.DeliveryReceiptTable, cfg.AWS.DB.Timeout)
+	privacySvc := service.NewPrivacyService(
+		members, prayers, pending, completed, history, transcripts, deferred, receipts, sender, memberSvc, prayerSvc,
+		cfg,
+	)
+	router := service.NewRouter(
+		members, blocked, optedOut, transcripts, memberSvc, prayerSvc, adminSvc, privacySvc, cfg,
+	)
 
 	if err = router.Handle(ctx, msg); err != nil {
 		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}, err
@@ -0,0 +1,412 @@
+/*
+Package adminapi serves the admin REST API. It lets admins manage members, prayers and the block list without texting
+admin commands, and reuses the same services that handle those commands. Every admin has their own bearer token, and
+a request acts as the admin whose token it presents: their saved role must have the permission that the endpoint
+requires, and the audit log records their phone.
+
+	GET    /members?q=...              list members, optionally filtered by phone or name
+	GET    /members/{phone}            view a member
+	GET    /members/{phone}/consent    export a phone's opt ins and opt outs, even after the member has opted out
+	PATCH  /members/{phone}            edit a member's name, weekly prayer limit or role
+	DELETE /members/{phone}            remove a member
+	DELETE /members/{phone}/data       delete everything stored about a phone, as texting deletemydata does
+	GET    /prayers                    list active and queued prayers
+	POST   /prayers/{phone}/reassign   give an intercessor's active prayer to another intercessor
+	DELETE /prayers/{phone}            cancel an intercessor's active prayer
+	DELETE /prayers/queued/{id}        cancel a queued prayer
+	GET    /blocks                     list the block list
+	POST   /blocks                     block a phone
+	DELETE /blocks/{phone}             unblock a phone
+	POST   /announcements              text a message to the given phones, or to every member when none are given
+	GET    /audit?admin=...&target=... list audit entries by admin, by target or both, newest first
+
+Listing needs the view permission. Removing members, deleting their data and changing the block list need block,
+reassigning and cancelling prayers need manage prayers, and announcements need broadcast. Editing members needs edit
+members, and also manage admins when the role changes. The audit log needs manage admins.
+*/
+package adminapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/4JesusApps/prayertexter/internal/apperr"
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/service"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+var (
+	errBadRequest   = errors.New("bad request")
+	errUnauthorized = errors.New("unauthorized")
+)
+
+type API struct {
+	prayerSvc  *service.PrayerService
+	adminSvc   *service.AdminService
+	privacySvc *service.PrivacyService
+	cfg        config.AdminAPIConfig
+}
+
+// handler runs a single endpoint.
+type handler func(ctx context.Context) (int, any, error)
+
+// NewAPI returns an API that accepts the admin tokens in cfg.
+func NewAPI(
+	prayerSvc *service.PrayerService,
+	adminSvc *service.AdminService,
+	privacySvc *service.PrivacyService,
+	cfg config.AdminAPIConfig,
+) *API {
+	return &API{
+		prayerSvc:  prayerSvc,
+		adminSvc:   adminSvc,
+		privacySvc: privacySvc,
+		cfg:        cfg,
+	}
+}
+
+type memberUpdateRequest struct {
+	Name              *string      `json:"name"`
+	WeeklyPrayerLimit *int         `json:"weeklyPrayerLimit"`
+	Role              *domain.Role `json:"role"`
+}
+
+type blockRequest struct {
+	Phone    string `json:"phone"`
+	Duration string `json:"duration"`
+	Reason   string `json:"reason"`
+}
+
+type announcementRequest struct {
+	Message string   `json:"message"`
+	Phones  []string `json:"phones"`
+}
+
+type announcementResponse struct {
+	Sent int
+}
+
+type prayersResponse struct {
+	Active []domain.Prayer
+	Queued []domain.Prayer
+}
+
+type reassignResponse struct {
+	Intercessor string
+	Queued      bool
+}
+
+type errorResponse struct {
+	Error string
+}
+
+// Handle authenticates req, runs the endpoint it is for and returns the JSON response.
+func (a *API) Handle(ctx context.Context, req events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	admin, err := a.authenticate(ctx, req)
+	if errors.Is(err, errUnauthorized) {
+		slog.WarnContext(ctx, "unauthorized admin api request", "method", req.HTTPMethod, "path", req.Path)
+		return respond(http.StatusUnauthorized, errorResponse{Error: "unauthorized"})
+	}
+
+	var status int
+	var body any
+	if err == nil {
+		status, body, err = a.routeRequest(ctx, req, *admin)
+	}
+	if err != nil {
+		status = errorStatus(err)
+		if status == http.StatusInternalServerError {
+			apperr.LogError(ctx, err, "admin api request failed", "method", req.HTTPMethod, "path", req.Path)
+			return respond(status, errorResponse{Error: "internal error"})
+		}
+		return respond(status, errorResponse{Error: err.Error()})
+	}
+
+	return respond(status, body)
+}
+
+// authenticate returns the admin whose token req presents, or errUnauthorized when there is no such admin. The admin's
+// role is read from their member on every request, so demoting an admin also takes away their access to the API.
+func (a *API) authenticate(ctx context.Context, req events.APIGatewayProxyRequest) (*domain.Member, error) {
+	header := req.Headers["Authorization"]
+	if header == "" {
+		header = req.Headers["authorization"]
+	}
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return nil, errUnauthorized
+	}
+
+	var adminPhone string
+	for phn, adminToken := range a.cfg.Tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+			adminPhone = phn
+		}
+	}
+	if adminPhone == "" {
+		return nil, errUnauthorized
+	}
+
+	admin, err := a.adminSvc.Member(ctx, adminPhone)
+	if errors.Is(err, service.ErrMemberNotFound) {
+		return nil, apperr.WrapError(errUnauthorized, "admin is not a member")
+	}
+	return admin, err
+}
+
+func (a *API) routeRequest(
+	ctx context.Context,
+	req events.APIGatewayProxyRequest,
+	admin domain.Member,
+) (int, any, error) {
+	parts, err := pathParts(req.Path)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	perm, run := a.route(req, parts, admin)
+	if run == nil {
+		return http.StatusNotFound, errorResponse{Error: "not found"}, nil
+	}
+	if err = service.Authorize(admin.Role, perm); err != nil {
+		return 0, nil, err
+	}
+	return run(ctx)
+}
+
+// route returns the permission that the endpoint for req requires and its handler, which acts as admin, or a nil
+// handler when there is no such endpoint.
+func (a *API) route(
+	req events.APIGatewayProxyRequest,
+	parts []string,
+	admin domain.Member,
+) (domain.Permission, handler) {
+	const (
+		collection = 1
+		item       = 2
+		action     = 3
+	)
+
+	switch {
+	case len(parts) == collection && parts[0] == "members" && req.HTTPMethod == http.MethodGet:
+		return domain.PermView, func(ctx context.Context) (int, any, error) {
+			members, err := a.adminSvc.Members(ctx, req.QueryStringParameters["q"])
+			return http.StatusOK, members, err
+		}
+
+	case len(parts) == item && parts[0] == "members" && req.HTTPMethod == http.MethodGet:
+		return domain.PermView, func(ctx context.Context) (int, any, error) {
+			mem, err := a.adminSvc.Member(ctx, parts[1])
+			return http.StatusOK, mem, err
+		}
+
+	case len(parts) == action && parts[0] == "members" && parts[2] == "consent" && req.HTTPMethod == http.MethodGet:
+		return domain.PermView, func(ctx context.Context) (int, any, error) {
+			export, err := a.adminSvc.ConsentExport(ctx, parts[1])
+			return http.StatusOK, export, err
+		}
+
+	case len(parts) == item && parts[0] == "members" && req.HTTPMethod == http.MethodPatch:
+		return domain.PermEditMembers, func(ctx context.Context) (int, any, error) {
+			return a.updateMember(ctx, admin, parts[1], req.Body)
+		}
+
+	case len(parts) == item && parts[0] == "members" && req.HTTPMethod == http.MethodDelete:
+		return domain.PermBlock, func(ctx context.Context) (int, any, error) {
+			return http.StatusNoContent, nil, a.adminSvc.Remove(ctx, admin.Phone, parts[1])
+		}
+
+	case len(parts) == action && parts[0] == "members" && parts[2] == "data" && req.HTTPMethod == http.MethodDelete:
+		return domain.PermBlock, func(ctx context.Context) (int, any, error) {
+			return a.forget(ctx, admin, parts[1])
+		}
+
+	case len(parts) == collection && parts[0] == "prayers" && req.HTTPMethod == http.MethodGet:
+		return domain.PermView, func(ctx context.Context) (int, any, error) {
+			active, queued, err := a.prayerSvc.Prayers(ctx)
+			return http.StatusOK, prayersResponse{Active: active, Queued: queued}, err
+		}
+
+	case len(parts) == action && parts[0] == "prayers" && parts[2] == "reassign" &&
+		req.HTTPMethod == http.MethodPost:
+		return domain.PermManagePrayers, func(ctx context.Context) (int, any, error) {
+			return a.reassignPrayer(ctx, admin, parts[1])
+		}
+
+	case len(parts) == action && parts[0] == "prayers" && parts[1] == "queued" &&
+		req.HTTPMethod == http.MethodDelete:
+		return domain.PermManagePrayers, func(ctx context.Context) (int, any, error) {
+			return a.cancelPrayer(ctx, admin, parts[2], true)
+		}
+
+	case len(parts) == item && parts[0] == "prayers" && req.HTTPMethod == http.MethodDelete:
+		return domain.PermManagePrayers, func(ctx context.Context) (int, any, error) {
+			return a.cancelPrayer(ctx, admin, parts[1], false)
+		}
+
+	case len(parts) == collection && parts[0] == "blocks" && req.HTTPMethod == http.MethodGet:
+		return domain.PermView, func(ctx context.Context) (int, any, error) {
+			entries, err := a.adminSvc.Blocks(ctx)
+			return http.StatusOK, entries, err
+		}
+
+	case len(parts) == collection && parts[0] == "blocks" && req.HTTPMethod == http.MethodPost:
+		return domain.PermBlock, func(ctx context.Context) (int, any, error) {
+			return a.block(ctx, admin, req.Body)
+		}
+
+	case len(parts) == item && parts[0] == "blocks" && req.HTTPMethod == http.MethodDelete:
+		return domain.PermBlock, func(ctx context.Context) (int, any, error) {
+			return http.StatusNoContent, nil, a.adminSvc.Unblock(ctx, admin.Phone, parts[1])
+		}
+
+	case len(parts) == collection && parts[0] == "announcements" && req.HTTPMethod == http.MethodPost:
+		return domain.PermBroadcast, func(ctx context.Context) (int, any, error) {
+			return a.announce(ctx, admin, req.Body)
+		}
+
+	case len(parts) == collection && parts[0] == "audit" && req.HTTPMethod == http.MethodGet:
+		return domain.PermManageAdmins, func(ctx context.Context) (int, any, error) {
+			query := req.QueryStringParameters
+			entries, err := a.adminSvc.AuditLog(ctx, query["admin"], query["target"])
+			return http.StatusOK, entries, err
+		}
+
+	default:
+		return "", nil
+	}
+}
+
+func (a *API) updateMember(ctx context.Context, admin domain.Member, phn string, body string) (int, any, error) {
+	var update memberUpdateRequest
+	if err := json.Unmarshal([]byte(body), &update); err != nil {
+		return 0, nil, apperr.WrapError(errBadRequest, "invalid json")
+	}
+	if update.Role != nil {
+		if err := service.Authorize(admin.Role, domain.PermManageAdmins); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	mem, err := a.adminSvc.UpdateMember(ctx, admin.Phone, phn, service.MemberUpdate{
+		Name:              update.Name,
+		WeeklyPrayerLimit: update.WeeklyPrayerLimit,
+		Role:              update.Role,
+	})
+	return http.StatusOK, mem, err
+}
+
+func (a *API) reassignPrayer(ctx context.Context, admin domain.Member, intercessorPhone string) (int, any, error) {
+	intercessor, err := a.prayerSvc.Reassign(ctx, intercessorPhone)
+	if err != nil {
+		return 0, nil, a.adminSvc.RecordFailure(
+			ctx, admin.Phone, domain.AuditActionReassign, intercessorPhone, "", err,
+		)
+	}
+
+	detail := "queued"
+	if intercessor != "" {
+		detail = "reassigned to " + intercessor
+	}
+	err = a.adminSvc.RecordAction(ctx, admin.Phone, domain.AuditActionReassign, intercessorPhone, detail)
+	return http.StatusOK, reassignResponse{Intercessor: intercessor, Queued: intercessor == ""}, err
+}
+
+func (a *API) cancelPrayer(ctx context.Context, admin domain.Member, key string, queued bool) (int, any, error) {
+	if err := a.prayerSvc.Cancel(ctx, key, queued); err != nil {
+		return 0, nil, a.adminSvc.RecordFailure(ctx, admin.Phone, domain.AuditActionCancel, key, "", err)
+	}
+
+	err := a.adminSvc.RecordAction(ctx, admin.Phone, domain.AuditActionCancel, key, "")
+	return http.StatusNoContent, nil, err
+}
+
+func (a *API) forget(ctx context.Context, admin domain.Member, phn string) (int, any, error) {
+	if err := a.privacySvc.Forget(ctx, phn); err != nil {
+		return 0, nil, a.adminSvc.RecordFailure(ctx, admin.Phone, domain.AuditActionForget, phn, "", err)
+	}
+
+	err := a.adminSvc.RecordAction(ctx, admin.Phone, domain.AuditActionForget, phn, "")
+	return http.StatusNoContent, nil, err
+}
+
+func (a *API) block(ctx context.Context, admin domain.Member, body string) (int, any, error) {
+	var req blockRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return 0, nil, apperr.WrapError(errBadRequest, "invalid json")
+	}
+
+	duration, ok := service.ParseBlockDuration(req.Duration)
+	if req.Duration != "" && !ok {
+		return 0, nil, apperr.WrapError(errBadRequest, "duration must look like 12h, 7d or 2w")
+	}
+
+	err := a.adminSvc.Block(ctx, admin.Phone, req.Phone, duration, req.Reason)
+	return http.StatusCreated, nil, err
+}
+
+func (a *API) announce(ctx context.Context, admin domain.Member, body string) (int, any, error) {
+	var req announcementRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return 0, nil, apperr.WrapError(errBadRequest, "invalid json")
+	}
+
+	sent, err := a.adminSvc.Announce(ctx, admin.Phone, req.Message, req.Phones)
+	return http.StatusOK, announcementResponse{Sent: sent}, err
+}
+
+// pathParts splits path into its unescaped segments, so that /members/%2B11234567890 becomes [members +11234567890].
+func pathParts(path string) ([]string, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range parts {
+		unescaped, err := url.PathUnescape(part)
+		if err != nil {
+			return nil, apperr.WrapError(errBadRequest, "invalid path")
+		}
+		parts[i] = unescaped
+	}
+	return parts, nil
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, errBadRequest), errors.Is(err, service.ErrInvalidPhone),
+		errors.Is(err, service.ErrInvalidMemberUpdate), errors.Is(err, service.ErrInvalidAuditQuery),
+		errors.Is(err, service.ErrEmptyAnnouncement):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrMemberNotFound), errors.Is(err, service.ErrPrayerNotFound),
+		errors.Is(err, service.ErrNotBlocked), errors.Is(err, service.ErrConsentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAlreadyBlocked), errors.Is(err, service.ErrLastOwner):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func respond(status int, body any) events.APIGatewayProxyResponse {
+	resp := events.APIGatewayProxyResponse{
+		StatusCode: status,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+	}
+	if body == nil {
+		return resp
+	}
+
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	resp.Body = string(encoded)
+	return resp
+}
@@ -0,0 +1,434 @@
+package adminapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/4JesusApps/prayertexter/internal/adminapi"
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/4JesusApps/prayertexter/internal/service"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	msgmocks "github.com/4JesusApps/prayertexter/internal/mocks/messaging"
+	repomocks "github.com/4JesusApps/prayertexter/internal/mocks/repository"
+)
+
+type APISuite struct {
+	suite.Suite
+	api          *adminapi.API
+	prayerSvc    *service.PrayerService
+	adminSvc     *service.AdminService
+	privacySvc   *service.PrivacyService
+	members      *repomocks.MockMemberRepository
+	blocked      *repomocks.MockBlockedPhonesRepository
+	audit        *repomocks.MockAuditRepository
+	intercessors *repomocks.MockIntercessorPhonesRepository
+	prayers      *repomocks.MockPrayerRepository
+	consents     *repomocks.MockConsentRecordRepository
+	completed    *repomocks.MockCompletedPrayerRepository
+	pending      *repomocks.MockPendingPrayerRepository
+	history      *repomocks.MockRequestHistoryRepository
+	transcripts  *repomocks.MockTranscriptRepository
+	deferred     *repomocks.MockDeferredMessageRepository
+	receipts     *repomocks.MockDeliveryReceiptRepository
+	sender       *msgmocks.MockMessageSender
+	ctx          context.Context
+}
+
+func (s *APISuite) SetupTest() {
+	s.members = repomocks.NewMockMemberRepository(s.T())
+	s.blocked = repomocks.NewMockBlockedPhonesRepository(s.T())
+	s.audit = repomocks.NewMockAuditRepository(s.T())
+	s.intercessors = repomocks.NewMockIntercessorPhonesRepository(s.T())
+	s.prayers = repomocks.NewMockPrayerRepository(s.T())
+	s.consents = repomocks.NewMockConsentRecordRepository(s.T())
+	s.completed = repomocks.NewMockCompletedPrayerRepository(s.T())
+	s.pending = repomocks.NewMockPendingPrayerRepository(s.T())
+	s.history = repomocks.NewMockRequestHistoryRepository(s.T())
+	s.transcripts = repomocks.NewMockTranscriptRepository(s.T())
+	s.deferred = repomocks.NewMockDeferredMessageRepository(s.T())
+	s.receipts = repomocks.NewMockDeliveryReceiptRepository(s.T())
+	s.sender = msgmocks.NewMockMessageSender(s.T())
+	s.ctx = context.Background()
+
+	cfg := config.Config{
+		AdminAPI:              config.AdminAPIConfig{Tokens: map[string]string{"+19999999999": "secret"}},
+		IntercessorsPerPrayer: 2,
+		PhoneRegion:           "US",
+	}
+	profanity := messaging.NewProfanity(config.ProfanityConfig{}, domain.ProfanityLists{})
+	memberSvc := service.NewMemberService(s.members, s.intercessors, s.prayers, s.consents, s.sender, profanity, cfg)
+	s.prayerSvc = service.NewPrayerService(
+		s.members, s.intercessors, s.prayers, s.completed, s.pending, s.history, s.sender, profanity, cfg,
+	)
+	s.adminSvc = service.NewAdminService(
+		s.members, s.blocked, s.prayers, s.audit, s.consents, s.sender, memberSvc, s.prayerSvc, cfg,
+	)
+	s.privacySvc = service.NewPrivacyService(
+		s.members, s.prayers, s.pending, s.completed, s.history, s.transcripts, s.deferred, s.receipts, s.sender,
+		memberSvc, s.prayerSvc, cfg,
+	)
+	s.api = adminapi.NewAPI(s.prayerSvc, s.adminSvc, s.privacySvc, cfg.AdminAPI)
+	s.members.EXPECT().Get(s.ctx, "+19999999999").Return(&domain.Member{
+		Phone: "+19999999999", SetupStatus: domain.MemberSetupComplete, Role: domain.RoleOwner,
+	}, nil).Maybe()
+}
+
+// apiWithRole returns an API whose token belongs to an admin with role.
+func (s *APISuite) apiWithRole(role domain.Role) *adminapi.API {
+	s.members.EXPECT().Get(s.ctx, "+18888888888").Return(&domain.Member{
+		Phone: "+18888888888", SetupStatus: domain.MemberSetupComplete, Role: role,
+	}, nil)
+	cfg := config.AdminAPIConfig{Tokens: map[string]string{"+18888888888": "secret"}}
+	return adminapi.NewAPI(s.prayerSvc, s.adminSvc, s.privacySvc, cfg)
+}
+
+func (s *APISuite) request(method, path, body string) events.APIGatewayProxyResponse {
+	return s.api.Handle(s.ctx, events.APIGatewayProxyRequest{
+		HTTPMethod: method,
+		Path:       path,
+		Body:       body,
+		Headers:    map[string]string{"Authorization": "Bearer secret"},
+	})
+}
+
+func (s *APISuite) TestUnauthorized() {
+	for _, header := range []string{"", "Bearer ", "Bearer wrong", "secret"} {
+		resp := s.api.Handle(s.ctx, events.APIGatewayProxyRequest{
+			HTTPMethod: http.MethodGet,
+			Path:       "/members",
+			Headers:    map[string]string{"Authorization": header},
+		})
+		s.Equal(http.StatusUnauthorized, resp.StatusCode, header)
+	}
+}
+
+func (s *APISuite) TestUnauthorized_NotMember() {
+	s.members.EXPECT().Get(s.ctx, "+17777777777").Return(&domain.Member{}, nil)
+	cfg := config.AdminAPIConfig{Tokens: map[string]string{"+17777777777": "gone"}}
+	api := adminapi.NewAPI(s.prayerSvc, s.adminSvc, s.privacySvc, cfg)
+
+	resp := api.Handle(s.ctx, events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/members",
+		Headers:    map[string]string{"Authorization": "Bearer gone"},
+	})
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+}
+
+func (s *APISuite) TestDemotedAdmin() {
+	api := s.apiWithRole(domain.RoleNone)
+
+	resp := api.Handle(s.ctx, events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/members",
+		Headers:    map[string]string{"Authorization": "Bearer secret"},
+	})
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+}
+
+func (s *APISuite) TestNotFoundRoute() {
+	resp := s.request(http.MethodGet, "/unknown", "")
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func (s *APISuite) TestListMembers() {
+	s.members.EXPECT().GetAll(s.ctx).Return([]domain.Member{
+		{Phone: "+11111111111", Name: "John"},
+		{Phone: "+12222222222", Name: "Jane"},
+	}, nil)
+
+	resp := s.api.Handle(s.ctx, events.APIGatewayProxyRequest{
+		HTTPMethod:            http.MethodGet,
+		Path:                  "/members",
+		QueryStringParameters: map[string]string{"q": "jane"},
+		Headers:               map[string]string{"authorization": "Bearer secret"},
+	})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var members []domain.Member
+	s.Require().NoError(json.Unmarshal([]byte(resp.Body), &members))
+	s.Equal([]domain.Member{{Phone: "+12222222222", Name: "Jane"}}, members)
+}
+
+func (s *APISuite) TestGetMember() {
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete,
+	}, nil)
+
+	resp := s.request(http.MethodGet, "/members/%2B11234567890", "")
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(resp.Body, `"Phone":"+11234567890"`)
+}
+
+func (s *APISuite) TestGetMember_NotFound() {
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{Phone: "+11234567890"}, nil)
+
+	resp := s.request(http.MethodGet, "/members/123-456-7890", "")
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func (s *APISuite) TestConsentExport() {
+	s.consents.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.ConsentRecord{
+		Phone: "+11234567890",
+		Events: []domain.ConsentEvent{
+			{Type: domain.ConsentOptIn, Date: "2024-01-01T00:00:00Z", Disclosure: messaging.DisclosureVersion},
+			{Type: domain.ConsentOptOut, Date: "2024-02-01T00:00:00Z", Keyword: "stop"},
+		},
+	}, nil)
+
+	resp := s.request(http.MethodGet, "/members/%2B11234567890/consent", "")
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var export service.ConsentExport
+	s.Require().NoError(json.Unmarshal([]byte(resp.Body), &export))
+	s.Require().Len(export.Events, 2)
+	s.Equal(messaging.MsgSignUpConfirmation, export.Events[0].DisclosureText)
+	s.Equal(domain.ConsentOptOut, export.Events[1].Type)
+	s.Empty(export.Events[1].DisclosureText)
+}
+
+func (s *APISuite) TestConsentExport_NotFound() {
+	s.consents.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.ConsentRecord{}, nil)
+
+	resp := s.request(http.MethodGet, "/members/%2B11234567890/consent", "")
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func (s *APISuite) TestUpdateMember() {
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete,
+	}, nil)
+	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool {
+		return m.Role == domain.RoleCoordinator && m.WeeklyPrayerLimit == 4
+	})).Return(nil)
+	s.audit.EXPECT().Save(s.ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.AdminPhone == "+19999999999" && e.Action == domain.AuditActionEdit
+	})).Return(nil)
+
+	resp := s.request(http.MethodPatch, "/members/+11234567890", `{"role": "coordinator", "weeklyPrayerLimit": 4}`)
+	s.Equal(http.StatusOK, resp.StatusCode)
+}
+
+func (s *APISuite) TestUpdateMember_InvalidRole() {
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete,
+	}, nil)
+
+	resp := s.request(http.MethodPatch, "/members/+11234567890", `{"role": "boss"}`)
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+func (s *APISuite) TestForbidden() {
+	api := s.apiWithRole(domain.RoleModerator)
+	tests := []struct {
+		method, path, body string
+	}{
+		{http.MethodPatch, "/members/+11234567890", `{"name": "Jane"}`},
+		{http.MethodPost, "/prayers/+11234567890/reassign", ""},
+		{http.MethodPost, "/announcements", `{"message": "hello"}`},
+		{http.MethodGet, "/audit", ""},
+	}
+
+	for _, tt := range tests {
+		resp := api.Handle(s.ctx, events.APIGatewayProxyRequest{
+			HTTPMethod: tt.method,
+			Path:       tt.path,
+			Body:       tt.body,
+			Headers:    map[string]string{"Authorization": "Bearer secret"},
+		})
+		s.Equal(http.StatusForbidden, resp.StatusCode, tt.path)
+	}
+}
+
+func (s *APISuite) TestUpdateMember_RoleNeedsManageAdmins() {
+	api := s.apiWithRole(domain.RoleCoordinator)
+
+	resp := api.Handle(s.ctx, events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodPatch,
+		Path:       "/members/+11234567890",
+		Body:       `{"role": "owner"}`,
+		Headers:    map[string]string{"Authorization": "Bearer secret"},
+	})
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+}
+
+func (s *APISuite) TestAnnounce() {
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", "hello").Return(nil)
+	s.audit.EXPECT().Save(s.ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.AdminPhone == "+19999999999" && e.Action == domain.AuditActionAnnounce
+	})).Return(nil)
+
+	resp := s.request(http.MethodPost, "/announcements", `{"message": "hello", "phones": ["123-456-7890"]}`)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`{"Sent": 1}`, resp.Body)
+}
+
+func (s *APISuite) TestUpdateMember_BadJSON() {
+	resp := s.request(http.MethodPatch, "/members/+11234567890", `{`)
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+func (s *APISuite) TestListPrayers() {
+	s.prayers.EXPECT().GetAll(s.ctx, false).Return([]domain.Prayer{{IntercessorPhone: "+11111111111"}}, nil)
+	s.prayers.EXPECT().GetAll(s.ctx, true).Return([]domain.Prayer{{IntercessorPhone: "queue-id"}}, nil)
+
+	resp := s.request(http.MethodGet, "/prayers", "")
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(resp.Body, `"Active":[{`)
+	s.Contains(resp.Body, `"IntercessorPhone":"queue-id"`)
+}
+
+func (s *APISuite) TestCancelQueuedPrayer() {
+	s.prayers.EXPECT().Get(s.ctx, "queue-id", true).Return(&domain.Prayer{Request: "pray"}, nil)
+	s.prayers.EXPECT().Delete(s.ctx, "queue-id", true).Return(nil)
+	s.audit.EXPECT().Save(s.ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionCancel && e.Target == "queue-id"
+	})).Return(nil)
+
+	resp := s.request(http.MethodDelete, "/prayers/queued/queue-id", "")
+	s.Equal(http.StatusNoContent, resp.StatusCode)
+}
+
+func (s *APISuite) TestCancelActivePrayer_NotFound() {
+	s.prayers.EXPECT().Get(s.ctx, "+11111111111", false).Return(&domain.Prayer{}, nil)
+	s.audit.EXPECT().Save(s.ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionCancel && e.Result == "failed: prayer not found"
+	})).Return(nil)
+
+	resp := s.request(http.MethodDelete, "/prayers/+11111111111", "")
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func (s *APISuite) TestReassignPrayer_Queued() {
+	s.prayers.EXPECT().Get(s.ctx, "+11111111111", false).Return(&domain.Prayer{
+		Request: "pray", Requestor: domain.MemberRef{Phone: "+19999999999"},
+	}, nil)
+	s.intercessors.EXPECT().Get(s.ctx).Return(&domain.IntercessorPhones{}, nil)
+	s.prayers.EXPECT().Delete(s.ctx, "+11111111111", false).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11111111111", messaging.MsgPrayerReassigned).Return(nil)
+	s.prayers.EXPECT().Save(s.ctx, mock.Anything, true).Return(nil)
+	s.audit.EXPECT().Save(s.ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionReassign && e.Detail == "queued"
+	})).Return(nil)
+
+	resp := s.request(http.MethodPost, "/prayers/+11111111111/reassign", "")
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`{"Intercessor":"","Queued":true}`, resp.Body)
+}
+
+func (s *APISuite) TestListBlocks() {
+	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{Phones: []string{"+11111111111"}}, nil)
+
+	resp := s.request(http.MethodGet, "/blocks", "")
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(resp.Body, `"Phone":"+11111111111"`)
+}
+
+func (s *APISuite) TestBlock() {
+	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{}, nil)
+	s.blocked.EXPECT().Save(s.ctx, mock.MatchedBy(func(bp *domain.BlockedPhones) bool {
+		entry, ok := bp.Entry("+447911123456")
+		return ok && entry.Reason == "spam" && entry.ExpiresAt != "" && entry.BlockedBy == "+19999999999"
+	})).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+447911123456").Return(&domain.Member{Phone: "+447911123456"}, nil)
+	s.members.EXPECT().Delete(s.ctx, "+447911123456").Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+447911123456", mock.Anything).Return(nil)
+	s.audit.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
+
+	resp := s.request(http.MethodPost, "/blocks", `{"phone": "+44 7911 123456", "duration": "7d", "reason": "spam"}`)
+	s.Equal(http.StatusCreated, resp.StatusCode)
+}
+
+func (s *APISuite) TestBlock_BadRequests() {
+	resp := s.request(http.MethodPost, "/blocks", `{"phone": "+11234567890", "duration": "forever"}`)
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	resp = s.request(http.MethodPost, "/blocks", `{"phone": "123"}`)
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+func (s *APISuite) TestBlock_AlreadyBlocked() {
+	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{Phones: []string{"+11234567890"}}, nil)
+
+	resp := s.request(http.MethodPost, "/blocks", `{"phone": "+11234567890"}`)
+	s.Equal(http.StatusConflict, resp.StatusCode)
+}
+
+func (s *APISuite) TestUnblock_NotBlocked() {
+	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{}, nil)
+
+	resp := s.request(http.MethodDelete, "/blocks/+11234567890", "")
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func (s *APISuite) TestRemoveMember() {
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete,
+	}, nil)
+	s.members.EXPECT().Delete(s.ctx, "+11234567890").Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgRemoveUser).Return(nil)
+	s.audit.EXPECT().Save(s.ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionRemove && e.Result == domain.AuditResultSuccess
+	})).Return(nil)
+
+	resp := s.request(http.MethodDelete, "/members/+11234567890", "")
+	s.Equal(http.StatusNoContent, resp.StatusCode)
+}
+
+func (s *APISuite) TestDeleteMemberData() {
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete,
+	}, nil)
+	s.members.EXPECT().Delete(s.ctx, "+11234567890").Return(nil)
+	s.prayers.EXPECT().GetAll(s.ctx, false).Return(nil, nil)
+	s.prayers.EXPECT().GetAll(s.ctx, true).Return(nil, nil)
+	s.pending.EXPECT().GetAll(s.ctx).Return(nil, nil)
+	s.completed.EXPECT().GetAll(s.ctx).Return(nil, nil)
+	s.history.EXPECT().Delete(s.ctx, "+11234567890").Return(nil)
+	s.deferred.EXPECT().GetAll(s.ctx).Return(nil, nil)
+	s.receipts.EXPECT().GetAll(s.ctx).Return(nil, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgDataDeleted).Return(nil)
+	s.transcripts.EXPECT().DeleteThread(s.ctx, "+11234567890").Return(nil)
+	s.audit.EXPECT().Save(s.ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionForget && e.Result == domain.AuditResultSuccess
+	})).Return(nil)
+
+	resp := s.request(http.MethodDelete, "/members/%2B11234567890/data", "")
+	s.Equal(http.StatusNoContent, resp.StatusCode)
+}
+
+func (s *APISuite) TestAuditLog() {
+	s.audit.EXPECT().GetByTarget(s.ctx, "+11234567890").Return([]domain.AuditEntry{
+		{ID: "1", Action: domain.AuditActionBlock, Target: "+11234567890", Timestamp: "2026-01-01T00:00:00Z"},
+		{ID: "2", Action: domain.AuditActionUnblock, Target: "+11234567890", Timestamp: "2026-01-02T00:00:00Z"},
+	}, nil)
+
+	resp := s.api.Handle(s.ctx, events.APIGatewayProxyRequest{
+		HTTPMethod:            http.MethodGet,
+		Path:                  "/audit",
+		QueryStringParameters: map[string]string{"target": "123-456-7890"},
+		Headers:               map[string]string{"Authorization": "Bearer secret"},
+	})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var entries []domain.AuditEntry
+	s.Require().NoError(json.Unmarshal([]byte(resp.Body), &entries))
+	s.Require().Len(entries, 2)
+	s.Equal("2", entries[0].ID)
+}
+
+func (s *APISuite) TestAuditLog_NoFilter() {
+	resp := s.request(http.MethodGet, "/audit", "")
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAPISuite(t *testing.T) {
+	suite.Run(t, new(APISuite))
+}
@@ -8,9 +8,106 @@ import (
 
 // Config holds all application configuration.
 type Config struct {
-	AWS                   AWSConfig
-	IntercessorsPerPrayer int
-	PrayerReminderHours   int
+	AdminAPI                AdminAPIConfig
+	AWS                     AWSConfig
+	Crisis                  CrisisConfig
+	Dashboard               DashboardConfig
+	DeliveryFailureLimit    int
+	IntercessorsPerPrayer   int
+	Moderation              ModerationConfig
+	PhoneRegion             string
+	PrayerReminderHours     int
+	Profanity               ProfanityConfig
+	Redaction               RedactionConfig
+	SignUp                  SignUpConfig
+	Throttle                ThrottleConfig
+	TranscriptRetentionDays int
+	WeeklyReport            WeeklyReportConfig
+}
+
+// AdminAPIConfig configures the admin API. Tokens maps each admin's phone, in E.164 format, to the bearer token they
+// present. A request acts as that admin, with the role saved on their member; when Tokens is empty every request is
+// rejected. When set from the environment, Tokens is a comma separated list of phone:token pairs.
+type AdminAPIConfig struct {
+	Tokens map[string]string
+}
+
+// CrisisConfig controls how prayer requests that suggest the requestor may be in danger are handled. A request that
+// contains one of Keywords, matched like moderation keywords, is answered straight away with Message, OnCall is alerted
+// and the request is sent to intercessors ahead of other requests. When OnCall is empty every admin who can moderate is
+// alerted. A request that also matches a moderation keyword is still held for review, marked urgent. When set from
+// the environment, Keywords and OnCall are comma separated lists.
+type CrisisConfig struct {
+	Keywords []string
+	Message  string
+	OnCall   []string
+}
+
+// DashboardConfig configures the read-only dashboard. Addr is the address it listens on when run locally. Every request
+// must present Username and Password using basic auth, and the dashboard refuses all requests until both are set.
+type DashboardConfig struct {
+	Addr     string
+	Username string
+	Password string
+}
+
+// ModerationConfig lists the words and phrases that hold a prayer request for an admin to review before it is sent to
+// intercessors. Matching ignores case and punctuation and only matches whole words. When set from the environment,
+// Keywords is a comma separated list.
+type ModerationConfig struct {
+	Keywords []string
+}
+
+// ProfanityConfig controls the profanity filter. Deny and Allow add words to and remove words from the built in list
+// for both names and prayer requests, while Name and Request configure each separately. When set from the environment,
+// every list is comma separated.
+type ProfanityConfig struct {
+	Deny    []string
+	Allow   []string
+	Name    ProfanityPolicyConfig
+	Request ProfanityPolicyConfig
+}
+
+// ProfanityPolicyConfig is the profanity policy for one kind of text. Mode is "reject" to refuse text that contains
+// profanity, "mask" to accept it but replace profane words with asterisks wherever the text is shown to others, or
+// "off". Deny and Allow add to the lists in ProfanityConfig.
+type ProfanityPolicyConfig struct {
+	Mode  string
+	Deny  []string
+	Allow []string
+}
+
+// RedactionConfig controls how prayer requests are redacted before they are sent to intercessors. Email addresses,
+// phone numbers and street addresses are always replaced. When Surnames is true, names written like "John Smith" are
+// also shortened to "John S.".
+type RedactionConfig struct {
+	Surnames bool
+}
+
+// SignUpConfig controls unfinished sign ups. A member who has not moved to the next sign up stage for NudgeHours is
+// reminded once to finish, and one who has not for ExpireHours is removed. Zero turns each off.
+type SignUpConfig struct {
+	NudgeHours  int
+	ExpireHours int
+}
+
+// ThrottleConfig limits how many prayer requests a member can send. DailyRequests and WeeklyRequests cap requests over
+// the last day and week, and a request at least DuplicateSimilarity alike, from 0 to 1, to one sent in the last week is
+// refused as a duplicate. A member who is refused EscalateAfter times in a week is reported to admins. Zero turns each
+// check off.
+type ThrottleConfig struct {
+	DailyRequests       int
+	WeeklyRequests      int
+	DuplicateSimilarity float64
+	EscalateAfter       int
+}
+
+// WeeklyReportConfig controls when statecontroller texts admins the weekly summary. It is sent by the run that falls on
+// Day (such as "monday") during Hour, in UTC, so Hour must be inside the statecontroller schedule. An empty Day turns
+// the report off.
+type WeeklyReportConfig struct {
+	Day  string
+	Hour int
 }
 
 type AWSConfig struct {
@@ -26,13 +123,52 @@ type DBConfig struct {
 	MemberTable            string
 	ActivePrayerTable      string
 	QueuedPrayerTable      string
+	CompletedPrayerTable   string
+	PendingPrayerTable     string
+	RequestHistoryTable    string
+	ConsentRecordTable     string
 	BlockedPhonesTable     string
+	ProfanityListsTable    string
 	IntercessorPhonesTable string
+	OptedOutPhonesTable    string
+	DeliveryReceiptTable   string
+	DeferredMessageTable   string
+	SendCountTable         string
+	MessagesTable          string
+	AdminAuditTable        string
 }
 
 type SMSConfig struct {
+	Provider  string
 	PhonePool string
 	Timeout   int
+	MaxParts  int
+	RateLimit RateLimitConfig
+	Twilio    TwilioConfig
+	Webhook   WebhookConfig
+}
+
+// RateLimitConfig controls outbound throughput across every function that sends messages. MessagesPerSecond and Burst
+// size the global limit, which allows Burst messages in each window of Burst / MessagesPerSecond seconds (0 messages
+// per second disables it). DailyPerPhone caps how many messages a single phone can receive per day (0 disables the
+// cap) and MaxWait is how many seconds a send may wait for the next window before the message is deferred instead.
+type RateLimitConfig struct {
+	MessagesPerSecond float64
+	Burst             int
+	DailyPerPhone     int
+	MaxWait           int
+}
+
+type TwilioConfig struct {
+	BaseURL    string
+	AccountSID string
+	AuthToken  string
+	From       string
+}
+
+type WebhookConfig struct {
+	URL   string
+	Token string
 }
 
 // Load initializes Viper and returns a Config struct.
@@ -41,6 +177,9 @@ func Load() Config {
 	initViper()
 
 	return Config{
+		AdminAPI: AdminAPIConfig{
+			Tokens: splitPairs(viper.GetString("conf.adminapi.tokens")),
+		},
 		AWS: AWSConfig{
 			Region:  viper.GetString("conf.aws.region"),
 			Backoff: viper.GetInt("conf.aws.backoff"),
@@ -50,48 +189,224 @@ func Load() Config {
 				MemberTable:            viper.GetString("conf.aws.db.member.table"),
 				ActivePrayerTable:      viper.GetString("conf.aws.db.prayer.activetable"),
 				QueuedPrayerTable:      viper.GetString("conf.aws.db.prayer.queuetable"),
+				CompletedPrayerTable:   viper.GetString("conf.aws.db.prayer.completedtable"),
+				PendingPrayerTable:     viper.GetString("conf.aws.db.prayer.pendingtable"),
+				RequestHistoryTable:    viper.GetString("conf.aws.db.requesthistory.table"),
+				ConsentRecordTable:     viper.GetString("conf.aws.db.consentrecord.table"),
 				BlockedPhonesTable:     viper.GetString("conf.aws.db.blockedphones.table"),
+				ProfanityListsTable:    viper.GetString("conf.aws.db.profanitylists.table"),
 				IntercessorPhonesTable: viper.GetString("conf.aws.db.intercessorphones.table"),
+				OptedOutPhonesTable:    viper.GetString("conf.aws.db.optedoutphones.table"),
+				DeliveryReceiptTable:   viper.GetString("conf.aws.db.deliveryreceipt.table"),
+				DeferredMessageTable:   viper.GetString("conf.aws.db.deferredmessage.table"),
+				SendCountTable:         viper.GetString("conf.aws.db.sendcount.table"),
+				MessagesTable:          viper.GetString("conf.aws.db.messages.table"),
+				AdminAuditTable:        viper.GetString("conf.aws.db.adminaudit.table"),
 			},
 			SMS: SMSConfig{
+				Provider:  viper.GetString("conf.aws.sms.provider"),
 				PhonePool: viper.GetString("conf.aws.sms.phonepool"),
 				Timeout:   viper.GetInt("conf.aws.sms.timeout"),
+				MaxParts:  viper.GetInt("conf.aws.sms.maxparts"),
+				RateLimit: RateLimitConfig{
+					MessagesPerSecond: viper.GetFloat64("conf.aws.sms.ratelimit.messagespersecond"),
+					Burst:             viper.GetInt("conf.aws.sms.ratelimit.burst"),
+					DailyPerPhone:     viper.GetInt("conf.aws.sms.ratelimit.dailyperphone"),
+					MaxWait:           viper.GetInt("conf.aws.sms.ratelimit.maxwait"),
+				},
+				Twilio: TwilioConfig{
+					BaseURL:    viper.GetString("conf.aws.sms.twilio.baseurl"),
+					AccountSID: viper.GetString("conf.aws.sms.twilio.accountsid"),
+					AuthToken:  viper.GetString("conf.aws.sms.twilio.authtoken"),
+					From:       viper.GetString("conf.aws.sms.twilio.from"),
+				},
+				Webhook: WebhookConfig{
+					URL:   viper.GetString("conf.aws.sms.webhook.url"),
+					Token: viper.GetString("conf.aws.sms.webhook.token"),
+				},
 			},
 		},
+		Crisis: CrisisConfig{
+			Keywords: splitList(viper.GetString("conf.crisis.keywords")),
+			Message:  viper.GetString("conf.crisis.message"),
+			OnCall:   splitList(viper.GetString("conf.crisis.oncall")),
+		},
+		Dashboard: DashboardConfig{
+			Addr:     viper.GetString("conf.dashboard.addr"),
+			Username: viper.GetString("conf.dashboard.username"),
+			Password: viper.GetString("conf.dashboard.password"),
+		},
+		DeliveryFailureLimit:  viper.GetInt("conf.deliveryfailurelimit"),
 		IntercessorsPerPrayer: viper.GetInt("conf.intercessorsperprayer"),
-		PrayerReminderHours:   viper.GetInt("conf.prayerreminderhours"),
+		Moderation: ModerationConfig{
+			Keywords: splitList(viper.GetString("conf.moderation.keywords")),
+		},
+		PhoneRegion:         viper.GetString("conf.phoneregion"),
+		PrayerReminderHours: viper.GetInt("conf.prayerreminderhours"),
+		Profanity: ProfanityConfig{
+			Deny:  splitList(viper.GetString("conf.profanity.deny")),
+			Allow: splitList(viper.GetString("conf.profanity.allow")),
+			Name: ProfanityPolicyConfig{
+				Mode:  viper.GetString("conf.profanity.name.mode"),
+				Deny:  splitList(viper.GetString("conf.profanity.name.deny")),
+				Allow: splitList(viper.GetString("conf.profanity.name.allow")),
+			},
+			Request: ProfanityPolicyConfig{
+				Mode:  viper.GetString("conf.profanity.request.mode"),
+				Deny:  splitList(viper.GetString("conf.profanity.request.deny")),
+				Allow: splitList(viper.GetString("conf.profanity.request.allow")),
+			},
+		},
+		Redaction: RedactionConfig{
+			Surnames: viper.GetBool("conf.redaction.surnames"),
+		},
+		SignUp: SignUpConfig{
+			NudgeHours:  viper.GetInt("conf.signup.nudgehours"),
+			ExpireHours: viper.GetInt("conf.signup.expirehours"),
+		},
+		Throttle: ThrottleConfig{
+			DailyRequests:       viper.GetInt("conf.throttle.dailyrequests"),
+			WeeklyRequests:      viper.GetInt("conf.throttle.weeklyrequests"),
+			DuplicateSimilarity: viper.GetFloat64("conf.throttle.duplicatesimilarity"),
+			EscalateAfter:       viper.GetInt("conf.throttle.escalateafter"),
+		},
+		TranscriptRetentionDays: viper.GetInt("conf.transcriptretentiondays"),
+		WeeklyReport: WeeklyReportConfig{
+			Day:  viper.GetString("conf.weeklyreport.day"),
+			Hour: viper.GetInt("conf.weeklyreport.hour"),
+		},
 	}
 }
 
 func initViper() {
 	defaults := map[string]any{
+		"adminapi": map[string]any{
+			"tokens": "",
+		},
 		"aws": map[string]any{
 			"region":  "us-west-1",
 			"backoff": 10,
 			"retry":   5,
 			"db": map[string]any{
 				"timeout": 60,
+				"adminaudit": map[string]any{
+					"table": "AdminAudit",
+				},
 				"blockedphones": map[string]any{
 					"table": "General",
 				},
+				"consentrecord": map[string]any{
+					"table": "ConsentRecord",
+				},
+				"deferredmessage": map[string]any{
+					"table": "DeferredMessage",
+				},
+				"deliveryreceipt": map[string]any{
+					"table": "DeliveryReceipt",
+				},
 				"intercessorphones": map[string]any{
 					"table": "General",
 				},
 				"member": map[string]any{
 					"table": "Member",
 				},
+				"messages": map[string]any{
+					"table": "Messages",
+				},
+				"optedoutphones": map[string]any{
+					"table": "General",
+				},
 				"prayer": map[string]any{
-					"activetable": "ActivePrayer",
-					"queuetable":  "QueuedPrayer",
+					"activetable":    "ActivePrayer",
+					"completedtable": "CompletedPrayer",
+					"pendingtable":   "PendingPrayer",
+					"queuetable":     "QueuedPrayer",
+				},
+				"profanitylists": map[string]any{
+					"table": "General",
+				},
+				"requesthistory": map[string]any{
+					"table": "RequestHistory",
+				},
+				"sendcount": map[string]any{
+					"table": "SendCount",
 				},
 			},
 			"sms": map[string]any{
+				"provider":  "pinpoint",
 				"phonepool": "dummy",
 				"timeout":   60,
+				"maxparts":  3,
+				"ratelimit": map[string]any{
+					"messagespersecond": 1.0,
+					"burst":             1,
+					"dailyperphone":     20,
+					"maxwait":           10,
+				},
+				"twilio": map[string]any{
+					"baseurl":    "https://api.twilio.com",
+					"accountsid": "",
+					"authtoken":  "",
+					"from":       "",
+				},
+				"webhook": map[string]any{
+					"url":   "",
+					"token": "",
+				},
 			},
 		},
+		"crisis": map[string]any{
+			"keywords": "suicide,suicidal,kill myself,end my life,want to die,hurt myself,self harm,self-harm," +
+				"overdose,abuse,abused,abusing",
+			"message": "If you are thinking about suicide or are in crisis, call or text 988 to reach the Suicide " +
+				"and Crisis Lifeline, any time. If you are being hurt or abused, call the Domestic Violence " +
+				"Hotline at 1-800-799-7233. If you are in immediate danger, call 911.",
+			"oncall": "",
+		},
+		"dashboard": map[string]any{
+			"addr":     ":8080",
+			"username": "",
+			"password": "",
+		},
+		"deliveryfailurelimit":  3,
 		"intercessorsperprayer": 2,
-		"prayerreminderhours":   3,
+		"moderation": map[string]any{
+			"keywords": "abuse,abused,abusing,assault,assaulted,molested,rape,raped,self harm,self-harm,overdose",
+		},
+		"phoneregion":         "US",
+		"prayerreminderhours": 3,
+		"profanity": map[string]any{
+			"deny":  "",
+			"allow": "jerk,ass,butt",
+			"name": map[string]any{
+				"mode":  "reject",
+				"deny":  "",
+				"allow": "",
+			},
+			"request": map[string]any{
+				"mode":  "reject",
+				"deny":  "",
+				"allow": "",
+			},
+		},
+		"redaction": map[string]any{
+			"surnames": false,
+		},
+		"signup": map[string]any{
+			"nudgehours":  24,
+			"expirehours": 168,
+		},
+		"throttle": map[string]any{
+			"dailyrequests":       3,
+			"weeklyrequests":      10,
+			"duplicatesimilarity": 0.8,
+			"escalateafter":       3,
+		},
+		"transcriptretentiondays": 90,
+		"weeklyreport": map[string]any{
+			"day":  "monday",
+			"hour": 16,
+		},
 	}
 
 	viper.SetDefault("conf", defaults)
@@ -99,3 +414,26 @@ func initViper() {
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
 }
+
+// splitPairs splits a comma separated list of key:value pairs into a map, dropping items without a key or a value.
+func splitPairs(list string) map[string]string {
+	pairs := map[string]string{}
+	for _, item := range splitList(list) {
+		key, value, _ := strings.Cut(item, ":")
+		if key, value = strings.TrimSpace(key), strings.TrimSpace(value); key != "" && value != "" {
+			pairs[key] = value
+		}
+	}
+	return pairs
+}
+
+// splitList splits a comma separated list, dropping empty items.
+func splitList(list string) []string {
+	var items []string
+	for item := range strings.SplitSeq(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
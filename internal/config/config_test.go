@@ -1,6 +1,8 @@
 package config_test
 
 import (
+	"maps"
+	"strings"
 	"testing"
 
 	"github.com/4JesusApps/prayertexter/internal/config"
@@ -10,6 +12,9 @@ func TestLoad(t *testing.T) {
 	t.Run("verify that Load returns a Config with expected default values", func(t *testing.T) {
 		cfg := config.Load()
 
+		if len(cfg.AdminAPI.Tokens) != 0 {
+			t.Errorf("expected no admin api tokens, got %v", cfg.AdminAPI.Tokens)
+		}
 		if cfg.AWS.Region != "us-west-1" {
 			t.Errorf("expected region us-west-1, got %v", cfg.AWS.Region)
 		}
@@ -31,24 +36,152 @@ func TestLoad(t *testing.T) {
 		if cfg.AWS.DB.QueuedPrayerTable != "QueuedPrayer" {
 			t.Errorf("expected queued prayer table QueuedPrayer, got %v", cfg.AWS.DB.QueuedPrayerTable)
 		}
+		if cfg.AWS.DB.CompletedPrayerTable != "CompletedPrayer" {
+			t.Errorf("expected completed prayer table CompletedPrayer, got %v", cfg.AWS.DB.CompletedPrayerTable)
+		}
+		if cfg.AWS.DB.PendingPrayerTable != "PendingPrayer" {
+			t.Errorf("expected pending prayer table PendingPrayer, got %v", cfg.AWS.DB.PendingPrayerTable)
+		}
+		if cfg.AWS.DB.RequestHistoryTable != "RequestHistory" {
+			t.Errorf("expected request history table RequestHistory, got %v", cfg.AWS.DB.RequestHistoryTable)
+		}
+		if cfg.AWS.DB.ConsentRecordTable != "ConsentRecord" {
+			t.Errorf("expected consent record table ConsentRecord, got %v", cfg.AWS.DB.ConsentRecordTable)
+		}
 		if cfg.AWS.DB.BlockedPhonesTable != "General" {
 			t.Errorf("expected blocked phones table General, got %v", cfg.AWS.DB.BlockedPhonesTable)
 		}
+		if cfg.AWS.DB.ProfanityListsTable != "General" {
+			t.Errorf("expected profanity lists table General, got %v", cfg.AWS.DB.ProfanityListsTable)
+		}
 		if cfg.AWS.DB.IntercessorPhonesTable != "General" {
 			t.Errorf("expected intercessor phones table General, got %v", cfg.AWS.DB.IntercessorPhonesTable)
 		}
+		if cfg.AWS.DB.DeliveryReceiptTable != "DeliveryReceipt" {
+			t.Errorf("expected delivery receipt table DeliveryReceipt, got %v", cfg.AWS.DB.DeliveryReceiptTable)
+		}
+		if cfg.AWS.DB.OptedOutPhonesTable != "General" {
+			t.Errorf("expected opted out phones table General, got %v", cfg.AWS.DB.OptedOutPhonesTable)
+		}
+		if cfg.AWS.DB.AdminAuditTable != "AdminAudit" {
+			t.Errorf("expected admin audit table AdminAudit, got %v", cfg.AWS.DB.AdminAuditTable)
+		}
+		if cfg.AWS.DB.MessagesTable != "Messages" {
+			t.Errorf("expected messages table Messages, got %v", cfg.AWS.DB.MessagesTable)
+		}
+		if cfg.AWS.DB.DeferredMessageTable != "DeferredMessage" {
+			t.Errorf("expected deferred message table DeferredMessage, got %v", cfg.AWS.DB.DeferredMessageTable)
+		}
+		if cfg.AWS.DB.SendCountTable != "SendCount" {
+			t.Errorf("expected send count table SendCount, got %v", cfg.AWS.DB.SendCountTable)
+		}
 		if cfg.AWS.SMS.PhonePool != "dummy" {
 			t.Errorf("expected phone pool dummy, got %v", cfg.AWS.SMS.PhonePool)
 		}
 		if cfg.AWS.SMS.Timeout != 60 {
 			t.Errorf("expected sms timeout 60, got %v", cfg.AWS.SMS.Timeout)
 		}
+		if cfg.AWS.SMS.Provider != "pinpoint" {
+			t.Errorf("expected sms provider pinpoint, got %v", cfg.AWS.SMS.Provider)
+		}
+		if cfg.AWS.SMS.Twilio.BaseURL != "https://api.twilio.com" {
+			t.Errorf("expected twilio base url https://api.twilio.com, got %v", cfg.AWS.SMS.Twilio.BaseURL)
+		}
+		if cfg.AWS.SMS.MaxParts != 3 {
+			t.Errorf("expected sms max parts 3, got %v", cfg.AWS.SMS.MaxParts)
+		}
+		if cfg.AWS.SMS.RateLimit.MessagesPerSecond != 1 {
+			t.Errorf("expected 1 message per second, got %v", cfg.AWS.SMS.RateLimit.MessagesPerSecond)
+		}
+		if cfg.AWS.SMS.RateLimit.Burst != 1 {
+			t.Errorf("expected rate limit burst 1, got %v", cfg.AWS.SMS.RateLimit.Burst)
+		}
+		if cfg.AWS.SMS.RateLimit.DailyPerPhone != 20 {
+			t.Errorf("expected daily per phone cap 20, got %v", cfg.AWS.SMS.RateLimit.DailyPerPhone)
+		}
+		if cfg.AWS.SMS.RateLimit.MaxWait != 10 {
+			t.Errorf("expected rate limit max wait 10, got %v", cfg.AWS.SMS.RateLimit.MaxWait)
+		}
+		if cfg.TranscriptRetentionDays != 90 {
+			t.Errorf("expected transcript retention days 90, got %v", cfg.TranscriptRetentionDays)
+		}
+		if cfg.Dashboard.Addr != ":8080" {
+			t.Errorf("expected dashboard addr :8080, got %v", cfg.Dashboard.Addr)
+		}
+		if len(cfg.Crisis.Keywords) != 12 || cfg.Crisis.Keywords[0] != "suicide" {
+			t.Errorf("expected 12 default crisis keywords starting with suicide, got %v", cfg.Crisis.Keywords)
+		}
+		if !strings.Contains(cfg.Crisis.Message, "988") {
+			t.Errorf("expected crisis message to include 988, got %v", cfg.Crisis.Message)
+		}
+		if len(cfg.Crisis.OnCall) != 0 {
+			t.Errorf("expected no on call phones, got %v", cfg.Crisis.OnCall)
+		}
+		if cfg.Dashboard.Username != "" || cfg.Dashboard.Password != "" {
+			t.Errorf("expected empty dashboard credentials, got %v", cfg.Dashboard.Username)
+		}
+		if cfg.DeliveryFailureLimit != 3 {
+			t.Errorf("expected delivery failure limit 3, got %v", cfg.DeliveryFailureLimit)
+		}
 		if cfg.IntercessorsPerPrayer != 2 {
 			t.Errorf("expected intercessors per prayer 2, got %v", cfg.IntercessorsPerPrayer)
 		}
+		if len(cfg.Moderation.Keywords) != 11 || cfg.Moderation.Keywords[8] != "self harm" {
+			t.Errorf("expected 11 default moderation keywords including self harm, got %v", cfg.Moderation.Keywords)
+		}
+		if cfg.PhoneRegion != "US" {
+			t.Errorf("expected phone region US, got %v", cfg.PhoneRegion)
+		}
 		if cfg.PrayerReminderHours != 3 {
 			t.Errorf("expected prayer reminder hours 3, got %v", cfg.PrayerReminderHours)
 		}
+		if len(cfg.Profanity.Deny) != 0 {
+			t.Errorf("expected no extra profanity, got %v", cfg.Profanity.Deny)
+		}
+		if len(cfg.Profanity.Allow) != 3 || cfg.Profanity.Allow[0] != "jerk" {
+			t.Errorf("expected allowed words [jerk ass butt], got %v", cfg.Profanity.Allow)
+		}
+		if cfg.Profanity.Name.Mode != "reject" {
+			t.Errorf("expected name profanity mode reject, got %v", cfg.Profanity.Name.Mode)
+		}
+		if cfg.Profanity.Request.Mode != "reject" {
+			t.Errorf("expected request profanity mode reject, got %v", cfg.Profanity.Request.Mode)
+		}
+		if len(cfg.Profanity.Name.Deny) != 0 || len(cfg.Profanity.Name.Allow) != 0 {
+			t.Errorf("expected no name profanity lists, got %v and %v", cfg.Profanity.Name.Deny,
+				cfg.Profanity.Name.Allow)
+		}
+		if len(cfg.Profanity.Request.Deny) != 0 || len(cfg.Profanity.Request.Allow) != 0 {
+			t.Errorf("expected no request profanity lists, got %v and %v", cfg.Profanity.Request.Deny,
+				cfg.Profanity.Request.Allow)
+		}
+		if cfg.Redaction.Surnames {
+			t.Errorf("expected surnames to not be redacted, got %v", cfg.Redaction.Surnames)
+		}
+		if cfg.SignUp.NudgeHours != 24 {
+			t.Errorf("expected sign up nudge hours 24, got %v", cfg.SignUp.NudgeHours)
+		}
+		if cfg.SignUp.ExpireHours != 168 {
+			t.Errorf("expected sign up expire hours 168, got %v", cfg.SignUp.ExpireHours)
+		}
+		if cfg.Throttle.DailyRequests != 3 {
+			t.Errorf("expected daily requests 3, got %v", cfg.Throttle.DailyRequests)
+		}
+		if cfg.Throttle.WeeklyRequests != 10 {
+			t.Errorf("expected weekly requests 10, got %v", cfg.Throttle.WeeklyRequests)
+		}
+		if cfg.Throttle.DuplicateSimilarity != 0.8 {
+			t.Errorf("expected duplicate similarity 0.8, got %v", cfg.Throttle.DuplicateSimilarity)
+		}
+		if cfg.Throttle.EscalateAfter != 3 {
+			t.Errorf("expected escalate after 3, got %v", cfg.Throttle.EscalateAfter)
+		}
+		if cfg.WeeklyReport.Day != "monday" {
+			t.Errorf("expected weekly report day monday, got %v", cfg.WeeklyReport.Day)
+		}
+		if cfg.WeeklyReport.Hour != 16 {
+			t.Errorf("expected weekly report hour 16, got %v", cfg.WeeklyReport.Hour)
+		}
 	})
 }
 
@@ -72,3 +205,35 @@ func TestEnvironmentalVariableOverride(t *testing.T) {
 		}
 	})
 }
+
+func TestModerationKeywordsOverride(t *testing.T) {
+	t.Setenv("PRAY_CONF_MODERATION_KEYWORDS", " harm , ,kill myself")
+
+	cfg := config.Load()
+	if len(cfg.Moderation.Keywords) != 2 || cfg.Moderation.Keywords[1] != "kill myself" {
+		t.Errorf("expected keywords [harm kill myself], got %v", cfg.Moderation.Keywords)
+	}
+}
+
+func TestAdminAPITokensOverride(t *testing.T) {
+	t.Setenv("PRAY_CONF_ADMINAPI_TOKENS", "+11234567890:abc:123, +19999999999: def ,+18888888888:")
+
+	cfg := config.Load()
+	want := map[string]string{"+11234567890": "abc:123", "+19999999999": "def"}
+	if !maps.Equal(cfg.AdminAPI.Tokens, want) {
+		t.Errorf("expected admin api tokens %v, got %v", want, cfg.AdminAPI.Tokens)
+	}
+}
+
+func TestProfanityOverride(t *testing.T) {
+	t.Setenv("PRAY_CONF_PROFANITY_REQUEST_MODE", "mask")
+	t.Setenv("PRAY_CONF_PROFANITY_NAME_DENY", "pastor,reverend")
+
+	cfg := config.Load()
+	if cfg.Profanity.Request.Mode != "mask" {
+		t.Errorf("expected request profanity mode mask, got %v", cfg.Profanity.Request.Mode)
+	}
+	if len(cfg.Profanity.Name.Deny) != 2 || cfg.Profanity.Name.Deny[1] != "reverend" {
+		t.Errorf("expected name deny list [pastor reverend], got %v", cfg.Profanity.Name.Deny)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Package dashboard serves a read-only, server-rendered page that shows ministry leaders how PrayerTexter is doing:
+member and intercessor counts, queue depth and age, prayers prayed per week and the intercessors who have gone the
+longest without praying for their active prayer. It is a plain net/http handler so that it can run locally or behind
+API Gateway in a Lambda.
+*/
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"embed"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/apperr"
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/4JesusApps/prayertexter/internal/service"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+//go:embed dashboard.html
+var templates embed.FS
+
+var pageTmpl = template.Must(template.New("dashboard.html").Funcs(template.FuncMap{
+	"age":  messaging.FormatAge,
+	"date": func(t time.Time) string { return t.Format("Jan 2") },
+}).ParseFS(templates, "dashboard.html"))
+
+type Dashboard struct {
+	reports *service.ReportService
+	cfg     config.DashboardConfig
+}
+
+func NewDashboard(reports *service.ReportService, cfg config.DashboardConfig) *Dashboard {
+	return &Dashboard{
+		reports: reports,
+		cfg:     cfg,
+	}
+}
+
+// ServeHTTP renders the dashboard for GET / and rejects everything else.
+func (d *Dashboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !d.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="prayertexter"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	report, err := d.reports.Report(r.Context(), time.Now())
+	if err != nil {
+		apperr.LogError(r.Context(), err, "failed to build dashboard report")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	var page bytes.Buffer
+	if err = pageTmpl.Execute(&page, report); err != nil {
+		apperr.LogError(r.Context(), err, "failed to render dashboard")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(page.Bytes())
+}
+
+// HandleLambda serves an API Gateway request with ServeHTTP.
+func (d *Dashboard) HandleLambda(
+	ctx context.Context,
+	req events.APIGatewayProxyRequest,
+) (events.APIGatewayProxyResponse, error) {
+	r, err := http.NewRequestWithContext(ctx, req.HTTPMethod, req.Path, strings.NewReader(req.Body))
+	if err != nil {
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusBadRequest}, nil
+	}
+	for key, value := range req.Headers {
+		r.Header.Set(key, value)
+	}
+
+	w := &responseRecorder{header: http.Header{}, status: http.StatusOK}
+	d.ServeHTTP(w, r)
+
+	headers := make(map[string]string, len(w.header))
+	for key := range w.header {
+		headers[key] = w.header.Get(key)
+	}
+	return events.APIGatewayProxyResponse{
+		StatusCode: w.status,
+		Headers:    headers,
+		Body:       w.body.String(),
+	}, nil
+}
+
+// authorized reports whether r presents the configured basic auth credentials. Every request is refused until both a
+// username and a password are configured.
+func (d *Dashboard) authorized(r *http.Request) bool {
+	if d.cfg.Username == "" || d.cfg.Password == "" {
+		return false
+	}
+
+	username, password, ok := r.BasicAuth()
+	return ok &&
+		subtle.ConstantTimeCompare([]byte(username), []byte(d.cfg.Username)) == 1 &&
+		subtle.ConstantTimeCompare([]byte(password), []byte(d.cfg.Password)) == 1
+}
+
+// responseRecorder collects what ServeHTTP writes so that it can be returned to API Gateway.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
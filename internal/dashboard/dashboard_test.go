@@ -0,0 +1,142 @@
+package dashboard_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/dashboard"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/service"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	repomocks "github.com/4JesusApps/prayertexter/internal/mocks/repository"
+)
+
+type DashboardSuite struct {
+	suite.Suite
+	members   *repomocks.MockMemberRepository
+	prayers   *repomocks.MockPrayerRepository
+	completed *repomocks.MockCompletedPrayerRepository
+	reports   *service.ReportService
+	dash      *dashboard.Dashboard
+}
+
+func (s *DashboardSuite) SetupTest() {
+	s.members = repomocks.NewMockMemberRepository(s.T())
+	s.prayers = repomocks.NewMockPrayerRepository(s.T())
+	s.completed = repomocks.NewMockCompletedPrayerRepository(s.T())
+	s.reports = service.NewReportService(s.members, s.prayers, s.completed)
+	s.dash = dashboard.NewDashboard(s.reports, config.DashboardConfig{Username: "leader", Password: "secret"})
+}
+
+// request returns a request for path that presents the dashboard credentials.
+func (s *DashboardSuite) request(method, path string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	req.SetBasicAuth("leader", "secret")
+	return req
+}
+
+func (s *DashboardSuite) expectReport() {
+	s.members.EXPECT().GetAll(mock.Anything).Return([]domain.Member{
+		{Phone: "+11111111111", SetupStatus: domain.MemberSetupComplete, Intercessor: true},
+	}, nil)
+	s.prayers.EXPECT().GetAll(mock.Anything, false).Return([]domain.Prayer{
+		{IntercessorPhone: "+11111111111", Intercessor: domain.MemberRef{Name: "<b>Bob</b>"}, ReminderCount: 3},
+	}, nil)
+	s.prayers.EXPECT().GetAll(mock.Anything, true).Return([]domain.Prayer{
+		{IntercessorPhone: "queue-id", RequestDate: time.Now().Add(-50 * time.Hour).Format(time.RFC3339)},
+	}, nil)
+	s.completed.EXPECT().GetAll(mock.Anything).Return([]domain.CompletedPrayer{
+		{ID: "1", CompletedDate: time.Now().Format(time.RFC3339)},
+	}, nil)
+}
+
+func (s *DashboardSuite) TestServeHTTP() {
+	s.expectReport()
+
+	w := httptest.NewRecorder()
+	s.dash.ServeHTTP(w, s.request(http.MethodGet, "/"))
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Header().Get("Content-Type"), "text/html")
+	body := w.Body.String()
+	s.Contains(body, "queued prayers, oldest 2d")
+	s.Contains(body, "<td>&lt;b&gt;Bob&lt;/b&gt;</td><td>&#43;11111111111</td><td>3</td>")
+	s.Contains(body, "<td>1</td></tr>")
+}
+
+func (s *DashboardSuite) TestServeHTTP_BasicAuth() {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.SetBasicAuth("leader", "wrong")
+	s.dash.ServeHTTP(w, req)
+	s.Equal(http.StatusUnauthorized, w.Code)
+	s.NotEmpty(w.Header().Get("WWW-Authenticate"))
+
+	w = httptest.NewRecorder()
+	s.dash.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	s.Equal(http.StatusUnauthorized, w.Code)
+
+	s.expectReport()
+	w = httptest.NewRecorder()
+	s.dash.ServeHTTP(w, s.request(http.MethodGet, "/"))
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *DashboardSuite) TestServeHTTP_Unconfigured() {
+	for _, cfg := range []config.DashboardConfig{{}, {Username: "leader"}} {
+		dash := dashboard.NewDashboard(s.reports, cfg)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth(cfg.Username, "")
+		dash.ServeHTTP(w, req)
+		s.Equal(http.StatusUnauthorized, w.Code)
+	}
+}
+
+func (s *DashboardSuite) TestServeHTTP_NotFoundAndMethod() {
+	w := httptest.NewRecorder()
+	s.dash.ServeHTTP(w, s.request(http.MethodGet, "/members"))
+	s.Equal(http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	s.dash.ServeHTTP(w, s.request(http.MethodPost, "/"))
+	s.Equal(http.StatusMethodNotAllowed, w.Code)
+}
+
+func (s *DashboardSuite) TestServeHTTP_ReportError() {
+	s.members.EXPECT().GetAll(mock.Anything).Return(nil, errors.New("boom"))
+
+	w := httptest.NewRecorder()
+	s.dash.ServeHTTP(w, s.request(http.MethodGet, "/"))
+	s.Equal(http.StatusInternalServerError, w.Code)
+	s.NotContains(w.Body.String(), "boom")
+}
+
+func (s *DashboardSuite) TestHandleLambda() {
+	s.expectReport()
+
+	req := s.request(http.MethodGet, "/")
+	resp, err := s.dash.HandleLambda(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/",
+		Headers:    map[string]string{"Authorization": req.Header.Get("Authorization")},
+	})
+
+	s.Require().NoError(err)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(resp.Headers["Content-Type"], "text/html")
+	s.Contains(resp.Body, "PrayerTexter")
+}
+
+func TestDashboardSuite(t *testing.T) {
+	suite.Run(t, new(DashboardSuite))
+}
@@ -0,0 +1,40 @@
+package domain
+
+const (
+	AuditActionBlock       = "block"
+	AuditActionUnblock     = "unblock"
+	AuditActionExpireBlock = "expire block"
+	AuditActionStats       = "stats"
+	AuditActionQueue       = "queue"
+	AuditActionLookup      = "lookup"
+	AuditActionPromote     = "promote"
+	AuditActionDemote      = "demote"
+	AuditActionRemove      = "remove"
+	AuditActionForget      = "delete data"
+	AuditActionAnnounce    = "announce"
+	AuditActionEdit        = "edit"
+	AuditActionReassign    = "reassign prayer"
+	AuditActionCancel      = "cancel prayer"
+	AuditActionApprove     = "approve prayer"
+	AuditActionReject      = "reject prayer"
+)
+
+// AuditSystemActor is used as the admin phone for actions taken automatically, such as expiring blocks.
+const AuditSystemActor = "system"
+
+// AuditResultSuccess is the Result of an action that completed. Failed actions have a Result of "failed: " followed by
+// the error.
+const AuditResultSuccess = "success"
+
+// AuditEntry records a single admin action. AdminPhone is the actor, Target is the phone or prayer the action was taken
+// against, if any, and Detail holds the action's parameters, such as a block reason. Entries are never changed once
+// written. Target is left out of the item when empty so that untargeted actions stay out of the target index.
+type AuditEntry struct {
+	ID         string
+	AdminPhone string
+	Action     string
+	Target     string `dynamodbav:",omitempty"`
+	Detail     string
+	Result     string
+	Timestamp  string
+}
@@ -0,0 +1,36 @@
+package domain
+
+const (
+	ConsentOptIn  = "OPT IN"
+	ConsentOptOut = "OPT OUT"
+	// ConsentMethodKeyword is consent given or withdrawn by texting a keyword, such as pray or stop, to PrayerTexter.
+	ConsentMethodKeyword = "SMS KEYWORD"
+)
+
+// ConsentRecord is every opt in and opt out by a phone, oldest first. It is kept apart from the member so that it
+// outlives the member when they opt out, and is exported for carrier audits.
+type ConsentRecord struct {
+	Phone  string
+	Events []ConsentEvent
+}
+
+// ConsentEvent is a single opt in or opt out. Keyword is the text that triggered it and Disclosure is the version of
+// the opt in disclosure that was shown, which is only set for opt ins. Date is RFC3339.
+type ConsentEvent struct {
+	Type       string
+	Date       string
+	Method     string
+	Keyword    string
+	Disclosure string
+}
+
+// OptOuts returns the dates of every opt out in the record, oldest first.
+func (c *ConsentRecord) OptOuts() []string {
+	var dates []string
+	for _, event := range c.Events {
+		if event.Type == ConsentOptOut {
+			dates = append(dates, event.Date)
+		}
+	}
+	return dates
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "slices"
+
+// DeliveryReceipt is the most recent delivery status reported by the SMS provider for a single outbound message.
+type DeliveryReceipt struct {
+	MessageID   string
+	Phone       string
+	Status      string
+	Description string
+	Timestamp   string
+}
+
+const (
+	DeliveryStatusDelivered          = "TEXT_DELIVERED"
+	DeliveryStatusInvalid            = "TEXT_INVALID"
+	DeliveryStatusUnreachable        = "TEXT_UNREACHABLE"
+	DeliveryStatusCarrierUnreachable = "TEXT_CARRIER_UNREACHABLE"
+)
+
+// IsHardFailure reports whether the receipt indicates the phone itself can not receive texts (landline, disconnected
+// number, etc) as opposed to a temporary problem that may succeed on a later attempt.
+func (r DeliveryReceipt) IsHardFailure() bool {
+	return slices.Contains([]string{
+		DeliveryStatusInvalid,
+		DeliveryStatusUnreachable,
+		DeliveryStatusCarrierUnreachable,
+	}, r.Status)
+}
+
+func (r DeliveryReceipt) IsDelivered() bool {
+	return r.Status == DeliveryStatusDelivered
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// RequestHistory holds a member's recent prayer requests and the times they were throttled for sending too many.
+// PrayerService uses it to limit how often a member can send requests and to catch repeated requests.
+type RequestHistory struct {
+	Phone    string
+	Requests []RequestRecord
+	Strikes  []string
+}
+
+// RequestRecord is a prayer request a member sent. Date is RFC3339.
+type RequestRecord struct {
+	Date string
+	Text string
+}
+
+// Prune drops requests and strikes from before since. Entries with an unparsable time are logged and dropped.
+func (h *RequestHistory) Prune(since time.Time) {
+	h.Requests = slices.DeleteFunc(h.Requests, func(r RequestRecord) bool { return before(r.Date, since) })
+	h.Strikes = slices.DeleteFunc(h.Strikes, func(s string) bool { return before(s, since) })
+}
+
+// CountSince returns how many requests were sent at or after since.
+func (h *RequestHistory) CountSince(since time.Time) int {
+	count := 0
+	for _, r := range h.Requests {
+		if !before(r.Date, since) {
+			count++
+		}
+	}
+	return count
+}
+
+func before(date string, t time.Time) bool {
+	parsed, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		slog.Warn("invalid request history time", "time", date)
+		return true
+	}
+	return parsed.Before(t)
+}
@@ -0,0 +1,32 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/domain"
+)
+
+func TestRequestHistory(t *testing.T) {
+	now := time.Now()
+	history := domain.RequestHistory{
+		Requests: []domain.RequestRecord{
+			{Date: now.Add(-8 * 24 * time.Hour).Format(time.RFC3339), Text: "old"},
+			{Date: now.Add(-2 * 24 * time.Hour).Format(time.RFC3339), Text: "this week"},
+			{Date: now.Add(-time.Hour).Format(time.RFC3339), Text: "today"},
+			{Date: "invalid", Text: "invalid"},
+		},
+		Strikes: []string{now.Add(-8 * 24 * time.Hour).Format(time.RFC3339), now.Format(time.RFC3339)},
+	}
+
+	history.Prune(now.Add(-7 * 24 * time.Hour))
+	if len(history.Requests) != 2 || history.Requests[0].Text != "this week" {
+		t.Errorf("expected requests from this week to be kept, got %+v", history.Requests)
+	}
+	if len(history.Strikes) != 1 {
+		t.Errorf("expected 1 strike to be kept, got %v", history.Strikes)
+	}
+	if got := history.CountSince(now.Add(-24 * time.Hour)); got != 1 {
+		t.Errorf("expected 1 request in the last day, got %v", got)
+	}
+}
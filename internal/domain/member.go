@@ -1,13 +1,27 @@
 package domain
 
 type Member struct {
-	Administrator     bool
+	// ConsentDate, ConsentMethod and ConsentDisclosure record when and how the member opted in and which version of
+	// the opt in disclosure they were shown. OptOuts holds the dates of earlier opt outs by the same phone. The full
+	// history is kept in the phone's ConsentRecord.
+	ConsentDate       string
+	ConsentDisclosure string
+	ConsentMethod     string
+	DeliveryFailures  int
+	Inactive          bool
 	Intercessor       bool
 	Name              string
+	OptOuts           []string
 	Phone             string
 	PrayerCount       int
+	Role              Role
+	// SetupDate is when the member last moved to a new sign up stage, in RFC3339. SetupNudged records whether they
+	// have been reminded to finish since then.
+	SetupDate         string
+	SetupNudged       bool
 	SetupStage        int
 	SetupStatus       string
+	SignUpDate        string
 	WeeklyPrayerDate  string
 	WeeklyPrayerLimit int
 }
@@ -20,3 +34,8 @@ const (
 	MemberSignUpStepThree = 3
 	MemberSignUpStepFinal = 99
 )
+
+// Ref returns a reference to the member for use in prayers.
+func (m *Member) Ref() MemberRef {
+	return MemberRef{Name: m.Name, Phone: m.Phone}
+}
@@ -0,0 +1,29 @@
+package domain
+
+const (
+	DeferReasonDailyCap  = "daily cap"
+	DeferReasonRateLimit = "rate limit"
+)
+
+// DeferredMessage is an outbound text message that was held back by the rate limiter and will be sent later by the
+// statecontroller.
+type DeferredMessage struct {
+	ID      string
+	Phone   string
+	Body    string
+	Created string
+	Reason  string
+}
+
+// SendCount tracks how many messages have been sent in Period under Phone. Per phone counts use the phone and the UTC
+// day (YYYY-MM-DD) as the period; the global rate limit uses SendCountGlobal and a numbered time window. ExpiresAt is
+// the DynamoDB TTL attribute, in unix seconds.
+type SendCount struct {
+	Phone     string
+	Period    string
+	Count     int
+	ExpiresAt int64
+}
+
+// SendCountGlobal is the SendCount key that all outbound messages count against for the global rate limit.
+const SendCountGlobal = "GLOBAL"
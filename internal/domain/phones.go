@@ -4,14 +4,36 @@ import (
 	"log/slog"
 	"math/rand/v2"
 	"slices"
+	"time"
 )
 
+// BlockedPhones is the list of phones that may not use PrayerTexter. Phones is the authoritative list; Entries holds
+// details about how each phone was blocked. Phones blocked before entries existed have no entry.
 type BlockedPhones struct {
+	Key     string
+	Phones  []string
+	Entries []BlockEntry
+}
+
+// BlockEntry records who blocked a phone, when and why. ExpiresAt is empty for permanent blocks. Times are RFC3339.
+type BlockEntry struct {
+	Phone     string
+	BlockedBy string
+	BlockedAt string
+	Reason    string
+	ExpiresAt string
+}
+
+type IntercessorPhones struct {
 	Key    string
 	Phones []string
 }
 
-type IntercessorPhones struct {
+// OptedOutPhones lists the phones that texted "cancel" or "stop". The opt out check middleware reads it to drop every
+// later message to them, so that a STOP is honored by all senders and not only by removing the member: announcements,
+// reminders and replies to requests already made would otherwise still reach the phone. A phone is removed from the
+// list when it texts "pray" to sign up again.
+type OptedOutPhones struct {
 	Key    string
 	Phones []string
 }
@@ -23,8 +45,46 @@ func (b *BlockedPhones) AddPhone(phone string) {
 	b.Phones = append(b.Phones, phone)
 }
 
+// Block adds entry.Phone to the block list, replacing any previous entry for the same phone.
+func (b *BlockedPhones) Block(entry BlockEntry) {
+	b.AddPhone(entry.Phone)
+	b.Entries = slices.DeleteFunc(b.Entries, func(e BlockEntry) bool { return e.Phone == entry.Phone })
+	b.Entries = append(b.Entries, entry)
+}
+
 func (b *BlockedPhones) RemovePhone(phone string) {
 	b.Phones = slices.DeleteFunc(b.Phones, func(s string) bool { return s == phone })
+	b.Entries = slices.DeleteFunc(b.Entries, func(e BlockEntry) bool { return e.Phone == phone })
+}
+
+// Entry returns the block entry for phone, if there is one.
+func (b *BlockedPhones) Entry(phone string) (BlockEntry, bool) {
+	i := slices.IndexFunc(b.Entries, func(e BlockEntry) bool { return e.Phone == phone })
+	if i < 0 {
+		return BlockEntry{}, false
+	}
+	return b.Entries[i], true
+}
+
+// Expired returns the phones whose blocks expired at or before now. Entries with an unparsable expiry are logged and
+// treated as permanent.
+func (b *BlockedPhones) Expired(now time.Time) []string {
+	var phones []string
+	for _, entry := range b.Entries {
+		if entry.ExpiresAt == "" {
+			continue
+		}
+
+		expiresAt, err := time.Parse(time.RFC3339, entry.ExpiresAt)
+		if err != nil {
+			slog.Warn("invalid block expiry", "phone", entry.Phone, "expiresAt", entry.ExpiresAt)
+			continue
+		}
+		if !expiresAt.After(now) {
+			phones = append(phones, entry.Phone)
+		}
+	}
+	return phones
 }
 
 func (i *IntercessorPhones) AddPhone(phone string) {
@@ -38,6 +98,17 @@ func (i *IntercessorPhones) RemovePhone(phone string) {
 	i.Phones = slices.DeleteFunc(i.Phones, func(s string) bool { return s == phone })
 }
 
+func (o *OptedOutPhones) AddPhone(phone string) {
+	if slices.Contains(o.Phones, phone) {
+		return
+	}
+	o.Phones = append(o.Phones, phone)
+}
+
+func (o *OptedOutPhones) RemovePhone(phone string) {
+	o.Phones = slices.DeleteFunc(o.Phones, func(s string) bool { return s == phone })
+}
+
 func (i *IntercessorPhones) GenRandPhones(intercessorsPerPrayer int) []string {
 	if len(i.Phones) == 0 {
 		slog.Warn("unable to generate phones, phone list is empty")
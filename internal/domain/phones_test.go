@@ -2,6 +2,7 @@ package domain_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/4JesusApps/prayertexter/internal/domain"
 )
@@ -101,3 +102,33 @@ func TestRemovePhone(t *testing.T) {
 		}
 	})
 }
+
+func TestBlockedPhonesEntries(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	bp := &domain.BlockedPhones{Phones: []string{"+10000000000"}}
+
+	bp.Block(domain.BlockEntry{Phone: "+11111111111", Reason: "spam"})
+	bp.Block(domain.BlockEntry{Phone: "+12222222222", ExpiresAt: now.Add(-time.Hour).Format(time.RFC3339)})
+	bp.Block(domain.BlockEntry{Phone: "+13333333333", ExpiresAt: now.Add(time.Hour).Format(time.RFC3339)})
+	bp.Block(domain.BlockEntry{Phone: "+11111111111", Reason: "abuse"})
+
+	if len(bp.Phones) != 4 || len(bp.Entries) != 3 {
+		t.Fatalf("expected 4 phones and 3 entries, got %v and %v", bp.Phones, bp.Entries)
+	}
+	if entry, ok := bp.Entry("+11111111111"); !ok || entry.Reason != "abuse" {
+		t.Errorf("expected replaced entry with reason abuse, got %+v", entry)
+	}
+	if _, ok := bp.Entry("+10000000000"); ok {
+		t.Error("expected legacy phone to have no entry")
+	}
+
+	expired := bp.Expired(now)
+	if len(expired) != 1 || expired[0] != "+12222222222" {
+		t.Errorf("expected only +12222222222 to be expired, got %v", expired)
+	}
+
+	bp.RemovePhone("+12222222222")
+	if _, ok := bp.Entry("+12222222222"); ok || len(bp.Phones) != 3 {
+		t.Errorf("expected +12222222222 to be fully removed, got %v and %v", bp.Phones, bp.Entries)
+	}
+}
@@ -1,10 +1,49 @@
 package domain
 
+// AnonymousName is the name shown for members who did not give one and on requests sent with #anon.
+const AnonymousName = "Anonymous"
+
+// MemberRef refers to a member from a prayer by phone, with the name shown to other members. Name is a copy taken when
+// the prayer was made; repositories refresh it from the member on read, except for anonymous requests.
+type MemberRef struct {
+	Name  string
+	Phone string
+}
+
+// Prayer is a prayer request assigned to an intercessor, or queued until one is available. Priority is set on requests
+// that suggest the requestor may be in danger, which are assigned before other queued prayers.
 type Prayer struct {
-	Intercessor      Member
+	Intercessor      MemberRef
 	IntercessorPhone string
+	Priority         bool
 	ReminderCount    int
 	ReminderDate     string
 	Request          string
-	Requestor        Member
+	RequestDate      string
+	Requestor        MemberRef
+}
+
+// CompletedPrayer records a prayer that an intercessor confirmed they prayed for. It keeps only what is needed to
+// report on how prayers are being answered, not the request itself.
+type CompletedPrayer struct {
+	ID               string
+	IntercessorPhone string
+	IntercessorName  string
+	RequestorPhone   string
+	RequestDate      string
+	CompletedDate    string
+	ReminderCount    int
+}
+
+// PendingPrayer is a prayer request that matched a moderation rule. It is held until an admin approves, edits or
+// rejects it, and only then is it sent to intercessors. Rule is the keyword that the request matched.
+type PendingPrayer struct {
+	ID          string
+	Request     string
+	RequestDate string
+	Requestor   MemberRef
+	Rule        string
+	// Priority is set when the request also matched a crisis keyword. It is reviewed first and sent to intercessors
+	// marked urgent once approved.
+	Priority bool
 }
@@ -0,0 +1,9 @@
+package domain
+
+// ProfanityLists holds the words admins have added to or removed from the profanity filter without a deploy. They are
+// combined with the lists in config when the filter is built.
+type ProfanityLists struct {
+	Key   string
+	Deny  []string
+	Allow []string
+}
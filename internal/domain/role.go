@@ -0,0 +1,68 @@
+package domain
+
+// Role is the admin role a member holds. Members without a role are not admins.
+type Role string
+
+const (
+	RoleNone        Role = ""
+	RoleModerator   Role = "moderator"
+	RoleCoordinator Role = "coordinator"
+	RoleOwner       Role = "owner"
+)
+
+// Permission is a privileged action that a role may be allowed to take.
+type Permission string
+
+const (
+	// PermView allows viewing members, prayers, the queue and the block list.
+	PermView Permission = "view"
+	// PermBlock allows blocking, unblocking and removing members.
+	PermBlock Permission = "block"
+	// PermModerate allows approving, editing and rejecting prayer requests held for review.
+	PermModerate Permission = "moderate"
+	// PermManagePrayers allows reassigning and cancelling prayers.
+	PermManagePrayers Permission = "manage prayers"
+	// PermEditMembers allows editing a member's name and weekly prayer limit.
+	PermEditMembers Permission = "edit members"
+	// PermBroadcast allows sending announcements.
+	PermBroadcast Permission = "broadcast"
+	// PermManageAdmins allows changing members' roles and reviewing the admin audit log.
+	PermManageAdmins Permission = "manage admins"
+)
+
+// Roles lists every admin role from least to most privileged.
+func Roles() []Role {
+	return []Role{RoleModerator, RoleCoordinator, RoleOwner}
+}
+
+// ParseRole returns the role named s. "none" and the empty string are RoleNone.
+func ParseRole(s string) (Role, bool) {
+	if s == "" || s == "none" {
+		return RoleNone, true
+	}
+	for _, role := range Roles() {
+		if string(role) == s {
+			return role, true
+		}
+	}
+	return RoleNone, false
+}
+
+// Can reports whether r has permission p. Each role has every permission of the roles below it.
+func (r Role) Can(p Permission) bool {
+	switch p {
+	case PermView, PermBlock, PermModerate:
+		return r == RoleModerator || r == RoleCoordinator || r == RoleOwner
+	case PermManagePrayers, PermEditMembers, PermBroadcast:
+		return r == RoleCoordinator || r == RoleOwner
+	case PermManageAdmins:
+		return r == RoleOwner
+	default:
+		return false
+	}
+}
+
+// IsAdmin reports whether r is any admin role.
+func (r Role) IsAdmin() bool {
+	return r != RoleNone
+}
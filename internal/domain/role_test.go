@@ -0,0 +1,62 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/4JesusApps/prayertexter/internal/domain"
+)
+
+func TestRoleCan(t *testing.T) {
+	tests := []struct {
+		role    domain.Role
+		allowed []domain.Permission
+	}{
+		{domain.RoleNone, nil},
+		{domain.RoleModerator, []domain.Permission{domain.PermView, domain.PermBlock, domain.PermModerate}},
+		{domain.RoleCoordinator, []domain.Permission{
+			domain.PermView, domain.PermBlock, domain.PermModerate, domain.PermManagePrayers, domain.PermEditMembers,
+			domain.PermBroadcast,
+		}},
+		{domain.RoleOwner, []domain.Permission{
+			domain.PermView, domain.PermBlock, domain.PermModerate, domain.PermManagePrayers, domain.PermEditMembers,
+			domain.PermBroadcast, domain.PermManageAdmins,
+		}},
+	}
+
+	all := []domain.Permission{
+		domain.PermView, domain.PermBlock, domain.PermModerate, domain.PermManagePrayers, domain.PermEditMembers,
+		domain.PermBroadcast, domain.PermManageAdmins,
+	}
+	for _, tt := range tests {
+		for _, perm := range all {
+			want := false
+			for _, allowed := range tt.allowed {
+				want = want || allowed == perm
+			}
+			if got := tt.role.Can(perm); got != want {
+				t.Errorf("expected %q can %q to be %v, got %v", tt.role, perm, want, got)
+			}
+		}
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   domain.Role
+		wantOK bool
+	}{
+		{"", domain.RoleNone, true},
+		{"none", domain.RoleNone, true},
+		{"moderator", domain.RoleModerator, true},
+		{"owner", domain.RoleOwner, true},
+		{"admin", domain.RoleNone, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := domain.ParseRole(tt.name)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseRole(%q) = %q, %v; expected %q, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+const (
+	DirectionInbound  = "inbound"
+	DirectionOutbound = "outbound"
+)
+
+// TranscriptMessage is a single text message sent to or received from a phone. Messages are keyed by Phone and
+// Timestamp so that a phone's conversation can be read back in order. ExpiresAt is the DynamoDB TTL attribute, in unix
+// seconds.
+type TranscriptMessage struct {
+	Phone     string
+	Timestamp string
+	Direction string
+	Body      string
+	// MessageIDs are the provider IDs of an outbound message, one for each part it was sent as.
+	MessageIDs []string `dynamodbav:",omitempty"`
+	ExpiresAt  int64
+}
+
+// NewTranscriptMessage returns a transcript entry timestamped now that expires after retentionDays.
+func NewTranscriptMessage(phone, direction, body string, retentionDays int) TranscriptMessage {
+	now := time.Now().UTC()
+	return TranscriptMessage{
+		Phone:     phone,
+		Timestamp: now.Format(time.RFC3339Nano),
+		Direction: direction,
+		Body:      body,
+		ExpiresAt: now.AddDate(0, 0, retentionDays).Unix(),
+	}
+}
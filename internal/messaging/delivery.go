@@ -0,0 +1,39 @@
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/apperr"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+)
+
+// pinpointDeliveryEvent is the subset of the End User Messaging SMS event destination payload that we care about.
+type pinpointDeliveryEvent struct {
+	EventType                string `json:"eventType"`
+	EventTimestamp           int64  `json:"eventTimestamp"`
+	DestinationPhoneNumber   string `json:"destinationPhoneNumber"`
+	MessageID                string `json:"messageId"`
+	MessageStatusDescription string `json:"messageStatusDescription"`
+}
+
+// ParsePinpointDeliveryEvent parses an SMS delivery status event published by End User Messaging SMS to SNS.
+func ParsePinpointDeliveryEvent(payload string) (domain.DeliveryReceipt, error) {
+	var event pinpointDeliveryEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return domain.DeliveryReceipt{}, apperr.WrapError(err, "failed to unmarshal pinpoint delivery event")
+	}
+
+	if event.MessageID == "" || event.DestinationPhoneNumber == "" {
+		return domain.DeliveryReceipt{}, fmt.Errorf("%w: missing message id or phone", ErrInvalidInbound)
+	}
+
+	return domain.DeliveryReceipt{
+		MessageID:   event.MessageID,
+		Phone:       event.DestinationPhoneNumber,
+		Status:      event.EventType,
+		Description: event.MessageStatusDescription,
+		Timestamp:   time.UnixMilli(event.EventTimestamp).UTC().Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,14 @@
+package messaging
+
+type constError string
+
+func (err constError) Error() string {
+	return string(err)
+}
+
+const (
+	ErrUnknownProvider   = constError("unknown sms provider")
+	ErrInvalidInbound    = constError("invalid inbound text message")
+	ErrUnexpectedHTTP    = constError("unexpected http response")
+	ErrProviderThrottled = constError("throttled by sms provider")
+)
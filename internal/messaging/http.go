@@ -0,0 +1,37 @@
+package messaging
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	maxErrorBodyBytes    = 512
+	maxResponseBodyBytes = 64 << 10
+)
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// doRequest sends req and returns the body of a 2xx response, treating any other response as an error. A bounded
+// amount of the response body is included in the error since providers usually explain failures there. A 429 response
+// is reported as ErrProviderThrottled so that it can be retried.
+func doRequest(client HTTPClient, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return io.ReadAll(io.LimitReader(resp.Body, maxResponseBodyBytes))
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("%w: status %d: %s", ErrProviderThrottled, resp.StatusCode, body)
+	}
+	return nil, fmt.Errorf("%w: status %d: %s", ErrUnexpectedHTTP, resp.StatusCode, body)
+}
@@ -0,0 +1,107 @@
+package messaging
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // Twilio signs requests with HMAC-SHA1.
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/4JesusApps/prayertexter/internal/apperr"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+)
+
+// InboundParser converts the raw payload a provider delivers for a received text message into a domain.TextMessage.
+type InboundParser func(payload string) (domain.TextMessage, error)
+
+type webhookInbound struct {
+	From string `json:"from"`
+	Body string `json:"body"`
+}
+
+func NewInboundParser(provider string) (InboundParser, error) {
+	switch provider {
+	case ProviderPinpoint, "":
+		return ParsePinpointMessage, nil
+	case ProviderTwilio:
+		return ParseTwilioMessage, nil
+	case ProviderWebhook:
+		return ParseWebhookMessage, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, provider)
+	}
+}
+
+// ParsePinpointMessage parses the JSON inbound message that End User Messaging SMS publishes to SNS.
+func ParsePinpointMessage(payload string) (domain.TextMessage, error) {
+	var msg domain.TextMessage
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		return domain.TextMessage{}, apperr.WrapError(err, "failed to unmarshal pinpoint message")
+	}
+	return validateInbound(msg)
+}
+
+// ParseTwilioMessage parses the form encoded body Twilio posts to a messaging webhook.
+func ParseTwilioMessage(payload string) (domain.TextMessage, error) {
+	form, err := url.ParseQuery(payload)
+	if err != nil {
+		return domain.TextMessage{}, apperr.WrapError(err, "failed to parse twilio message")
+	}
+	return validateInbound(domain.TextMessage{Body: form.Get("Body"), Phone: form.Get("From")})
+}
+
+// ParseWebhookMessage parses the JSON body of the generic webhook provider, which mirrors WebhookSender's payload.
+func ParseWebhookMessage(payload string) (domain.TextMessage, error) {
+	var in webhookInbound
+	if err := json.Unmarshal([]byte(payload), &in); err != nil {
+		return domain.TextMessage{}, apperr.WrapError(err, "failed to unmarshal webhook message")
+	}
+	return validateInbound(domain.TextMessage{Body: in.Body, Phone: in.From})
+}
+
+// VerifyTwilioSignature reports whether signature is the X-Twilio-Signature header Twilio sends with a form encoded
+// request to rawURL. Twilio signs the full url followed by every parameter name and value, sorted by name, with
+// HMAC-SHA1 keyed by the account auth token.
+func VerifyTwilioSignature(authToken, rawURL, body, signature string) bool {
+	if authToken == "" || signature == "" {
+		return false
+	}
+	form, err := url.ParseQuery(body)
+	if err != nil {
+		return false
+	}
+
+	var data strings.Builder
+	data.WriteString(rawURL)
+	for _, key := range slices.Sorted(maps.Keys(form)) {
+		for _, val := range form[key] {
+			data.WriteString(key + val)
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(data.String()))
+	want := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(want), []byte(signature))
+}
+
+// VerifyWebhookToken reports whether authorization carries the webhook provider's bearer token. Inbound requests use
+// the same token that WebhookSender sends with outbound messages.
+func VerifyWebhookToken(token, authorization string) bool {
+	if token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(authorization), []byte("Bearer "+token)) == 1
+}
+
+func validateInbound(msg domain.TextMessage) (domain.TextMessage, error) {
+	if msg.Phone == "" {
+		return domain.TextMessage{}, fmt.Errorf("%w: missing phone", ErrInvalidInbound)
+	}
+	return msg, nil
+}
@@ -0,0 +1,111 @@
+package messaging_test
+
+import (
+	"testing"
+
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInboundParsers(t *testing.T) {
+	want := domain.TextMessage{Body: "pray", Phone: "+11234567890"}
+
+	tests := []struct {
+		name     string
+		provider string
+		payload  string
+		wantErr  bool
+	}{
+		{
+			name:     "pinpoint sns message",
+			provider: messaging.ProviderPinpoint,
+			payload:  `{"originationNumber":"+11234567890","messageBody":"pray"}`,
+		},
+		{
+			name:     "twilio webhook form",
+			provider: messaging.ProviderTwilio,
+			payload:  "From=%2B11234567890&To=%2B15555555555&Body=pray&MessageSid=SM123",
+		},
+		{
+			name:     "generic webhook json",
+			provider: messaging.ProviderWebhook,
+			payload:  `{"from":"+11234567890","body":"pray"}`,
+		},
+		{
+			name:     "missing phone is invalid",
+			provider: messaging.ProviderWebhook,
+			payload:  `{"body":"pray"}`,
+			wantErr:  true,
+		},
+		{
+			name:     "malformed json is invalid",
+			provider: messaging.ProviderPinpoint,
+			payload:  `{"originationNumber":`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parse, err := messaging.NewInboundParser(tt.provider)
+			require.NoError(t, err)
+
+			msg, err := parse(tt.payload)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, want, msg)
+		})
+	}
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, err := messaging.NewInboundParser("carrier-pigeon")
+		require.ErrorIs(t, err, messaging.ErrUnknownProvider)
+	})
+}
+
+func TestVerifyTwilioSignature(t *testing.T) {
+	// Example from Twilio's webhook security documentation.
+	rawURL := "https://mycompany.com/myapp.php?foo=1&bar=2"
+	body := "CallSid=CA1234567890ABCDE&Caller=%2B12349013030&Digits=1234&From=%2B12349013030&To=%2B18005551212"
+
+	assert.True(t, messaging.VerifyTwilioSignature("12345", rawURL, body, "0/KCTR6DLpKmkAf8muzZqo1nDgQ="))
+	assert.False(t, messaging.VerifyTwilioSignature("12345", rawURL, body+"&Body=pray", "0/KCTR6DLpKmkAf8muzZqo1nDgQ="))
+	assert.False(t, messaging.VerifyTwilioSignature("54321", rawURL, body, "0/KCTR6DLpKmkAf8muzZqo1nDgQ="))
+	assert.False(t, messaging.VerifyTwilioSignature("12345", rawURL, body, ""))
+}
+
+func TestVerifyWebhookToken(t *testing.T) {
+	assert.True(t, messaging.VerifyWebhookToken("tkn", "Bearer tkn"))
+	assert.False(t, messaging.VerifyWebhookToken("tkn", "Bearer other"))
+	assert.False(t, messaging.VerifyWebhookToken("tkn", ""))
+	assert.False(t, messaging.VerifyWebhookToken("", "Bearer "))
+}
+
+func TestParsePinpointDeliveryEvent(t *testing.T) {
+	t.Run("parses delivery event", func(t *testing.T) {
+		payload := `{"eventType":"TEXT_CARRIER_UNREACHABLE","eventTimestamp":1700000000000,` +
+			`"destinationPhoneNumber":"+11234567890","messageId":"abc-123",` +
+			`"messageStatusDescription":"Destination is not reachable"}`
+
+		receipt, err := messaging.ParsePinpointDeliveryEvent(payload)
+		require.NoError(t, err)
+		assert.Equal(t, domain.DeliveryReceipt{
+			MessageID:   "abc-123",
+			Phone:       "+11234567890",
+			Status:      domain.DeliveryStatusCarrierUnreachable,
+			Description: "Destination is not reachable",
+			Timestamp:   "2023-11-14T22:13:20Z",
+		}, receipt)
+		assert.True(t, receipt.IsHardFailure())
+	})
+
+	t.Run("missing message id is invalid", func(t *testing.T) {
+		_, err := messaging.ParsePinpointDeliveryEvent(`{"eventType":"TEXT_DELIVERED"}`)
+		require.ErrorIs(t, err, messaging.ErrInvalidInbound)
+	})
+}
@@ -0,0 +1,52 @@
+package messaging
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MatchKeyword returns the first of keywords that appears in text as whole words, or an empty string when none do.
+// Case and punctuation are ignored, so "self harm" matches "Self-harm." and "abuse" does not match "disabused".
+func MatchKeyword(text string, keywords []string) string {
+	padded := " " + normalizeWords(text) + " "
+	for _, keyword := range keywords {
+		words := normalizeWords(keyword)
+		if words != "" && strings.Contains(padded, " "+words+" ") {
+			return keyword
+		}
+	}
+	return ""
+}
+
+// normalizeWords lowercases s and replaces every run of characters that are not letters or digits with one space.
+func normalizeWords(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// Similarity returns how alike a and b are, from 0 for no words in common to 1 for the same words in any order. Case,
+// punctuation and repeated words are ignored.
+func Similarity(a, b string) float64 {
+	wordsA := wordSet(a)
+	wordsB := wordSet(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for word := range wordsA {
+		if _, ok := wordsB[word]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wordsA)+len(wordsB)-shared)
+}
+
+func wordSet(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for word := range strings.FieldsSeq(normalizeWords(s)) {
+		set[word] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,43 @@
+package messaging_test
+
+import (
+	"testing"
+
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchKeyword(t *testing.T) {
+	keywords := []string{"abuse", "self harm"}
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Please pray, I have been thinking about SELF-HARM lately", "self harm"},
+		{"pray for my friend who suffered abuse.", "abuse"},
+		{"I was disabused of that idea", ""},
+		{"pray for my family's health", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, messaging.MatchKeyword(tt.text, keywords), tt.text)
+	}
+	assert.Empty(t, messaging.MatchKeyword("anything", []string{" ", "!"}))
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"Please pray for my mom", "please pray for my MOM!", 1},
+		{"pray for my mom", "my mom, pray for", 1},
+		{"pray for my mom", "pray for my dad", 0.6},
+		{"pray for my mom", "healing for a broken leg", 1.0 / 8},
+		{"", "pray for my mom", 0},
+	}
+
+	for _, tt := range tests {
+		assert.InDelta(t, tt.want, messaging.Similarity(tt.a, tt.b), 0.001, "%q and %q", tt.a, tt.b)
+	}
+}
@@ -12,12 +12,30 @@ const (
 		"each week."
 	MsgIntercessorInstructions = "You are now signed up to receive prayer requests. Please try to pray for the " +
 		"requests as soon as you receive them. " + MsgPrayed
-	MsgWrongInput         = "Incorrect input received during sign up, please try again."
+	MsgWrongInput = "Incorrect input received during sign up, please try again, or reply restart to start " +
+		"over."
+	MsgSignUpNudge = "You started signing up for PrayerTexter but have not finished. Reply to the last question " +
+		"to continue, or reply restart to start over."
+	MsgSignUpExpired = "Your PrayerTexter sign up has expired because it was not finished. To sign up, text the " +
+		"word pray to this number."
 	MsgSignUpConfirmation = "You have opted into PrayerTexter. Msg & data rates may apply."
 	MsgRemoveUser         = "You have been removed from PrayerTexter. To sign back up, text the word pray to this " +
 		"number."
+	MsgDataDeleted = "Everything PrayerTexter stored about you has been deleted, except a record of when you opted " +
+		"in and out, which phone carriers require us to keep. To sign back up, text the word pray to this number."
+	MsgConfirmDeleteData = "This deletes everything PrayerTexter stored about you and stops all texts from us. " +
+		"Reply YES within 10 minutes to delete it."
 )
 
+// DisclosureVersion identifies the opt in disclosure, MsgSignUpConfirmation, that new members are shown and is saved
+// with their consent. When the disclosure changes, give it a new version here and keep the old text in Disclosures.
+const DisclosureVersion = "1"
+
+// Disclosures is the text of every opt in disclosure version that has been shown to members.
+var Disclosures = map[string]string{
+	"1": MsgSignUpConfirmation,
+}
+
 const (
 	MsgInvalidRequest = "Sorry, that request is not valid. Prayer requests must contain at least 5 words."
 	MsgPrayed         = "Once you have prayed, reply with the word prayed so that the prayer can be confirmed."
@@ -27,8 +45,15 @@ const (
 )
 
 const (
-	MsgNoActivePrayer = "You have no active prayers to mark as prayed."
-	MsgPrayerThankYou = "Thank you for praying! We let the prayer requestor know that you have prayed for them."
+	MsgNoActivePrayer   = "You have no active prayers to mark as prayed."
+	MsgPrayerThankYou   = "Thank you for praying! We let the prayer requestor know that you have prayed for them."
+	MsgPrayerReassigned = "The prayer request you were sent has been passed on to another intercessor. You no longer " +
+		"need to reply prayed for it."
+	MsgPrayerCancelled = "The prayer request you were sent has been cancelled. You no longer need to reply " +
+		"prayed for it."
+	MsgPrayerPendingReview = "Thank you for your prayer request. It is being reviewed and will be sent to " +
+		"intercessors shortly."
+	MsgDuplicateRequest = "It looks like you already sent this prayer request recently, so it was not sent again."
 )
 
 const (
@@ -38,6 +63,23 @@ const (
 	MsgSuccessfullyBlocked = "The phone number provided has been successfully added to the block list."
 	MsgBlockedNotification = "You have been blocked from using PrayerTexter. If you feel this is an error, feel free " +
 		"to reach out to us. "
+	MsgUserNotBlocked        = "The phone number provided is not on the block list."
+	MsgSuccessfullyUnblocked = "The phone number provided has been successfully removed from the block list."
+	MsgMemberNotFound        = "There is no member with the phone number provided."
+	MsgSuccessfullyDemoted   = "The member is no longer an administrator."
+	MsgInvalidRole           = "The role provided is invalid. Please use moderator, coordinator or owner."
+	MsgCannotChangeOwnRole   = "You cannot change your own role. Please ask another owner."
+	MsgSuccessfullyRemoved   = "The member has been successfully removed from PrayerTexter."
+	MsgCannotRemoveSelf      = "You cannot remove yourself. Reply STOP to leave PrayerTexter."
+	MsgLastOwner             = "This member is the only owner. Please make another member an owner first."
+	MsgQueueEmpty            = "There are no queued prayers."
+	MsgPendingNotFound       = "There is no prayer request waiting for review with the ID provided."
+	MsgEditMissingRequest    = "Please include the edited prayer request after the ID, for example: #edit 1a2b3c " +
+		"Please pray for my family."
+	MsgSuccessfullyApproved = "The prayer request has been approved and sent on."
+	MsgSuccessfullyRejected = "The prayer request has been rejected."
+	MsgUnknownCommand       = "Unknown admin command. Available commands: #block, #unblock, #stats, #queue, " +
+		"#lookup, #promote, #demote, #remove, #approve, #edit, #reject."
 )
 
 const (
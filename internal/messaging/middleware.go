@@ -0,0 +1,199 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/apperr"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/repository"
+)
+
+// Middleware decorates a MessageSender with additional behavior. Middleware is composed with Chain.
+type Middleware func(next MessageSender) MessageSender
+
+// SenderFunc adapts an ordinary function to the MessageSender interface.
+type SenderFunc func(ctx context.Context, to string, body string) error
+
+func (f SenderFunc) SendMessage(ctx context.Context, to string, body string) error {
+	return f(ctx, to, body)
+}
+
+// Chain wraps sender with middleware. The first middleware is the outermost one, so it sees each message first.
+func Chain(sender MessageSender, middleware ...Middleware) MessageSender {
+	for _, mw := range slices.Backward(middleware) {
+		sender = mw(sender)
+	}
+	return sender
+}
+
+// WithComposition wraps each message with MsgPre and MsgPost and splits it into at most maxParts messages. Every
+// middleware after it in the chain sees the individual parts.
+func WithComposition(maxParts int) Middleware {
+	return func(next MessageSender) MessageSender {
+		return SenderFunc(func(ctx context.Context, to string, body string) error {
+			for _, part := range ComposeMessages(body, maxParts) {
+				if err := next.SendMessage(ctx, to, part); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
+
+// WithDryRun logs messages instead of sending them when enabled. This is used when running locally with sam.
+func WithDryRun(enabled bool) Middleware {
+	return func(next MessageSender) MessageSender {
+		if !enabled {
+			return next
+		}
+		return SenderFunc(func(ctx context.Context, to string, body string) error {
+			info := CountSegments(body)
+			slog.InfoContext(ctx, "sent text message (local)", "phone", to, "body", body,
+				"encoding", info.Encoding, "segments", info.Segments)
+			return nil
+		})
+	}
+}
+
+// WithLogging logs every successful send along with how it will be billed, and logs and wraps any failure.
+func WithLogging() Middleware {
+	return func(next MessageSender) MessageSender {
+		return SenderFunc(func(ctx context.Context, to string, body string) error {
+			if err := next.SendMessage(ctx, to, body); err != nil {
+				return apperr.LogAndWrapError(ctx, err, "failed to send text message", "phone", to, "msg", body)
+			}
+
+			info := CountSegments(body)
+			slog.InfoContext(ctx, "sent text message", "phone", to, "encoding", info.Encoding,
+				"segments", info.Segments)
+			return nil
+		})
+	}
+}
+
+// WithRetry retries sends that the provider rejected with ErrProviderThrottled, waiting delay between attempts. Other
+// errors are returned immediately.
+func WithRetry(attempts int, delay time.Duration) Middleware {
+	return func(next MessageSender) MessageSender {
+		return SenderFunc(func(ctx context.Context, to string, body string) error {
+			var err error
+			for attempt := 1; attempt <= attempts; attempt++ {
+				err = next.SendMessage(ctx, to, body)
+				if err == nil || !errors.Is(err, ErrProviderThrottled) || attempt == attempts {
+					break
+				}
+
+				slog.WarnContext(ctx, "throttled by sms provider, retrying", "attempt", attempt, "phone", to)
+				time.Sleep(delay)
+			}
+			return err
+		})
+	}
+}
+
+// WithOptOutCheck drops messages to phones that have texted STOP. Dropped messages are logged and are not errors.
+func WithOptOutCheck(optedOut repository.OptedOutPhonesRepository) Middleware {
+	return func(next MessageSender) MessageSender {
+		return SenderFunc(func(ctx context.Context, to string, body string) error {
+			phones, err := optedOut.Get(ctx)
+			if err != nil {
+				return apperr.WrapError(err, "failed to get opted out phones")
+			}
+
+			if slices.Contains(phones.Phones, to) {
+				slog.WarnContext(ctx, "phone has opted out, dropping text message", "phone", to)
+				return nil
+			}
+
+			return next.SendMessage(ctx, to, body)
+		})
+	}
+}
+
+// WithRecording saves every successfully sent message to the conversation transcript, along with the message IDs the
+// provider returned so that delivery receipts can be matched to it. A failure to record is logged but does not fail
+// the send, since the message has already gone out.
+func WithRecording(transcripts repository.TranscriptRepository, retentionDays int) Middleware {
+	return func(next MessageSender) MessageSender {
+		return SenderFunc(func(ctx context.Context, to string, body string) error {
+			ids := &messageIDs{}
+			if err := next.SendMessage(context.WithValue(ctx, messageIDsKey{}, ids), to, body); err != nil {
+				return err
+			}
+
+			msg := domain.NewTranscriptMessage(to, domain.DirectionOutbound, body, retentionDays)
+			msg.MessageIDs = ids.ids
+			if err := transcripts.Save(ctx, &msg); err != nil {
+				apperr.LogError(ctx, err, "failed to record outbound text message", "phone", to)
+			}
+			return nil
+		})
+	}
+}
+
+type messageIDsKey struct{}
+
+// messageIDs collects the provider message IDs of everything sent with a context, one per part when a message is
+// split.
+type messageIDs struct {
+	ids []string
+}
+
+// reportMessageID is called by providers with the ID they assigned to a sent message. It does nothing when no one is
+// collecting IDs or the provider did not return one.
+func reportMessageID(ctx context.Context, id string) {
+	if ids, ok := ctx.Value(messageIDsKey{}).(*messageIDs); ok && id != "" {
+		ids.ids = append(ids.ids, id)
+	}
+}
+
+// Metrics counts the messages that pass through WithMetrics. A single Metrics is meant to live for one invocation and
+// be logged when it finishes.
+type Metrics struct {
+	mu       sync.Mutex
+	sent     int
+	failed   int
+	segments int
+}
+
+// WithMetrics records every send attempt in metrics.
+func WithMetrics(metrics *Metrics) Middleware {
+	return func(next MessageSender) MessageSender {
+		return SenderFunc(func(ctx context.Context, to string, body string) error {
+			err := next.SendMessage(ctx, to, body)
+			metrics.record(CountSegments(body).Segments, err)
+			return err
+		})
+	}
+}
+
+func (m *Metrics) record(segments int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err != nil {
+		m.failed++
+		return
+	}
+	m.sent++
+	m.segments += segments
+}
+
+// Counts returns the number of sent messages, failed messages and billed segments recorded so far.
+func (m *Metrics) Counts() (int, int, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.sent, m.failed, m.segments
+}
+
+func (m *Metrics) Log(ctx context.Context) {
+	sent, failed, segments := m.Counts()
+	slog.InfoContext(ctx, "text message metrics", "sent", sent, "failed", failed, "segments", segments)
+}
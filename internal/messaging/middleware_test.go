@@ -0,0 +1,139 @@
+package messaging_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	msgmocks "github.com/4JesusApps/prayertexter/internal/mocks/messaging"
+	repomocks "github.com/4JesusApps/prayertexter/internal/mocks/repository"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/pinpointsmsvoicev2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainOrder(t *testing.T) {
+	var calls []string
+	mark := func(name string) messaging.Middleware {
+		return func(next messaging.MessageSender) messaging.MessageSender {
+			return messaging.SenderFunc(func(ctx context.Context, to string, body string) error {
+				calls = append(calls, name)
+				return next.SendMessage(ctx, to, body)
+			})
+		}
+	}
+
+	next := msgmocks.NewMockMessageSender(t)
+	next.EXPECT().SendMessage(mock.Anything, "+11234567890", "pray").Return(nil)
+
+	sender := messaging.Chain(next, mark("first"), mark("second"))
+	require.NoError(t, sender.SendMessage(context.Background(), "+11234567890", "pray"))
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
+
+func TestWithComposition(t *testing.T) {
+	next := msgmocks.NewMockMessageSender(t)
+	next.EXPECT().SendMessage(mock.Anything, "+11234567890", mock.Anything).Return(nil).Times(2)
+
+	sender := messaging.Chain(next, messaging.WithComposition(3))
+	body := strings.Repeat("pray for my family ", 150)
+	require.NoError(t, sender.SendMessage(context.Background(), "+11234567890", body))
+}
+
+func TestWithDryRun(t *testing.T) {
+	next := msgmocks.NewMockMessageSender(t)
+
+	sender := messaging.Chain(next, messaging.WithDryRun(true))
+	require.NoError(t, sender.SendMessage(context.Background(), "+11234567890", "pray"))
+	next.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWithRetry(t *testing.T) {
+	t.Run("retries throttled sends", func(t *testing.T) {
+		next := msgmocks.NewMockMessageSender(t)
+		next.EXPECT().SendMessage(mock.Anything, mock.Anything, mock.Anything).
+			Return(messaging.ErrProviderThrottled).Once()
+		next.EXPECT().SendMessage(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		sender := messaging.Chain(next, messaging.WithRetry(3, 0))
+		require.NoError(t, sender.SendMessage(context.Background(), "+11234567890", "pray"))
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		next := msgmocks.NewMockMessageSender(t)
+		next.EXPECT().SendMessage(mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("invalid number")).Once()
+
+		sender := messaging.Chain(next, messaging.WithRetry(3, 0))
+		require.Error(t, sender.SendMessage(context.Background(), "+11234567890", "pray"))
+	})
+}
+
+func TestWithOptOutCheck(t *testing.T) {
+	optedOut := repomocks.NewMockOptedOutPhonesRepository(t)
+	optedOut.EXPECT().Get(mock.Anything).Return(&domain.OptedOutPhones{Phones: []string{"+11111111111"}}, nil)
+
+	next := msgmocks.NewMockMessageSender(t)
+	next.EXPECT().SendMessage(mock.Anything, "+12222222222", "pray").Return(nil).Once()
+
+	sender := messaging.Chain(next, messaging.WithOptOutCheck(optedOut))
+	require.NoError(t, sender.SendMessage(context.Background(), "+11111111111", "pray"))
+	require.NoError(t, sender.SendMessage(context.Background(), "+12222222222", "pray"))
+}
+
+func TestWithRecording_MessageIDs(t *testing.T) {
+	transcripts := repomocks.NewMockTranscriptRepository(t)
+	transcripts.EXPECT().Save(mock.Anything, mock.MatchedBy(func(m *domain.TranscriptMessage) bool {
+		return slices.Equal(m.MessageIDs, []string{"id-1", "id-2"})
+	})).Return(nil).Once()
+
+	client := msgmocks.NewMockPinpointClient(t)
+	client.EXPECT().SendTextMessage(mock.Anything, mock.Anything).
+		Return(&pinpointsmsvoicev2.SendTextMessageOutput{MessageId: aws.String("id-1")}, nil).Once()
+	client.EXPECT().SendTextMessage(mock.Anything, mock.Anything).
+		Return(&pinpointsmsvoicev2.SendTextMessageOutput{MessageId: aws.String("id-2")}, nil).Once()
+	sender := messaging.Chain(
+		messaging.NewPinpointSender(client, "pool", 5),
+		messaging.WithRecording(transcripts, 90),
+		messaging.WithComposition(3),
+	)
+	body := strings.Repeat("pray for my family ", 150)
+	require.NoError(t, sender.SendMessage(context.Background(), "+11234567890", body))
+}
+
+func TestWithRecording(t *testing.T) {
+	transcripts := repomocks.NewMockTranscriptRepository(t)
+	transcripts.EXPECT().Save(mock.Anything, mock.MatchedBy(func(m *domain.TranscriptMessage) bool {
+		return m.Phone == "+11234567890" && m.Body == "pray" && m.Direction == domain.DirectionOutbound
+	})).Return(errors.New("table missing")).Once()
+
+	next := msgmocks.NewMockMessageSender(t)
+	next.EXPECT().SendMessage(mock.Anything, "+11234567890", "pray").Return(nil).Once()
+	next.EXPECT().SendMessage(mock.Anything, "+12222222222", "pray").Return(errors.New("failed")).Once()
+
+	sender := messaging.Chain(next, messaging.WithRecording(transcripts, 90))
+	require.NoError(t, sender.SendMessage(context.Background(), "+11234567890", "pray"))
+	require.Error(t, sender.SendMessage(context.Background(), "+12222222222", "pray"))
+}
+
+func TestWithMetrics(t *testing.T) {
+	next := msgmocks.NewMockMessageSender(t)
+	next.EXPECT().SendMessage(mock.Anything, "+11111111111", mock.Anything).Return(nil)
+	next.EXPECT().SendMessage(mock.Anything, "+12222222222", mock.Anything).Return(errors.New("failed"))
+
+	metrics := &messaging.Metrics{}
+	sender := messaging.Chain(next, messaging.WithMetrics(metrics))
+	require.NoError(t, sender.SendMessage(context.Background(), "+11111111111", strings.Repeat("a", 161)))
+	require.Error(t, sender.SendMessage(context.Background(), "+12222222222", "pray"))
+
+	sent, failed, segments := metrics.Counts()
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, 2, segments)
+}
@@ -2,15 +2,105 @@ package messaging
 
 import (
 	"slices"
+	"strings"
 
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/domain"
 	goaway "github.com/TwiN/go-away"
 )
 
-func CheckProfanity(text string) string {
-	profanities := &goaway.DefaultProfanities
-	*profanities = slices.DeleteFunc(*profanities, func(s string) bool {
-		return s == "jerk" || s == "ass" || s == "butt"
-	})
-	detector := goaway.NewProfanityDetector().WithSanitizeSpaces(false)
-	return detector.ExtractProfanity(text)
+// ProfanityMode is what is done with text that contains profanity.
+type ProfanityMode string
+
+const (
+	// ProfanityReject refuses the text and tells the sender which word was found.
+	ProfanityReject ProfanityMode = "reject"
+	// ProfanityMask accepts the text but replaces each profane word with asterisks wherever it is shown to others.
+	ProfanityMask ProfanityMode = "mask"
+	// ProfanityOff accepts the text as it is.
+	ProfanityOff ProfanityMode = "off"
+)
+
+// Profanity holds the profanity filters for member names and prayer requests. It is built once at startup and is safe
+// for concurrent use.
+type Profanity struct {
+	Names    *ProfanityFilter
+	Requests *ProfanityFilter
+}
+
+// ProfanityFilter finds profanity in text according to a mode and deny and allow lists.
+type ProfanityFilter struct {
+	mode     ProfanityMode
+	detector *goaway.ProfanityDetector
+}
+
+// NewProfanity builds the name and request filters from cfg and the lists saved in DynamoDB. Each filter denies the
+// default profanities plus the deny lists in cfg, in its own policy and in lists, less every word that any of them
+// allow.
+func NewProfanity(cfg config.ProfanityConfig, lists domain.ProfanityLists) *Profanity {
+	deny := slices.Concat(cfg.Deny, lists.Deny)
+	allow := slices.Concat(cfg.Allow, lists.Allow)
+	return &Profanity{
+		Names: NewProfanityFilter(
+			ProfanityMode(cfg.Name.Mode),
+			slices.Concat(deny, cfg.Name.Deny),
+			slices.Concat(allow, cfg.Name.Allow),
+		),
+		Requests: NewProfanityFilter(
+			ProfanityMode(cfg.Request.Mode),
+			slices.Concat(deny, cfg.Request.Deny),
+			slices.Concat(allow, cfg.Request.Allow),
+		),
+	}
+}
+
+// NewProfanityFilter returns a filter that denies the default profanities and deny, less allow. An unknown mode is
+// treated as ProfanityReject. The default dictionaries are copied so that filters never change each other.
+func NewProfanityFilter(mode ProfanityMode, deny, allow []string) *ProfanityFilter {
+	if mode != ProfanityMask && mode != ProfanityOff {
+		mode = ProfanityReject
+	}
+
+	allowed := func(word string) bool {
+		return slices.ContainsFunc(allow, func(a string) bool { return strings.EqualFold(a, word) })
+	}
+	profanities := slices.DeleteFunc(slices.Concat(goaway.DefaultProfanities, lowerAll(deny)), allowed)
+	falseNegatives := slices.DeleteFunc(slices.Clone(goaway.DefaultFalseNegatives), allowed)
+	falsePositives := slices.Clone(goaway.DefaultFalsePositives)
+
+	return &ProfanityFilter{
+		mode: mode,
+		detector: goaway.NewProfanityDetector().
+			WithSanitizeSpaces(false).
+			WithCustomDictionary(profanities, falsePositives, falseNegatives),
+	}
+}
+
+// Mode returns what f does with text that contains profanity.
+func (f *ProfanityFilter) Mode() ProfanityMode {
+	return f.mode
+}
+
+// Reject returns the first profane word in text when f rejects profanity, or an empty string when text is acceptable.
+func (f *ProfanityFilter) Reject(text string) string {
+	if f.mode != ProfanityReject {
+		return ""
+	}
+	return f.detector.ExtractProfanity(text)
+}
+
+// Mask returns text with every profane word replaced by asterisks when f masks profanity, or text unchanged otherwise.
+func (f *ProfanityFilter) Mask(text string) string {
+	if f.mode != ProfanityMask {
+		return text
+	}
+	return f.detector.Censor(text)
+}
+
+func lowerAll(words []string) []string {
+	lowered := make([]string, 0, len(words))
+	for _, word := range words {
+		lowered = append(lowered, strings.ToLower(word))
+	}
+	return lowered
 }
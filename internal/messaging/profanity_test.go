@@ -0,0 +1,73 @@
+package messaging_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfanityFilterReject(t *testing.T) {
+	filter := messaging.NewProfanityFilter(messaging.ProfanityReject, []string{"Heck"}, []string{"jerk", "ass"})
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"pray for my friend", ""},
+		{"my boss is a jerk", ""},
+		{"pass the salt", ""},
+		{"what the shit", "shit"},
+		{"oh heck", "heck"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, filter.Reject(tt.text), tt.text)
+	}
+}
+
+func TestProfanityFilterMask(t *testing.T) {
+	filter := messaging.NewProfanityFilter(messaging.ProfanityMask, nil, nil)
+
+	assert.Empty(t, filter.Reject("what the shit"))
+	assert.Equal(t, "what the ****", filter.Mask("what the shit"))
+	assert.Equal(t, "pray for me", filter.Mask("pray for me"))
+}
+
+func TestProfanityFilterOff(t *testing.T) {
+	filter := messaging.NewProfanityFilter(messaging.ProfanityOff, nil, nil)
+
+	assert.Empty(t, filter.Reject("what the shit"))
+	assert.Equal(t, "what the shit", filter.Mask("what the shit"))
+}
+
+func TestNewProfanity(t *testing.T) {
+	profanity := messaging.NewProfanity(config.ProfanityConfig{
+		Deny:    []string{"darn"},
+		Name:    config.ProfanityPolicyConfig{Mode: "reject", Deny: []string{"pastor"}},
+		Request: config.ProfanityPolicyConfig{Mode: "mask", Allow: []string{"darn"}},
+	}, domain.ProfanityLists{Deny: []string{"gosh"}})
+
+	assert.Equal(t, messaging.ProfanityReject, profanity.Names.Mode())
+	assert.Equal(t, "darn", profanity.Names.Reject("darn it"))
+	assert.Equal(t, "gosh", profanity.Names.Reject("oh my gosh"))
+	assert.Equal(t, "pastor", profanity.Names.Reject("Pastor Bob"))
+
+	assert.Equal(t, messaging.ProfanityMask, profanity.Requests.Mode())
+	assert.Equal(t, "darn it, oh my ****, Pastor Bob", profanity.Requests.Mask("darn it, oh my gosh, Pastor Bob"))
+}
+
+func TestProfanityFiltersAreIndependent(t *testing.T) {
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			messaging.NewProfanityFilter(messaging.ProfanityReject, nil, []string{"shit"})
+		})
+	}
+	wg.Wait()
+
+	filter := messaging.NewProfanityFilter(messaging.ProfanityReject, nil, nil)
+	assert.Equal(t, "shit", filter.Reject("what the shit"))
+}
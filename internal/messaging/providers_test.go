@@ -0,0 +1,166 @@
+package messaging_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	repomocks "github.com/4JesusApps/prayertexter/internal/mocks/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwilioSender(t *testing.T) {
+	t.Run("posts form encoded message with basic auth", func(t *testing.T) {
+		var gotPath, gotTo, gotFrom, gotBody, gotUser, gotPass string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			gotUser, gotPass, _ = r.BasicAuth()
+			_ = r.ParseForm()
+			gotTo, gotFrom, gotBody = r.PostForm.Get("To"), r.PostForm.Get("From"), r.PostForm.Get("Body")
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer srv.Close()
+
+		cfg := config.TwilioConfig{BaseURL: srv.URL, AccountSID: "AC123", AuthToken: "secret", From: "+15555555555"}
+		sender := messaging.NewTwilioSender(srv.Client(), cfg, 5)
+
+		err := sender.SendMessage(context.Background(), "+11234567890", "hello")
+		require.NoError(t, err)
+		assert.Equal(t, "/2010-04-01/Accounts/AC123/Messages.json", gotPath)
+		assert.Equal(t, "AC123", gotUser)
+		assert.Equal(t, "secret", gotPass)
+		assert.Equal(t, "+11234567890", gotTo)
+		assert.Equal(t, "+15555555555", gotFrom)
+		assert.Equal(t, "hello", gotBody)
+	})
+
+	t.Run("records message sid", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"sid":"SM123","status":"queued"}`))
+		}))
+		defer srv.Close()
+
+		transcripts := repomocks.NewMockTranscriptRepository(t)
+		transcripts.EXPECT().Save(mock.Anything, mock.MatchedBy(func(m *domain.TranscriptMessage) bool {
+			return len(m.MessageIDs) == 1 && m.MessageIDs[0] == "SM123"
+		})).Return(nil).Once()
+
+		sender := messaging.Chain(
+			messaging.NewTwilioSender(srv.Client(), config.TwilioConfig{BaseURL: srv.URL}, 5),
+			messaging.WithRecording(transcripts, 90),
+		)
+		require.NoError(t, sender.SendMessage(context.Background(), "+11234567890", "hello"))
+	})
+
+	t.Run("non 2xx response returns error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, `{"message":"invalid number"}`, http.StatusBadRequest)
+		}))
+		defer srv.Close()
+
+		sender := messaging.NewTwilioSender(srv.Client(), config.TwilioConfig{BaseURL: srv.URL}, 5)
+
+		err := sender.SendMessage(context.Background(), "+11234567890", "hello")
+		require.ErrorIs(t, err, messaging.ErrUnexpectedHTTP)
+		assert.Contains(t, err.Error(), "invalid number")
+	})
+}
+
+func TestWebhookSender(t *testing.T) {
+	var gotAuth string
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	sender := messaging.NewWebhookSender(srv.Client(), config.WebhookConfig{URL: srv.URL, Token: "tkn"}, 5)
+
+	err := sender.SendMessage(context.Background(), "+11234567890", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer tkn", gotAuth)
+	assert.Equal(t, "+11234567890", got["to"])
+	assert.Equal(t, "hello", got["body"])
+}
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		provider string
+		wantType any
+		wantErr  error
+	}{
+		{messaging.ProviderPinpoint, &messaging.PinpointSender{}, nil},
+		{"", &messaging.PinpointSender{}, nil},
+		{messaging.ProviderTwilio, &messaging.TwilioSender{}, nil},
+		{messaging.ProviderWebhook, &messaging.WebhookSender{}, nil},
+		{"carrier-pigeon", nil, messaging.ErrUnknownProvider},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			sender, err := messaging.NewProvider(config.SMSConfig{Provider: tt.provider}, nil, http.DefaultClient)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tt.wantType, sender)
+		})
+	}
+}
+
+func TestNewOutbound(t *testing.T) {
+	t.Run("deferred sender skips opted out phones and records sent messages", func(t *testing.T) {
+		var sent []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var got map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			sent = append(sent, got["to"])
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		optedOut := repomocks.NewMockOptedOutPhonesRepository(t)
+		optedOut.EXPECT().Get(mock.Anything).Return(&domain.OptedOutPhones{Phones: []string{"+11111111111"}}, nil)
+		transcripts := repomocks.NewMockTranscriptRepository(t)
+		transcripts.EXPECT().Save(mock.Anything, mock.MatchedBy(func(m *domain.TranscriptMessage) bool {
+			return m.Phone == "+12222222222" && m.Direction == domain.DirectionOutbound
+		})).Return(nil).Once()
+		counts := repomocks.NewMockSendCountRepository(t)
+
+		cfg := config.Config{}
+		cfg.AWS.SMS = config.SMSConfig{
+			Provider: messaging.ProviderWebhook,
+			Webhook:  config.WebhookConfig{URL: srv.URL},
+			Timeout:  5,
+			MaxParts: 1,
+		}
+		outbound, err := messaging.NewOutbound(
+			cfg, nil, srv.Client(), optedOut, repomocks.NewMockDeferredMessageRepository(t), counts, transcripts,
+		)
+		require.NoError(t, err)
+
+		require.NoError(t, outbound.Deferred.SendMessage(context.Background(), "+11111111111", "pray"))
+		require.NoError(t, outbound.Deferred.SendMessage(context.Background(), "+12222222222", "pray"))
+		assert.Equal(t, []string{"+12222222222"}, sent)
+		sentCount, _, _ := outbound.Metrics.Counts()
+		assert.Equal(t, 1, sentCount)
+	})
+
+	t.Run("unknown provider returns error", func(t *testing.T) {
+		cfg := config.Config{}
+		cfg.AWS.SMS.Provider = "carrier-pigeon"
+		_, err := messaging.NewOutbound(cfg, nil, http.DefaultClient, nil, nil, nil, nil)
+		require.ErrorIs(t, err, messaging.ErrUnknownProvider)
+	})
+}
@@ -0,0 +1,189 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/apperr"
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/repository"
+)
+
+const (
+	sendCountDayFormat = "2006-01-02"
+	// sendCountDayTTL is how long per phone daily counts are kept after the last message they counted.
+	sendCountDayTTL = 48 * time.Hour
+	// sendCountWindowTTL is how long global rate limit windows are kept after the last message they counted.
+	sendCountWindowTTL = time.Hour
+)
+
+// RateLimiter keeps outbound traffic under the global messages per second limit and the per phone daily cap. Both are
+// counted in the SendCount table, so that they hold across every Lambda invocation and function that sends messages.
+// Messages that would exceed either limit are saved as deferred messages rather than dropped; SendDeferred delivers
+// them once there is capacity again.
+//
+// The global limit allows Burst messages in each window of Burst / MessagesPerSecond seconds, starting at the unix
+// epoch. A message that finds its window full waits for the next one, for up to MaxWait.
+type RateLimiter struct {
+	deferred repository.DeferredMessageRepository
+	counts   repository.SendCountRepository
+	burst    int
+	window   time.Duration
+	dailyCap int
+	maxWait  time.Duration
+}
+
+func NewRateLimiter(
+	deferred repository.DeferredMessageRepository,
+	counts repository.SendCountRepository,
+	cfg config.RateLimitConfig,
+) *RateLimiter {
+	burst := max(cfg.Burst, 1)
+	var window time.Duration
+	if cfg.MessagesPerSecond > 0 {
+		window = time.Duration(float64(burst) / cfg.MessagesPerSecond * float64(time.Second))
+	}
+	return &RateLimiter{
+		deferred: deferred,
+		counts:   counts,
+		burst:    burst,
+		window:   window,
+		dailyCap: cfg.DailyPerPhone,
+		maxWait:  time.Duration(cfg.MaxWait) * time.Second,
+	}
+}
+
+// Middleware returns the rate limiting middleware. It should come before WithComposition in the chain so that a
+// message split into several parts only counts once.
+func (l *RateLimiter) Middleware() Middleware {
+	return func(next MessageSender) MessageSender {
+		return SenderFunc(func(ctx context.Context, to string, body string) error {
+			reason, err := l.take(ctx, to)
+			if err != nil {
+				return err
+			}
+			if reason != "" {
+				return l.deferMessage(ctx, to, body, reason)
+			}
+			return next.SendMessage(ctx, to, body)
+		})
+	}
+}
+
+// SendDeferred delivers previously deferred messages through sender, oldest first. sender should not include the rate
+// limiting middleware itself. Messages for phones that are still over their daily cap are left in place, and the run
+// stops early once the global rate limit is reached.
+func (l *RateLimiter) SendDeferred(ctx context.Context, sender MessageSender) error {
+	msgs, err := l.deferred.GetAll(ctx)
+	if err != nil {
+		return apperr.WrapError(err, "failed to get deferred messages")
+	}
+
+	slices.SortFunc(msgs, func(a, b domain.DeferredMessage) int { return strings.Compare(a.Created, b.Created) })
+
+	for _, msg := range msgs {
+		var reason string
+		if reason, err = l.take(ctx, msg.Phone); err != nil {
+			return err
+		}
+		if reason == domain.DeferReasonDailyCap {
+			continue
+		}
+		if reason == domain.DeferReasonRateLimit {
+			slog.InfoContext(ctx, "rate limit reached, leaving remaining deferred messages")
+			break
+		}
+
+		if err = sender.SendMessage(ctx, msg.Phone, msg.Body); err != nil {
+			return err
+		}
+		if err = l.deferred.Delete(ctx, msg.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// take counts a message to phone against the global rate limit and then against phone's daily cap. It returns the
+// defer reason of the first limit that the message would exceed, or an empty string when it may be sent. A message
+// that is deferred for the daily cap has still used its place in the global limit, and a message whose send then
+// fails still counts towards both.
+func (l *RateLimiter) take(ctx context.Context, phn string) (string, error) {
+	ok, err := l.takeGlobal(ctx)
+	if err != nil || !ok {
+		return domain.DeferReasonRateLimit, err
+	}
+
+	if l.dailyCap <= 0 {
+		return "", nil
+	}
+	now := time.Now().UTC()
+	ok, err = l.counts.Take(ctx, phn, now.Format(sendCountDayFormat), l.dailyCap, now.Add(sendCountDayTTL))
+	if err != nil {
+		return "", apperr.WrapError(err, "failed to count message against daily cap")
+	}
+	if !ok {
+		return domain.DeferReasonDailyCap, nil
+	}
+	return "", nil
+}
+
+// takeGlobal takes a place in the current global rate limit window, waiting for later windows while they start within
+// maxWait. It returns false when there is no place in time or the context ends first. A zero window disables the
+// limit.
+func (l *RateLimiter) takeGlobal(ctx context.Context) (bool, error) {
+	if l.window <= 0 {
+		return true, nil
+	}
+
+	deadline := time.Now().Add(l.maxWait)
+	for {
+		now := time.Now()
+		window := now.UnixNano() / int64(l.window)
+		ok, err := l.counts.Take(ctx, domain.SendCountGlobal, strconv.FormatInt(window, 10), l.burst,
+			now.Add(sendCountWindowTTL))
+		if err != nil {
+			return false, apperr.WrapError(err, "failed to count message against rate limit")
+		}
+		if ok {
+			return true, nil
+		}
+
+		next := time.Unix(0, (window+1)*int64(l.window))
+		if next.After(deadline) {
+			return false, nil
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return false, nil
+		}
+	}
+}
+
+func (l *RateLimiter) deferMessage(ctx context.Context, to, body, reason string) error {
+	now := time.Now()
+	msg := domain.DeferredMessage{
+		ID:      fmt.Sprintf("%s-%d", to, now.UnixNano()),
+		Phone:   to,
+		Body:    body,
+		Created: now.Format(time.RFC3339Nano),
+		Reason:  reason,
+	}
+
+	if err := l.deferred.Save(ctx, &msg); err != nil {
+		return apperr.WrapError(err, "failed to save deferred message")
+	}
+
+	slog.InfoContext(ctx, "deferred text message", "phone", to, "reason", reason)
+	return nil
+}
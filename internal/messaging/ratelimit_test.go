@@ -0,0 +1,121 @@
+package messaging_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	msgmocks "github.com/4JesusApps/prayertexter/internal/mocks/messaging"
+	repomocks "github.com/4JesusApps/prayertexter/internal/mocks/repository"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func today() string {
+	return time.Now().UTC().Format("2006-01-02")
+}
+
+func TestRateLimiterSendsAndCounts(t *testing.T) {
+	ctx := context.Background()
+	next := msgmocks.NewMockMessageSender(t)
+	deferred := repomocks.NewMockDeferredMessageRepository(t)
+	counts := repomocks.NewMockSendCountRepository(t)
+	cfg := config.RateLimitConfig{MessagesPerSecond: 1, Burst: 1, DailyPerPhone: 2}
+	limiter := messaging.NewRateLimiter(deferred, counts, cfg)
+	sender := messaging.Chain(next, limiter.Middleware())
+
+	counts.EXPECT().Take(mock.Anything, domain.SendCountGlobal, mock.Anything, 1, mock.Anything).Return(true, nil)
+	counts.EXPECT().Take(mock.Anything, "+11234567890", today(), 2, mock.Anything).Return(true, nil)
+	next.EXPECT().SendMessage(mock.Anything, "+11234567890", "pray").Return(nil)
+
+	require.NoError(t, sender.SendMessage(ctx, "+11234567890", "pray"))
+}
+
+func TestRateLimiterDefersOverDailyCap(t *testing.T) {
+	ctx := context.Background()
+	next := msgmocks.NewMockMessageSender(t)
+	deferred := repomocks.NewMockDeferredMessageRepository(t)
+	counts := repomocks.NewMockSendCountRepository(t)
+	cfg := config.RateLimitConfig{MessagesPerSecond: 1, Burst: 1, DailyPerPhone: 2}
+	limiter := messaging.NewRateLimiter(deferred, counts, cfg)
+	sender := messaging.Chain(next, limiter.Middleware())
+
+	counts.EXPECT().Take(mock.Anything, domain.SendCountGlobal, mock.Anything, 1, mock.Anything).Return(true, nil)
+	counts.EXPECT().Take(mock.Anything, "+11234567890", today(), 2, mock.Anything).Return(false, nil)
+	deferred.EXPECT().Save(mock.Anything, mock.MatchedBy(func(msg *domain.DeferredMessage) bool {
+		return msg.Phone == "+11234567890" && msg.Body == "pray" && msg.Reason == domain.DeferReasonDailyCap
+	})).Return(nil)
+
+	require.NoError(t, sender.SendMessage(ctx, "+11234567890", "pray"))
+}
+
+func TestRateLimiterDefersOverRateLimit(t *testing.T) {
+	ctx := context.Background()
+	next := msgmocks.NewMockMessageSender(t)
+	deferred := repomocks.NewMockDeferredMessageRepository(t)
+	counts := repomocks.NewMockSendCountRepository(t)
+	cfg := config.RateLimitConfig{MessagesPerSecond: 1, Burst: 1, DailyPerPhone: 2, MaxWait: 0}
+	limiter := messaging.NewRateLimiter(deferred, counts, cfg)
+	sender := messaging.Chain(next, limiter.Middleware())
+
+	// The window is shared with every other sender, so it can already be full for the first message.
+	counts.EXPECT().Take(mock.Anything, domain.SendCountGlobal, mock.Anything, 1, mock.Anything).Return(false, nil)
+	deferred.EXPECT().Save(mock.Anything, mock.MatchedBy(func(msg *domain.DeferredMessage) bool {
+		return msg.Phone == "+12222222222" && msg.Reason == domain.DeferReasonRateLimit
+	})).Return(nil).Once()
+
+	require.NoError(t, sender.SendMessage(ctx, "+12222222222", "second"))
+}
+
+func TestRateLimiterWaitsForNextWindow(t *testing.T) {
+	ctx := context.Background()
+	next := msgmocks.NewMockMessageSender(t)
+	deferred := repomocks.NewMockDeferredMessageRepository(t)
+	counts := repomocks.NewMockSendCountRepository(t)
+	cfg := config.RateLimitConfig{MessagesPerSecond: 20, Burst: 1, MaxWait: 1}
+	limiter := messaging.NewRateLimiter(deferred, counts, cfg)
+	sender := messaging.Chain(next, limiter.Middleware())
+
+	var windows []string
+	counts.EXPECT().Take(mock.Anything, domain.SendCountGlobal, mock.Anything, 1, mock.Anything).
+		RunAndReturn(func(_ context.Context, _, period string, _ int, _ time.Time) (bool, error) {
+			windows = append(windows, period)
+			return len(windows) > 1, nil
+		}).Twice()
+	next.EXPECT().SendMessage(mock.Anything, "+11234567890", "pray").Return(nil)
+
+	require.NoError(t, sender.SendMessage(ctx, "+11234567890", "pray"))
+	require.Len(t, windows, 2)
+	require.NotEqual(t, windows[0], windows[1])
+}
+
+func TestRateLimiterSendDeferred(t *testing.T) {
+	ctx := context.Background()
+	next := msgmocks.NewMockMessageSender(t)
+	deferred := repomocks.NewMockDeferredMessageRepository(t)
+	counts := repomocks.NewMockSendCountRepository(t)
+	cfg := config.RateLimitConfig{MessagesPerSecond: 0, DailyPerPhone: 1}
+	limiter := messaging.NewRateLimiter(deferred, counts, cfg)
+
+	deferred.EXPECT().GetAll(mock.Anything).Return([]domain.DeferredMessage{
+		{ID: "b", Phone: "+12222222222", Body: "newer", Created: "2025-01-02T00:00:00Z"},
+		{ID: "a", Phone: "+11111111111", Body: "older", Created: "2025-01-01T00:00:00Z"},
+		{ID: "c", Phone: "+13333333333", Body: "capped", Created: "2025-01-03T00:00:00Z"},
+	}, nil)
+	counts.EXPECT().Take(mock.Anything, "+11111111111", today(), 1, mock.Anything).Return(true, nil)
+	counts.EXPECT().Take(mock.Anything, "+12222222222", today(), 1, mock.Anything).Return(true, nil)
+	counts.EXPECT().Take(mock.Anything, "+13333333333", today(), 1, mock.Anything).Return(false, nil)
+
+	var order []string
+	next.EXPECT().SendMessage(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ string, body string) { order = append(order, body) }).
+		Return(nil).Twice()
+	deferred.EXPECT().Delete(mock.Anything, "a").Return(nil)
+	deferred.EXPECT().Delete(mock.Anything, "b").Return(nil)
+
+	require.NoError(t, limiter.SendDeferred(ctx, next))
+	require.Equal(t, []string{"older", "newer"}, order)
+}
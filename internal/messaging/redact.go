@@ -0,0 +1,61 @@
+package messaging
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Placeholders that replace personal details in redacted text.
+const (
+	RedactedEmail   = "[email]"
+	RedactedPhone   = "[phone]"
+	RedactedAddress = "[address]"
+)
+
+var (
+	emailRE   = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	phoneRE   = regexp.MustCompile(`(?:\+?\d{1,3}[\s.-]?)?(?:\(\d{3}\)|\b\d{3})[\s.-]?\d{3}[\s.-]?\d{4}\b`)
+	addressRE = regexp.MustCompile(`(?i)\b\d{1,6}\s+(?:[a-z0-9.'-]+\s+){1,4}(?:street|st|avenue|ave|road|rd|` +
+		`boulevard|blvd|lane|ln|drive|dr|court|ct|way|place|pl|circle|cir|parkway|pkwy|highway|hwy|terrace|ter)\b\.?` +
+		`(?:,?\s+(?:apt|apartment|unit|suite|ste|#)\.?\s*[a-z0-9-]+\b)?`)
+	properNameRE = regexp.MustCompile(`\b[A-Z][a-z]+(?:\s+[A-Z][a-z'-]+)+\b`)
+)
+
+// notSurnames are capitalized words that often come before or after a first name but are not surnames, so they are
+// left as they are.
+var notSurnames = []string{
+	"God", "Jesus", "Christ", "Lord", "Holy", "Spirit", "Father", "Mother", "Aunt", "Uncle", "Grandma", "Grandpa",
+	"Mr", "Mrs", "Ms", "Dr", "Pastor", "Saint",
+}
+
+// Redact replaces email addresses, phone numbers and street addresses in text with placeholders so that requests can be
+// shared with intercessors without exposing anyone's contact details. When surnames is true, every capitalized word
+// that follows a first name is also shortened to its initial, so "John Smith" becomes "John S.". Surnames are found by
+// capitalization alone, which misses names written in lowercase.
+func Redact(text string, surnames bool) string {
+	text = emailRE.ReplaceAllString(text, RedactedEmail)
+	text = phoneRE.ReplaceAllString(text, RedactedPhone)
+	text = addressRE.ReplaceAllString(text, RedactedAddress)
+	if surnames {
+		text = properNameRE.ReplaceAllStringFunc(text, shortenSurnames)
+	}
+	return text
+}
+
+// shortenSurnames keeps the first name in a run of capitalized words and shortens the words after it to initials.
+func shortenSurnames(name string) string {
+	words := strings.Fields(name)
+	first := true
+	for i, word := range words {
+		if slices.Contains(notSurnames, word) {
+			continue
+		}
+		if first {
+			first = false
+			continue
+		}
+		words[i] = word[:1] + "."
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,32 @@
+package messaging_test
+
+import (
+	"testing"
+
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		text     string
+		surnames bool
+		want     string
+	}{
+		{"pray for my mom, she is sick", false, "pray for my mom, she is sick"},
+		{"call her at (555) 123-4567 or 555.123.4567", false, "call her at [phone] or [phone]"},
+		{"text +1 555 123 4567 any time", false, "text [phone] any time"},
+		{"email john.smith@example.com please", false, "email [email] please"},
+		{"she lives at 123 N Main St. Apt 4B in town", false, "she lives at [address] in town"},
+		{"they moved to 42 Oak Avenue last year", false, "they moved to [address] last year"},
+		{"pray for my 3 kids and 2 dogs", false, "pray for my 3 kids and 2 dogs"},
+		{"pray for John Smith", false, "pray for John Smith"},
+		{"pray for John Smith and Mary Ann Jones", true, "pray for John S. and Mary A. J."},
+		{"pray for Aunt Susan Miller to know Jesus Christ", true, "pray for Aunt Susan M. to know Jesus Christ"},
+		{"pray for john smith", true, "pray for john smith"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, messaging.Redact(tt.text, tt.surnames), tt.text)
+	}
+}
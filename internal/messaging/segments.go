@@ -0,0 +1,159 @@
+package messaging
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+type Encoding string
+
+const (
+	EncodingGSM7 Encoding = "GSM-7"
+	EncodingUCS2 Encoding = "UCS-2"
+)
+
+const (
+	// MaxMessageChars is the longest message body Pinpoint will accept in a single SendTextMessage call.
+	MaxMessageChars = 1600
+
+	gsm7SingleLimit = 160
+	gsm7MultiLimit  = 153
+	ucs2SingleLimit = 70
+	ucs2MultiLimit  = 67
+
+	ellipsis = "..."
+)
+
+const (
+	gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+		"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+	gsm7Extended = "\f^{}\\[~]|€"
+)
+
+// SegmentInfo describes how a message body will be billed by the carrier. Characters is counted in the units of the
+// encoding: septets for GSM-7 (extended characters count twice) and UTF-16 code units for UCS-2.
+type SegmentInfo struct {
+	Encoding   Encoding
+	Characters int
+	Segments   int
+}
+
+func CountSegments(body string) SegmentInfo {
+	info := SegmentInfo{Encoding: EncodingGSM7}
+	for _, ch := range body {
+		switch {
+		case strings.ContainsRune(gsm7Basic, ch):
+			info.Characters++
+		case strings.ContainsRune(gsm7Extended, ch):
+			info.Characters += 2
+		default:
+			return countUCS2(body)
+		}
+	}
+	info.Segments = segmentCount(info.Characters, gsm7SingleLimit, gsm7MultiLimit)
+	return info
+}
+
+func countUCS2(body string) SegmentInfo {
+	units := len(utf16.Encode([]rune(body)))
+	return SegmentInfo{
+		Encoding:   EncodingUCS2,
+		Characters: units,
+		Segments:   segmentCount(units, ucs2SingleLimit, ucs2MultiLimit),
+	}
+}
+
+func segmentCount(chars, singleLimit, multiLimit int) int {
+	if chars == 0 {
+		return 0
+	}
+	if chars <= singleLimit {
+		return 1
+	}
+	return (chars + multiLimit - 1) / multiLimit
+}
+
+// ComposeMessages wraps body with MsgPre and MsgPost. When the result is longer than MaxMessageChars, body is split at
+// word boundaries across numbered messages such as "(1/3) ". At most maxParts messages are returned; any text that
+// does not fit is truncated.
+func ComposeMessages(body string, maxParts int) []string {
+	whole := MsgPre + body + "\n\n" + MsgPost
+	if runeLen(whole) <= MaxMessageChars {
+		return []string{whole}
+	}
+
+	maxParts = max(maxParts, 1)
+	budget := MaxMessageChars - runeLen(MsgPre) - runeLen(partLabel(maxParts, maxParts)) - runeLen("\n\n"+MsgPost)
+
+	chunks := splitText(body, budget)
+	if len(chunks) > maxParts {
+		remainder := strings.Join(chunks[maxParts-1:], " ")
+		chunks = append(chunks[:maxParts-1], Truncate(remainder, budget))
+	}
+
+	messages := make([]string, 0, len(chunks))
+	for i, chunk := range chunks {
+		msg := MsgPre + partLabel(i+1, len(chunks)) + chunk
+		if i == len(chunks)-1 {
+			msg += "\n\n" + MsgPost
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages
+}
+
+// Truncate shortens text to at most limit characters, cutting at the last word boundary and appending an ellipsis.
+func Truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+
+	keep := max(limit-len(ellipsis), 0)
+	cut := lastSpace(runes[:keep+1])
+	if cut <= 0 {
+		cut = keep
+	}
+
+	return strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace) + ellipsis
+}
+
+func splitText(text string, limit int) []string {
+	var parts []string
+	runes := []rune(strings.TrimSpace(text))
+
+	for len(runes) > limit {
+		cut := lastSpace(runes[:limit+1])
+		if cut <= 0 {
+			cut = limit
+		}
+		parts = append(parts, strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace))
+		runes = []rune(strings.TrimLeftFunc(string(runes[cut:]), unicode.IsSpace))
+	}
+
+	if len(runes) > 0 {
+		parts = append(parts, string(runes))
+	}
+
+	return parts
+}
+
+func lastSpace(runes []rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if unicode.IsSpace(runes[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+func partLabel(part, total int) string {
+	return fmt.Sprintf("(%d/%d) ", part, total)
+}
+
+func runeLen(s string) int {
+	return len([]rune(s))
+}
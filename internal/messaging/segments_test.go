@@ -0,0 +1,91 @@
+package messaging_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountSegments(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		encoding messaging.Encoding
+		chars    int
+		segments int
+	}{
+		{"empty", "", messaging.EncodingGSM7, 0, 0},
+		{"short gsm", "Please pray for me", messaging.EncodingGSM7, 18, 1},
+		{"gsm single segment limit", strings.Repeat("a", 160), messaging.EncodingGSM7, 160, 1},
+		{"gsm two segments", strings.Repeat("a", 161), messaging.EncodingGSM7, 161, 2},
+		{"gsm extended chars count twice", "{}", messaging.EncodingGSM7, 4, 1},
+		{"emoji forces ucs2", "pray 🙏", messaging.EncodingUCS2, 7, 1},
+		{
+			"ucs2 two segments",
+			strings.Repeat("é", 60) + "🙏" + strings.Repeat("ж", 10),
+			messaging.EncodingUCS2, 72, 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := messaging.CountSegments(tt.body)
+			assert.Equal(t, tt.encoding, info.Encoding)
+			assert.Equal(t, tt.chars, info.Characters)
+			assert.Equal(t, tt.segments, info.Segments)
+		})
+	}
+}
+
+func TestComposeMessages(t *testing.T) {
+	t.Run("short body is wrapped into a single message", func(t *testing.T) {
+		msgs := messaging.ComposeMessages("Please pray for me", 3)
+		require.Len(t, msgs, 1)
+		assert.Equal(t, messaging.MsgPre+"Please pray for me\n\n"+messaging.MsgPost, msgs[0])
+	})
+
+	t.Run("long body is split into numbered messages", func(t *testing.T) {
+		body := strings.Repeat("pray for my family ", 150)
+		msgs := messaging.ComposeMessages(body, 3)
+		require.Len(t, msgs, 2)
+		assert.True(t, strings.HasPrefix(msgs[0], messaging.MsgPre+"(1/2) "))
+		assert.True(t, strings.HasPrefix(msgs[1], messaging.MsgPre+"(2/2) "))
+		assert.False(t, strings.HasSuffix(msgs[0], messaging.MsgPost))
+		assert.True(t, strings.HasSuffix(msgs[1], messaging.MsgPost))
+		for _, msg := range msgs {
+			assert.LessOrEqual(t, len([]rune(msg)), messaging.MaxMessageChars)
+		}
+	})
+
+	t.Run("body longer than max parts is truncated", func(t *testing.T) {
+		body := strings.Repeat("pray for my family ", 500)
+		msgs := messaging.ComposeMessages(body, 2)
+		require.Len(t, msgs, 2)
+		assert.Contains(t, msgs[1], "...\n\n"+messaging.MsgPost)
+		for _, msg := range msgs {
+			assert.LessOrEqual(t, len([]rune(msg)), messaging.MaxMessageChars)
+		}
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  string
+	}{
+		{"under limit unchanged", "pray for me", 20, "pray for me"},
+		{"cuts at word boundary", "please pray for my family", 15, "please pray..."},
+		{"no spaces hard cuts", "abcdefghijklmnop", 10, "abcdefg..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, messaging.Truncate(tt.text, tt.limit))
+		})
+	}
+}
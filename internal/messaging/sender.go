@@ -1,7 +1,104 @@
 package messaging
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/repository"
+)
+
+const (
+	ProviderPinpoint = "pinpoint"
+	ProviderTwilio   = "twilio"
+	ProviderWebhook  = "webhook"
+)
 
 type MessageSender interface {
 	SendMessage(ctx context.Context, to string, body string) error
 }
+
+const (
+	sendAttempts   = 3
+	sendRetryDelay = 500 * time.Millisecond
+)
+
+// NewSender returns the MessageSender for the provider selected in cfg, wrapped with the middleware every sender
+// needs: composition, dry run when running under sam local, metrics, logging and retries on provider throttling.
+func NewSender(
+	cfg config.SMSConfig,
+	pinpoint PinpointClient,
+	httpClient HTTPClient,
+	metrics *Metrics,
+) (MessageSender, error) {
+	provider, err := NewProvider(cfg, pinpoint, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return Chain(
+		provider,
+		WithComposition(cfg.MaxParts),
+		WithDryRun(os.Getenv("AWS_SAM_LOCAL") == "true"),
+		WithMetrics(metrics),
+		WithLogging(),
+		WithRetry(sendAttempts, sendRetryDelay),
+	), nil
+}
+
+// Outbound holds the outbound message pipeline shared by every function that sends messages.
+type Outbound struct {
+	// Sender checks opt outs, applies the rate limits and records each message in the transcript before handing it to
+	// the provider.
+	Sender MessageSender
+	// Deferred is Sender without the rate limiting middleware, for use with Limiter.SendDeferred.
+	Deferred MessageSender
+	Limiter  *RateLimiter
+	Metrics  *Metrics
+}
+
+// NewOutbound builds the outbound message pipeline for the provider selected in cfg.
+func NewOutbound(
+	cfg config.Config,
+	pinpoint PinpointClient,
+	httpClient HTTPClient,
+	optedOut repository.OptedOutPhonesRepository,
+	deferred repository.DeferredMessageRepository,
+	counts repository.SendCountRepository,
+	transcripts repository.TranscriptRepository,
+) (*Outbound, error) {
+	metrics := &Metrics{}
+	provider, err := NewSender(cfg.AWS.SMS, pinpoint, httpClient, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	limiter := NewRateLimiter(deferred, counts, cfg.AWS.SMS.RateLimit)
+	optOut := WithOptOutCheck(optedOut)
+	recording := WithRecording(transcripts, cfg.TranscriptRetentionDays)
+
+	return &Outbound{
+		Sender:   Chain(provider, optOut, limiter.Middleware(), recording),
+		Deferred: Chain(provider, optOut, recording),
+		Limiter:  limiter,
+		Metrics:  metrics,
+	}, nil
+}
+
+// NewProvider returns the bare MessageSender for the provider selected in cfg. Each call to SendMessage results in
+// exactly one message sent as is. The pinpoint client is only used by the pinpoint provider and the http client only
+// by the http based providers.
+func NewProvider(cfg config.SMSConfig, pinpoint PinpointClient, httpClient HTTPClient) (MessageSender, error) {
+	switch cfg.Provider {
+	case ProviderPinpoint, "":
+		return NewPinpointSender(pinpoint, cfg.PhonePool, cfg.Timeout), nil
+	case ProviderTwilio:
+		return NewTwilioSender(httpClient, cfg.Twilio, cfg.Timeout), nil
+	case ProviderWebhook:
+		return NewWebhookSender(httpClient, cfg.Webhook, cfg.Timeout), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, cfg.Provider)
+	}
+}
@@ -2,12 +2,14 @@ package messaging
 
 import (
 	"bytes"
+	"strconv"
 	"text/template"
+	"time"
 )
 
 var (
 	PrayerIntroTmpl = template.Must(template.New("prayerIntro").Parse(
-		"Hello! Please pray for {{.Name}}:\n\n"))
+		"{{if .Urgent}}URGENT: {{end}}Hello! Please pray for {{.Name}}:\n\n"))
 
 	ProfanityDetectedTmpl = template.Must(template.New("profanity").Parse(
 		"There was profanity found in your message:\n\n{{.Word}}\n\nPlease try again"))
@@ -17,6 +19,57 @@ var (
 
 	PrayerReminderTmpl = template.Must(template.New("prayerReminder").Parse(
 		"This is a friendly reminder to pray for {{.Name}}:\n\n"))
+
+	StatsTmpl = template.Must(template.New("stats").Parse(
+		"Members: {{.Members}}\nIntercessors: {{.Intercessors}}\nActive prayers: {{.Active}}\n" +
+			"Queued prayers: {{.Queued}}"))
+
+	MemberProfileTmpl = template.Must(template.New("memberProfile").Parse(
+		"Name: {{.Name}}\nPhone: {{.Phone}}\nStatus: {{.SetupStatus}}\nIntercessor: {{.Intercessor}}\n" +
+			"Role: {{if .Role}}{{.Role}}{{else}}none{{end}}\n" +
+			"Prayers this week: {{.PrayerCount}}/{{.WeeklyPrayerLimit}}\nInactive: {{.Inactive}}"))
+
+	RoleGrantedTmpl = template.Must(template.New("roleGranted").Parse(
+		"The member has been successfully given the {{.Role}} role."))
+
+	ModerationNoticeTmpl = template.Must(template.New("moderationNotice").Parse(
+		"{{if .Priority}}URGENT: {{end}}A prayer request from {{.Requestor.Name}} ({{.Requestor.Phone}}) matched " +
+			"\"{{.Rule}}\" and is waiting for review:\n\n{{.Request}}\n\nReply #approve {{.ID}}, #edit {{.ID}} " +
+			"followed by the new request, or #reject {{.ID}} followed by an optional reason."))
+
+	CrisisAlertTmpl = template.Must(template.New("crisisAlert").Parse(
+		"Crisis alert: a prayer request from {{.Name}} ({{.Phone}}) mentions \"{{.Keyword}}\" and was sent crisis " +
+			"resources:\n\n{{.Request}}\n\n{{if .Held}}It is waiting for review and will be sent to intercessors " +
+			"first once approved.{{else}}It is being sent to intercessors first.{{end}} Please consider reaching out."))
+
+	RequestLimitTmpl = template.Must(template.New("requestLimit").Parse(
+		"Sorry, you can only send {{.Limit}} prayer requests per {{.Period}}. Please try again later."))
+
+	ThrottleAlertTmpl = template.Must(template.New("throttleAlert").Parse(
+		"Throttle alert: {{.Name}} ({{.Phone}}) has had {{.Count}} prayer requests refused this week for sending too " +
+			"many or repeating them. Their latest request was:\n\n{{.Request}}\n\nReply #block {{.Phone}} to " +
+			"block them."))
+
+	PrayerRejectedTmpl = template.Must(template.New("prayerRejected").Parse(
+		"Your prayer request was reviewed and could not be sent to intercessors.{{if .Reason}} Reason: " +
+			"{{.Reason}}{{end}}"))
+
+	QueueItemTmpl = template.Must(template.New("queueItem").Parse(
+		"{{.Number}}. {{.Name}} ({{.Phone}}): {{.Request}}"))
+
+	MyDataTmpl = template.Must(template.New("myData").Parse(
+		"PrayerTexter stores this about {{.Phone}}:\n" +
+			"{{if .Member}}Name: {{.Name}}\nSigned up: {{.SignUpDate}}\nIntercessor: " +
+			"{{if .Intercessor}}yes, up to {{.WeeklyPrayerLimit}} prayers a week{{else}}no{{end}}\n" +
+			"Opted in: {{.ConsentDate}}\n{{else}}You are not signed up.\n{{end}}" +
+			"Open prayer requests: {{.Open}}\nRequests in the last week: {{.Recent}}\n" +
+			"Prayers completed: {{.Completed}}\nText messages: {{.Messages}}, each kept for {{.RetentionDays}} days" +
+			"\n\nReply deletemydata to delete all of it."))
+
+	WeeklyReportTmpl = template.Must(template.New("weeklyReport").Parse(
+		"Weekly summary:\nNew members: {{.NewMembers}}\nRequests received: {{.Requests}}\n" +
+			"Prayers completed: {{.Completed}}\nMedian time to prayed: {{.MedianTimeToPrayed}}\n" +
+			"Queued prayers: {{.Queued}}"))
 )
 
 func Render(tmpl *template.Template, data any) (string, error) {
@@ -26,3 +79,17 @@ func Render(tmpl *template.Template, data any) (string, error) {
 	}
 	return buf.String(), nil
 }
+
+// FormatAge formats d in the largest whole unit that fits, such as 3d, 5h or 12m.
+func FormatAge(d time.Duration) string {
+	const day = 24 * time.Hour
+
+	switch {
+	case d >= day:
+		return strconv.Itoa(int(d/day)) + "d"
+	case d >= time.Hour:
+		return strconv.Itoa(int(d/time.Hour)) + "h"
+	default:
+		return strconv.Itoa(int(d/time.Minute)) + "m"
+	}
+}
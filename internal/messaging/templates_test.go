@@ -3,7 +3,9 @@ package messaging_test
 import (
 	"testing"
 	"text/template"
+	"time"
 
+	"github.com/4JesusApps/prayertexter/internal/domain"
 	"github.com/4JesusApps/prayertexter/internal/messaging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,10 +18,38 @@ func TestRender(t *testing.T) {
 		data     any
 		contains string
 	}{
-		{"prayer intro", messaging.PrayerIntroTmpl, struct{ Name string }{"John"}, "Hello! Please pray for John:\n\n"},
+		{"prayer intro", messaging.PrayerIntroTmpl, struct {
+			Name   string
+			Urgent bool
+		}{"John", false}, "Hello! Please pray for John:\n\n"},
+		{"urgent prayer intro", messaging.PrayerIntroTmpl, struct {
+			Name   string
+			Urgent bool
+		}{"John", true}, "URGENT: Hello!"},
+		{"crisis alert", messaging.CrisisAlertTmpl, struct {
+			Name, Phone, Keyword, Request string
+			Held                          bool
+		}{"Ann", "+11234567890", "suicide", "help", true}, "mentions \"suicide\" and was sent crisis resources:\n\n" +
+			"help\n\nIt is waiting for review"},
 		{"profanity detected", messaging.ProfanityDetectedTmpl, struct{ Word string }{"badword"}, "badword"},
 		{"prayer confirmation", messaging.PrayerConfirmationTmpl, struct{ Name string }{"Jane"}, "Jane"},
 		{"prayer reminder", messaging.PrayerReminderTmpl, struct{ Name string }{"Bob"}, "Bob"},
+		{"stats", messaging.StatsTmpl, struct{ Members, Intercessors, Active, Queued int }{4, 3, 2, 1}, "Members: 4"},
+		{"member profile", messaging.MemberProfileTmpl, struct {
+			Name, Phone, SetupStatus, Role string
+			Intercessor, Inactive          bool
+			PrayerCount, WeeklyPrayerLimit int
+		}{Name: "Ann", PrayerCount: 1, WeeklyPrayerLimit: 3}, "Role: none\nPrayers this week: 1/3"},
+		{"role granted", messaging.RoleGrantedTmpl, struct{ Role string }{"owner"}, "given the owner role"},
+		{"moderation notice", messaging.ModerationNoticeTmpl, domain.PendingPrayer{
+			ID: "1a2b3c", Request: "help", Rule: "abuse",
+			Requestor: domain.MemberRef{Name: "Ann", Phone: "+11234567890"},
+		}, "Ann (+11234567890) matched \"abuse\""},
+		{"prayer rejected", messaging.PrayerRejectedTmpl, struct{ Reason string }{""}, "intercessors."},
+		{"weekly report", messaging.WeeklyReportTmpl, struct {
+			NewMembers, Requests, Completed, Queued int
+			MedianTimeToPrayed                      string
+		}{2, 5, 4, 1, "3h"}, "Median time to prayed: 3h"},
 	}
 
 	for _, tt := range tests {
@@ -30,3 +60,9 @@ func TestRender(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatAge(t *testing.T) {
+	assert.Equal(t, "12m", messaging.FormatAge(12*time.Minute+30*time.Second))
+	assert.Equal(t, "5h", messaging.FormatAge(5*time.Hour+59*time.Minute))
+	assert.Equal(t, "3d", messaging.FormatAge(80*time.Hour))
+}
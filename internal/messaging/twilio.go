@@ -0,0 +1,68 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/config"
+)
+
+// TwilioSender sends text messages through the Twilio Programmable Messaging REST API, or any service that implements
+// the same API.
+type TwilioSender struct {
+	client     HTTPClient
+	baseURL    string
+	accountSID string
+	authToken  string
+	from       string
+	timeout    int
+}
+
+// twilioMessage is the subset of the Twilio message resource returned on send that we care about.
+type twilioMessage struct {
+	SID string `json:"sid"`
+}
+
+func NewTwilioSender(client HTTPClient, cfg config.TwilioConfig, timeout int) *TwilioSender {
+	return &TwilioSender{
+		client:     client,
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		accountSID: cfg.AccountSID,
+		authToken:  cfg.AuthToken,
+		from:       cfg.From,
+		timeout:    timeout,
+	}
+}
+
+func (s *TwilioSender) SendMessage(ctx context.Context, to string, body string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(s.timeout)*time.Second)
+	defer cancel()
+
+	form := url.Values{}
+	form.Set("To", to)
+	form.Set("From", s.from)
+	form.Set("Body", body)
+
+	endpoint := s.baseURL + "/2010-04-01/Accounts/" + url.PathEscape(s.accountSID) + "/Messages.json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(s.accountSID, s.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	respBody, err := doRequest(s.client, req)
+	if err != nil {
+		return err
+	}
+
+	// The message was sent, so a response without a sid only loses the ID.
+	var msg twilioMessage
+	_ = json.Unmarshal(respBody, &msg)
+	reportMessageID(ctx, msg.SID)
+	return nil
+}
@@ -0,0 +1,68 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/config"
+)
+
+// WebhookSender hands outbound text messages to an HTTP endpoint as JSON. This allows any SMS gateway to be used by
+// putting a small adapter in front of it.
+type WebhookSender struct {
+	client  HTTPClient
+	url     string
+	token   string
+	timeout int
+}
+
+type webhookPayload struct {
+	To   string `json:"to"`
+	Body string `json:"body"`
+}
+
+type webhookResponse struct {
+	ID string `json:"id"`
+}
+
+func NewWebhookSender(client HTTPClient, cfg config.WebhookConfig, timeout int) *WebhookSender {
+	return &WebhookSender{
+		client:  client,
+		url:     cfg.URL,
+		token:   cfg.Token,
+		timeout: timeout,
+	}
+}
+
+func (s *WebhookSender) SendMessage(ctx context.Context, to string, body string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(s.timeout)*time.Second)
+	defer cancel()
+
+	payload, err := json.Marshal(webhookPayload{To: to, Body: body})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	respBody, err := doRequest(s.client, req)
+	if err != nil {
+		return err
+	}
+
+	// Endpoints may answer with the ID of the message they sent, but are not required to.
+	var resp webhookResponse
+	_ = json.Unmarshal(respBody, &resp)
+	reportMessageID(ctx, resp.ID)
+	return nil
+}
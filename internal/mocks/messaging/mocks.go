@@ -7,9 +7,124 @@ package mocks
 import (
 	"context"
 
+	"github.com/aws/aws-sdk-go-v2/service/pinpointsmsvoicev2"
 	mock "github.com/stretchr/testify/mock"
 )
 
+// NewMockPinpointClient creates a new instance of MockPinpointClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPinpointClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPinpointClient {
+	mock := &MockPinpointClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPinpointClient is an autogenerated mock type for the PinpointClient type
+type MockPinpointClient struct {
+	mock.Mock
+}
+
+type MockPinpointClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPinpointClient) EXPECT() *MockPinpointClient_Expecter {
+	return &MockPinpointClient_Expecter{mock: &_m.Mock}
+}
+
+// SendTextMessage provides a mock function for the type MockPinpointClient
+func (_mock *MockPinpointClient) SendTextMessage(ctx context.Context, params *pinpointsmsvoicev2.SendTextMessageInput, optFns ...func(*pinpointsmsvoicev2.Options)) (*pinpointsmsvoicev2.SendTextMessageOutput, error) {
+	// func(*pinpointsmsvoicev2.Options)
+	_va := make([]interface{}, len(optFns))
+	for _i := range optFns {
+		_va[_i] = optFns[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, params)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendTextMessage")
+	}
+
+	var r0 *pinpointsmsvoicev2.SendTextMessageOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *pinpointsmsvoicev2.SendTextMessageInput, ...func(*pinpointsmsvoicev2.Options)) (*pinpointsmsvoicev2.SendTextMessageOutput, error)); ok {
+		return returnFunc(ctx, params, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *pinpointsmsvoicev2.SendTextMessageInput, ...func(*pinpointsmsvoicev2.Options)) *pinpointsmsvoicev2.SendTextMessageOutput); ok {
+		r0 = returnFunc(ctx, params, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pinpointsmsvoicev2.SendTextMessageOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *pinpointsmsvoicev2.SendTextMessageInput, ...func(*pinpointsmsvoicev2.Options)) error); ok {
+		r1 = returnFunc(ctx, params, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPinpointClient_SendTextMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendTextMessage'
+type MockPinpointClient_SendTextMessage_Call struct {
+	*mock.Call
+}
+
+// SendTextMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params *pinpointsmsvoicev2.SendTextMessageInput
+//   - optFns ...func(*pinpointsmsvoicev2.Options)
+func (_e *MockPinpointClient_Expecter) SendTextMessage(ctx interface{}, params interface{}, optFns ...interface{}) *MockPinpointClient_SendTextMessage_Call {
+	return &MockPinpointClient_SendTextMessage_Call{Call: _e.mock.On("SendTextMessage",
+		append([]interface{}{ctx, params}, optFns...)...)}
+}
+
+func (_c *MockPinpointClient_SendTextMessage_Call) Run(run func(ctx context.Context, params *pinpointsmsvoicev2.SendTextMessageInput, optFns ...func(*pinpointsmsvoicev2.Options))) *MockPinpointClient_SendTextMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *pinpointsmsvoicev2.SendTextMessageInput
+		if args[1] != nil {
+			arg1 = args[1].(*pinpointsmsvoicev2.SendTextMessageInput)
+		}
+		var arg2 []func(*pinpointsmsvoicev2.Options)
+		variadicArgs := make([]func(*pinpointsmsvoicev2.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*pinpointsmsvoicev2.Options))
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPinpointClient_SendTextMessage_Call) Return(sendTextMessageOutput *pinpointsmsvoicev2.SendTextMessageOutput, err error) *MockPinpointClient_SendTextMessage_Call {
+	_c.Call.Return(sendTextMessageOutput, err)
+	return _c
+}
+
+func (_c *MockPinpointClient_SendTextMessage_Call) RunAndReturn(run func(ctx context.Context, params *pinpointsmsvoicev2.SendTextMessageInput, optFns ...func(*pinpointsmsvoicev2.Options)) (*pinpointsmsvoicev2.SendTextMessageOutput, error)) *MockPinpointClient_SendTextMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockMessageSender creates a new instance of MockMessageSender. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockMessageSender(t interface {
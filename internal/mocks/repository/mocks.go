@@ -6,19 +6,20 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/4JesusApps/prayertexter/internal/domain"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	mock "github.com/stretchr/testify/mock"
 )
 
-// NewMockDDBClient creates a new instance of MockDDBClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockAuditRepository creates a new instance of MockAuditRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockDDBClient(t interface {
+func NewMockAuditRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockDDBClient {
-	mock := &MockDDBClient{}
+}) *MockAuditRepository {
+	mock := &MockAuditRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -26,339 +27,1156 @@ func NewMockDDBClient(t interface {
 	return mock
 }
 
-// MockDDBClient is an autogenerated mock type for the DDBClient type
-type MockDDBClient struct {
+// MockAuditRepository is an autogenerated mock type for the AuditRepository type
+type MockAuditRepository struct {
 	mock.Mock
 }
 
-type MockDDBClient_Expecter struct {
+type MockAuditRepository_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockDDBClient) EXPECT() *MockDDBClient_Expecter {
-	return &MockDDBClient_Expecter{mock: &_m.Mock}
+func (_m *MockAuditRepository) EXPECT() *MockAuditRepository_Expecter {
+	return &MockAuditRepository_Expecter{mock: &_m.Mock}
 }
 
-// DeleteItem provides a mock function for the type MockDDBClient
-func (_mock *MockDDBClient) DeleteItem(ctx context.Context, input *dynamodb.DeleteItemInput, opts ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
-	// func(*dynamodb.Options)
-	_va := make([]interface{}, len(opts))
-	for _i := range opts {
-		_va[_i] = opts[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, ctx, input)
-	_ca = append(_ca, _va...)
-	ret := _mock.Called(_ca...)
+// GetByAdmin provides a mock function for the type MockAuditRepository
+func (_mock *MockAuditRepository) GetByAdmin(ctx context.Context, adminPhone string) ([]domain.AuditEntry, error) {
+	ret := _mock.Called(ctx, adminPhone)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteItem")
+		panic("no return value specified for GetByAdmin")
 	}
 
-	var r0 *dynamodb.DeleteItemOutput
+	var r0 []domain.AuditEntry
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.DeleteItemInput, ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)); ok {
-		return returnFunc(ctx, input, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.AuditEntry, error)); ok {
+		return returnFunc(ctx, adminPhone)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.DeleteItemInput, ...func(*dynamodb.Options)) *dynamodb.DeleteItemOutput); ok {
-		r0 = returnFunc(ctx, input, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.AuditEntry); ok {
+		r0 = returnFunc(ctx, adminPhone)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*dynamodb.DeleteItemOutput)
+			r0 = ret.Get(0).([]domain.AuditEntry)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *dynamodb.DeleteItemInput, ...func(*dynamodb.Options)) error); ok {
-		r1 = returnFunc(ctx, input, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, adminPhone)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDDBClient_DeleteItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteItem'
-type MockDDBClient_DeleteItem_Call struct {
+// MockAuditRepository_GetByAdmin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByAdmin'
+type MockAuditRepository_GetByAdmin_Call struct {
 	*mock.Call
 }
 
-// DeleteItem is a helper method to define mock.On call
+// GetByAdmin is a helper method to define mock.On call
 //   - ctx context.Context
-//   - input *dynamodb.DeleteItemInput
-//   - opts ...func(*dynamodb.Options)
-func (_e *MockDDBClient_Expecter) DeleteItem(ctx interface{}, input interface{}, opts ...interface{}) *MockDDBClient_DeleteItem_Call {
-	return &MockDDBClient_DeleteItem_Call{Call: _e.mock.On("DeleteItem",
-		append([]interface{}{ctx, input}, opts...)...)}
+//   - adminPhone string
+func (_e *MockAuditRepository_Expecter) GetByAdmin(ctx interface{}, adminPhone interface{}) *MockAuditRepository_GetByAdmin_Call {
+	return &MockAuditRepository_GetByAdmin_Call{Call: _e.mock.On("GetByAdmin", ctx, adminPhone)}
 }
 
-func (_c *MockDDBClient_DeleteItem_Call) Run(run func(ctx context.Context, input *dynamodb.DeleteItemInput, opts ...func(*dynamodb.Options))) *MockDDBClient_DeleteItem_Call {
+func (_c *MockAuditRepository_GetByAdmin_Call) Run(run func(ctx context.Context, adminPhone string)) *MockAuditRepository_GetByAdmin_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *dynamodb.DeleteItemInput
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*dynamodb.DeleteItemInput)
-		}
-		var arg2 []func(*dynamodb.Options)
-		variadicArgs := make([]func(*dynamodb.Options), len(args)-2)
-		for i, a := range args[2:] {
-			if a != nil {
-				variadicArgs[i] = a.(func(*dynamodb.Options))
-			}
+			arg1 = args[1].(string)
 		}
-		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDDBClient_DeleteItem_Call) Return(deleteItemOutput *dynamodb.DeleteItemOutput, err error) *MockDDBClient_DeleteItem_Call {
-	_c.Call.Return(deleteItemOutput, err)
+func (_c *MockAuditRepository_GetByAdmin_Call) Return(auditEntrys []domain.AuditEntry, err error) *MockAuditRepository_GetByAdmin_Call {
+	_c.Call.Return(auditEntrys, err)
 	return _c
 }
 
-func (_c *MockDDBClient_DeleteItem_Call) RunAndReturn(run func(ctx context.Context, input *dynamodb.DeleteItemInput, opts ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)) *MockDDBClient_DeleteItem_Call {
+func (_c *MockAuditRepository_GetByAdmin_Call) RunAndReturn(run func(ctx context.Context, adminPhone string) ([]domain.AuditEntry, error)) *MockAuditRepository_GetByAdmin_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetItem provides a mock function for the type MockDDBClient
-func (_mock *MockDDBClient) GetItem(ctx context.Context, input *dynamodb.GetItemInput, opts ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
-	// func(*dynamodb.Options)
-	_va := make([]interface{}, len(opts))
-	for _i := range opts {
-		_va[_i] = opts[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, ctx, input)
-	_ca = append(_ca, _va...)
-	ret := _mock.Called(_ca...)
+// GetByTarget provides a mock function for the type MockAuditRepository
+func (_mock *MockAuditRepository) GetByTarget(ctx context.Context, target string) ([]domain.AuditEntry, error) {
+	ret := _mock.Called(ctx, target)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetItem")
+		panic("no return value specified for GetByTarget")
 	}
 
-	var r0 *dynamodb.GetItemOutput
+	var r0 []domain.AuditEntry
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.GetItemInput, ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)); ok {
-		return returnFunc(ctx, input, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.AuditEntry, error)); ok {
+		return returnFunc(ctx, target)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.GetItemInput, ...func(*dynamodb.Options)) *dynamodb.GetItemOutput); ok {
-		r0 = returnFunc(ctx, input, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.AuditEntry); ok {
+		r0 = returnFunc(ctx, target)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*dynamodb.GetItemOutput)
+			r0 = ret.Get(0).([]domain.AuditEntry)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *dynamodb.GetItemInput, ...func(*dynamodb.Options)) error); ok {
-		r1 = returnFunc(ctx, input, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, target)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDDBClient_GetItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItem'
-type MockDDBClient_GetItem_Call struct {
+// MockAuditRepository_GetByTarget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTarget'
+type MockAuditRepository_GetByTarget_Call struct {
 	*mock.Call
 }
 
-// GetItem is a helper method to define mock.On call
+// GetByTarget is a helper method to define mock.On call
 //   - ctx context.Context
-//   - input *dynamodb.GetItemInput
-//   - opts ...func(*dynamodb.Options)
-func (_e *MockDDBClient_Expecter) GetItem(ctx interface{}, input interface{}, opts ...interface{}) *MockDDBClient_GetItem_Call {
-	return &MockDDBClient_GetItem_Call{Call: _e.mock.On("GetItem",
-		append([]interface{}{ctx, input}, opts...)...)}
+//   - target string
+func (_e *MockAuditRepository_Expecter) GetByTarget(ctx interface{}, target interface{}) *MockAuditRepository_GetByTarget_Call {
+	return &MockAuditRepository_GetByTarget_Call{Call: _e.mock.On("GetByTarget", ctx, target)}
 }
 
-func (_c *MockDDBClient_GetItem_Call) Run(run func(ctx context.Context, input *dynamodb.GetItemInput, opts ...func(*dynamodb.Options))) *MockDDBClient_GetItem_Call {
+func (_c *MockAuditRepository_GetByTarget_Call) Run(run func(ctx context.Context, target string)) *MockAuditRepository_GetByTarget_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *dynamodb.GetItemInput
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*dynamodb.GetItemInput)
-		}
-		var arg2 []func(*dynamodb.Options)
-		variadicArgs := make([]func(*dynamodb.Options), len(args)-2)
-		for i, a := range args[2:] {
-			if a != nil {
-				variadicArgs[i] = a.(func(*dynamodb.Options))
-			}
+			arg1 = args[1].(string)
 		}
-		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDDBClient_GetItem_Call) Return(getItemOutput *dynamodb.GetItemOutput, err error) *MockDDBClient_GetItem_Call {
-	_c.Call.Return(getItemOutput, err)
+func (_c *MockAuditRepository_GetByTarget_Call) Return(auditEntrys []domain.AuditEntry, err error) *MockAuditRepository_GetByTarget_Call {
+	_c.Call.Return(auditEntrys, err)
 	return _c
 }
 
-func (_c *MockDDBClient_GetItem_Call) RunAndReturn(run func(ctx context.Context, input *dynamodb.GetItemInput, opts ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)) *MockDDBClient_GetItem_Call {
+func (_c *MockAuditRepository_GetByTarget_Call) RunAndReturn(run func(ctx context.Context, target string) ([]domain.AuditEntry, error)) *MockAuditRepository_GetByTarget_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PutItem provides a mock function for the type MockDDBClient
-func (_mock *MockDDBClient) PutItem(ctx context.Context, input *dynamodb.PutItemInput, opts ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
-	// func(*dynamodb.Options)
-	_va := make([]interface{}, len(opts))
-	for _i := range opts {
-		_va[_i] = opts[_i]
+// Save provides a mock function for the type MockAuditRepository
+func (_mock *MockAuditRepository) Save(ctx context.Context, entry *domain.AuditEntry) error {
+	ret := _mock.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
 	}
-	var _ca []interface{}
-	_ca = append(_ca, ctx, input)
-	_ca = append(_ca, _va...)
-	ret := _mock.Called(_ca...)
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.AuditEntry) error); ok {
+		r0 = returnFunc(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuditRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockAuditRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry *domain.AuditEntry
+func (_e *MockAuditRepository_Expecter) Save(ctx interface{}, entry interface{}) *MockAuditRepository_Save_Call {
+	return &MockAuditRepository_Save_Call{Call: _e.mock.On("Save", ctx, entry)}
+}
+
+func (_c *MockAuditRepository_Save_Call) Run(run func(ctx context.Context, entry *domain.AuditEntry)) *MockAuditRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.AuditEntry
+		if args[1] != nil {
+			arg1 = args[1].(*domain.AuditEntry)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditRepository_Save_Call) Return(err error) *MockAuditRepository_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuditRepository_Save_Call) RunAndReturn(run func(ctx context.Context, entry *domain.AuditEntry) error) *MockAuditRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockConsentRecordRepository creates a new instance of MockConsentRecordRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockConsentRecordRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockConsentRecordRepository {
+	mock := &MockConsentRecordRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockConsentRecordRepository is an autogenerated mock type for the ConsentRecordRepository type
+type MockConsentRecordRepository struct {
+	mock.Mock
+}
+
+type MockConsentRecordRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockConsentRecordRepository) EXPECT() *MockConsentRecordRepository_Expecter {
+	return &MockConsentRecordRepository_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function for the type MockConsentRecordRepository
+func (_mock *MockConsentRecordRepository) Get(ctx context.Context, phone string) (*domain.ConsentRecord, error) {
+	ret := _mock.Called(ctx, phone)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PutItem")
+		panic("no return value specified for Get")
 	}
 
-	var r0 *dynamodb.PutItemOutput
+	var r0 *domain.ConsentRecord
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.PutItemInput, ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)); ok {
-		return returnFunc(ctx, input, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.ConsentRecord, error)); ok {
+		return returnFunc(ctx, phone)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.PutItemInput, ...func(*dynamodb.Options)) *dynamodb.PutItemOutput); ok {
-		r0 = returnFunc(ctx, input, opts...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.ConsentRecord); ok {
+		r0 = returnFunc(ctx, phone)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*dynamodb.PutItemOutput)
+			r0 = ret.Get(0).(*domain.ConsentRecord)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *dynamodb.PutItemInput, ...func(*dynamodb.Options)) error); ok {
-		r1 = returnFunc(ctx, input, opts...)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, phone)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDDBClient_PutItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutItem'
-type MockDDBClient_PutItem_Call struct {
+// MockConsentRecordRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockConsentRecordRepository_Get_Call struct {
 	*mock.Call
 }
 
-// PutItem is a helper method to define mock.On call
+// Get is a helper method to define mock.On call
 //   - ctx context.Context
-//   - input *dynamodb.PutItemInput
-//   - opts ...func(*dynamodb.Options)
-func (_e *MockDDBClient_Expecter) PutItem(ctx interface{}, input interface{}, opts ...interface{}) *MockDDBClient_PutItem_Call {
-	return &MockDDBClient_PutItem_Call{Call: _e.mock.On("PutItem",
-		append([]interface{}{ctx, input}, opts...)...)}
+//   - phone string
+func (_e *MockConsentRecordRepository_Expecter) Get(ctx interface{}, phone interface{}) *MockConsentRecordRepository_Get_Call {
+	return &MockConsentRecordRepository_Get_Call{Call: _e.mock.On("Get", ctx, phone)}
 }
 
-func (_c *MockDDBClient_PutItem_Call) Run(run func(ctx context.Context, input *dynamodb.PutItemInput, opts ...func(*dynamodb.Options))) *MockDDBClient_PutItem_Call {
+func (_c *MockConsentRecordRepository_Get_Call) Run(run func(ctx context.Context, phone string)) *MockConsentRecordRepository_Get_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *dynamodb.PutItemInput
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*dynamodb.PutItemInput)
-		}
-		var arg2 []func(*dynamodb.Options)
-		variadicArgs := make([]func(*dynamodb.Options), len(args)-2)
-		for i, a := range args[2:] {
-			if a != nil {
-				variadicArgs[i] = a.(func(*dynamodb.Options))
-			}
+			arg1 = args[1].(string)
 		}
-		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDDBClient_PutItem_Call) Return(putItemOutput *dynamodb.PutItemOutput, err error) *MockDDBClient_PutItem_Call {
-	_c.Call.Return(putItemOutput, err)
+func (_c *MockConsentRecordRepository_Get_Call) Return(consentRecord *domain.ConsentRecord, err error) *MockConsentRecordRepository_Get_Call {
+	_c.Call.Return(consentRecord, err)
 	return _c
 }
 
-func (_c *MockDDBClient_PutItem_Call) RunAndReturn(run func(ctx context.Context, input *dynamodb.PutItemInput, opts ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)) *MockDDBClient_PutItem_Call {
+func (_c *MockConsentRecordRepository_Get_Call) RunAndReturn(run func(ctx context.Context, phone string) (*domain.ConsentRecord, error)) *MockConsentRecordRepository_Get_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Scan provides a mock function for the type MockDDBClient
-func (_mock *MockDDBClient) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
-	// func(*dynamodb.Options)
-	_va := make([]interface{}, len(optFns))
-	for _i := range optFns {
-		_va[_i] = optFns[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, ctx, params)
-	_ca = append(_ca, _va...)
-	ret := _mock.Called(_ca...)
+// Save provides a mock function for the type MockConsentRecordRepository
+func (_mock *MockConsentRecordRepository) Save(ctx context.Context, record *domain.ConsentRecord) error {
+	ret := _mock.Called(ctx, record)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Scan")
+		panic("no return value specified for Save")
 	}
 
-	var r0 *dynamodb.ScanOutput
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.ScanInput, ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)); ok {
-		return returnFunc(ctx, params, optFns...)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.ScanInput, ...func(*dynamodb.Options)) *dynamodb.ScanOutput); ok {
-		r0 = returnFunc(ctx, params, optFns...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*dynamodb.ScanOutput)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *dynamodb.ScanInput, ...func(*dynamodb.Options)) error); ok {
-		r1 = returnFunc(ctx, params, optFns...)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.ConsentRecord) error); ok {
+		r0 = returnFunc(ctx, record)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDDBClient_Scan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Scan'
-type MockDDBClient_Scan_Call struct {
+// MockConsentRecordRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockConsentRecordRepository_Save_Call struct {
 	*mock.Call
 }
 
-// Scan is a helper method to define mock.On call
+// Save is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params *dynamodb.ScanInput
-//   - optFns ...func(*dynamodb.Options)
-func (_e *MockDDBClient_Expecter) Scan(ctx interface{}, params interface{}, optFns ...interface{}) *MockDDBClient_Scan_Call {
-	return &MockDDBClient_Scan_Call{Call: _e.mock.On("Scan",
-		append([]interface{}{ctx, params}, optFns...)...)}
+//   - record *domain.ConsentRecord
+func (_e *MockConsentRecordRepository_Expecter) Save(ctx interface{}, record interface{}) *MockConsentRecordRepository_Save_Call {
+	return &MockConsentRecordRepository_Save_Call{Call: _e.mock.On("Save", ctx, record)}
 }
 
-func (_c *MockDDBClient_Scan_Call) Run(run func(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options))) *MockDDBClient_Scan_Call {
+func (_c *MockConsentRecordRepository_Save_Call) Run(run func(ctx context.Context, record *domain.ConsentRecord)) *MockConsentRecordRepository_Save_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *dynamodb.ScanInput
+		var arg1 *domain.ConsentRecord
 		if args[1] != nil {
-			arg1 = args[1].(*dynamodb.ScanInput)
+			arg1 = args[1].(*domain.ConsentRecord)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockConsentRecordRepository_Save_Call) Return(err error) *MockConsentRecordRepository_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockConsentRecordRepository_Save_Call) RunAndReturn(run func(ctx context.Context, record *domain.ConsentRecord) error) *MockConsentRecordRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeliveryReceiptRepository creates a new instance of MockDeliveryReceiptRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeliveryReceiptRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeliveryReceiptRepository {
+	mock := &MockDeliveryReceiptRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeliveryReceiptRepository is an autogenerated mock type for the DeliveryReceiptRepository type
+type MockDeliveryReceiptRepository struct {
+	mock.Mock
+}
+
+type MockDeliveryReceiptRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeliveryReceiptRepository) EXPECT() *MockDeliveryReceiptRepository_Expecter {
+	return &MockDeliveryReceiptRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockDeliveryReceiptRepository
+func (_mock *MockDeliveryReceiptRepository) Delete(ctx context.Context, messageID string) error {
+	ret := _mock.Called(ctx, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, messageID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeliveryReceiptRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockDeliveryReceiptRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - messageID string
+func (_e *MockDeliveryReceiptRepository_Expecter) Delete(ctx interface{}, messageID interface{}) *MockDeliveryReceiptRepository_Delete_Call {
+	return &MockDeliveryReceiptRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, messageID)}
+}
+
+func (_c *MockDeliveryReceiptRepository_Delete_Call) Run(run func(ctx context.Context, messageID string)) *MockDeliveryReceiptRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeliveryReceiptRepository_Delete_Call) Return(err error) *MockDeliveryReceiptRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeliveryReceiptRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, messageID string) error) *MockDeliveryReceiptRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockDeliveryReceiptRepository
+func (_mock *MockDeliveryReceiptRepository) Get(ctx context.Context, messageID string) (*domain.DeliveryReceipt, error) {
+	ret := _mock.Called(ctx, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.DeliveryReceipt
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.DeliveryReceipt, error)); ok {
+		return returnFunc(ctx, messageID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.DeliveryReceipt); ok {
+		r0 = returnFunc(ctx, messageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DeliveryReceipt)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, messageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeliveryReceiptRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockDeliveryReceiptRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - messageID string
+func (_e *MockDeliveryReceiptRepository_Expecter) Get(ctx interface{}, messageID interface{}) *MockDeliveryReceiptRepository_Get_Call {
+	return &MockDeliveryReceiptRepository_Get_Call{Call: _e.mock.On("Get", ctx, messageID)}
+}
+
+func (_c *MockDeliveryReceiptRepository_Get_Call) Run(run func(ctx context.Context, messageID string)) *MockDeliveryReceiptRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeliveryReceiptRepository_Get_Call) Return(deliveryReceipt *domain.DeliveryReceipt, err error) *MockDeliveryReceiptRepository_Get_Call {
+	_c.Call.Return(deliveryReceipt, err)
+	return _c
+}
+
+func (_c *MockDeliveryReceiptRepository_Get_Call) RunAndReturn(run func(ctx context.Context, messageID string) (*domain.DeliveryReceipt, error)) *MockDeliveryReceiptRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function for the type MockDeliveryReceiptRepository
+func (_mock *MockDeliveryReceiptRepository) GetAll(ctx context.Context) ([]domain.DeliveryReceipt, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.DeliveryReceipt
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.DeliveryReceipt, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.DeliveryReceipt); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.DeliveryReceipt)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeliveryReceiptRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockDeliveryReceiptRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDeliveryReceiptRepository_Expecter) GetAll(ctx interface{}) *MockDeliveryReceiptRepository_GetAll_Call {
+	return &MockDeliveryReceiptRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx)}
+}
+
+func (_c *MockDeliveryReceiptRepository_GetAll_Call) Run(run func(ctx context.Context)) *MockDeliveryReceiptRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeliveryReceiptRepository_GetAll_Call) Return(deliveryReceipts []domain.DeliveryReceipt, err error) *MockDeliveryReceiptRepository_GetAll_Call {
+	_c.Call.Return(deliveryReceipts, err)
+	return _c
+}
+
+func (_c *MockDeliveryReceiptRepository_GetAll_Call) RunAndReturn(run func(ctx context.Context) ([]domain.DeliveryReceipt, error)) *MockDeliveryReceiptRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockDeliveryReceiptRepository
+func (_mock *MockDeliveryReceiptRepository) Save(ctx context.Context, receipt *domain.DeliveryReceipt) error {
+	ret := _mock.Called(ctx, receipt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.DeliveryReceipt) error); ok {
+		r0 = returnFunc(ctx, receipt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeliveryReceiptRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockDeliveryReceiptRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - receipt *domain.DeliveryReceipt
+func (_e *MockDeliveryReceiptRepository_Expecter) Save(ctx interface{}, receipt interface{}) *MockDeliveryReceiptRepository_Save_Call {
+	return &MockDeliveryReceiptRepository_Save_Call{Call: _e.mock.On("Save", ctx, receipt)}
+}
+
+func (_c *MockDeliveryReceiptRepository_Save_Call) Run(run func(ctx context.Context, receipt *domain.DeliveryReceipt)) *MockDeliveryReceiptRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.DeliveryReceipt
+		if args[1] != nil {
+			arg1 = args[1].(*domain.DeliveryReceipt)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeliveryReceiptRepository_Save_Call) Return(err error) *MockDeliveryReceiptRepository_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeliveryReceiptRepository_Save_Call) RunAndReturn(run func(ctx context.Context, receipt *domain.DeliveryReceipt) error) *MockDeliveryReceiptRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDDBClient creates a new instance of MockDDBClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDDBClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDDBClient {
+	mock := &MockDDBClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDDBClient is an autogenerated mock type for the DDBClient type
+type MockDDBClient struct {
+	mock.Mock
+}
+
+type MockDDBClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDDBClient) EXPECT() *MockDDBClient_Expecter {
+	return &MockDDBClient_Expecter{mock: &_m.Mock}
+}
+
+// DeleteItem provides a mock function for the type MockDDBClient
+func (_mock *MockDDBClient) DeleteItem(ctx context.Context, input *dynamodb.DeleteItemInput, opts ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	// func(*dynamodb.Options)
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, input)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteItem")
+	}
+
+	var r0 *dynamodb.DeleteItemOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.DeleteItemInput, ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)); ok {
+		return returnFunc(ctx, input, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.DeleteItemInput, ...func(*dynamodb.Options)) *dynamodb.DeleteItemOutput); ok {
+		r0 = returnFunc(ctx, input, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dynamodb.DeleteItemOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dynamodb.DeleteItemInput, ...func(*dynamodb.Options)) error); ok {
+		r1 = returnFunc(ctx, input, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDDBClient_DeleteItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteItem'
+type MockDDBClient_DeleteItem_Call struct {
+	*mock.Call
+}
+
+// DeleteItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *dynamodb.DeleteItemInput
+//   - opts ...func(*dynamodb.Options)
+func (_e *MockDDBClient_Expecter) DeleteItem(ctx interface{}, input interface{}, opts ...interface{}) *MockDDBClient_DeleteItem_Call {
+	return &MockDDBClient_DeleteItem_Call{Call: _e.mock.On("DeleteItem",
+		append([]interface{}{ctx, input}, opts...)...)}
+}
+
+func (_c *MockDDBClient_DeleteItem_Call) Run(run func(ctx context.Context, input *dynamodb.DeleteItemInput, opts ...func(*dynamodb.Options))) *MockDDBClient_DeleteItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dynamodb.DeleteItemInput
+		if args[1] != nil {
+			arg1 = args[1].(*dynamodb.DeleteItemInput)
+		}
+		var arg2 []func(*dynamodb.Options)
+		variadicArgs := make([]func(*dynamodb.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*dynamodb.Options))
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDDBClient_DeleteItem_Call) Return(deleteItemOutput *dynamodb.DeleteItemOutput, err error) *MockDDBClient_DeleteItem_Call {
+	_c.Call.Return(deleteItemOutput, err)
+	return _c
+}
+
+func (_c *MockDDBClient_DeleteItem_Call) RunAndReturn(run func(ctx context.Context, input *dynamodb.DeleteItemInput, opts ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)) *MockDDBClient_DeleteItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItem provides a mock function for the type MockDDBClient
+func (_mock *MockDDBClient) GetItem(ctx context.Context, input *dynamodb.GetItemInput, opts ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	// func(*dynamodb.Options)
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, input)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItem")
+	}
+
+	var r0 *dynamodb.GetItemOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.GetItemInput, ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)); ok {
+		return returnFunc(ctx, input, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.GetItemInput, ...func(*dynamodb.Options)) *dynamodb.GetItemOutput); ok {
+		r0 = returnFunc(ctx, input, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dynamodb.GetItemOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dynamodb.GetItemInput, ...func(*dynamodb.Options)) error); ok {
+		r1 = returnFunc(ctx, input, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDDBClient_GetItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItem'
+type MockDDBClient_GetItem_Call struct {
+	*mock.Call
+}
+
+// GetItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *dynamodb.GetItemInput
+//   - opts ...func(*dynamodb.Options)
+func (_e *MockDDBClient_Expecter) GetItem(ctx interface{}, input interface{}, opts ...interface{}) *MockDDBClient_GetItem_Call {
+	return &MockDDBClient_GetItem_Call{Call: _e.mock.On("GetItem",
+		append([]interface{}{ctx, input}, opts...)...)}
+}
+
+func (_c *MockDDBClient_GetItem_Call) Run(run func(ctx context.Context, input *dynamodb.GetItemInput, opts ...func(*dynamodb.Options))) *MockDDBClient_GetItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dynamodb.GetItemInput
+		if args[1] != nil {
+			arg1 = args[1].(*dynamodb.GetItemInput)
+		}
+		var arg2 []func(*dynamodb.Options)
+		variadicArgs := make([]func(*dynamodb.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*dynamodb.Options))
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDDBClient_GetItem_Call) Return(getItemOutput *dynamodb.GetItemOutput, err error) *MockDDBClient_GetItem_Call {
+	_c.Call.Return(getItemOutput, err)
+	return _c
+}
+
+func (_c *MockDDBClient_GetItem_Call) RunAndReturn(run func(ctx context.Context, input *dynamodb.GetItemInput, opts ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)) *MockDDBClient_GetItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PutItem provides a mock function for the type MockDDBClient
+func (_mock *MockDDBClient) PutItem(ctx context.Context, input *dynamodb.PutItemInput, opts ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	// func(*dynamodb.Options)
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, input)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutItem")
+	}
+
+	var r0 *dynamodb.PutItemOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.PutItemInput, ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)); ok {
+		return returnFunc(ctx, input, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.PutItemInput, ...func(*dynamodb.Options)) *dynamodb.PutItemOutput); ok {
+		r0 = returnFunc(ctx, input, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dynamodb.PutItemOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dynamodb.PutItemInput, ...func(*dynamodb.Options)) error); ok {
+		r1 = returnFunc(ctx, input, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDDBClient_PutItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutItem'
+type MockDDBClient_PutItem_Call struct {
+	*mock.Call
+}
+
+// PutItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *dynamodb.PutItemInput
+//   - opts ...func(*dynamodb.Options)
+func (_e *MockDDBClient_Expecter) PutItem(ctx interface{}, input interface{}, opts ...interface{}) *MockDDBClient_PutItem_Call {
+	return &MockDDBClient_PutItem_Call{Call: _e.mock.On("PutItem",
+		append([]interface{}{ctx, input}, opts...)...)}
+}
+
+func (_c *MockDDBClient_PutItem_Call) Run(run func(ctx context.Context, input *dynamodb.PutItemInput, opts ...func(*dynamodb.Options))) *MockDDBClient_PutItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dynamodb.PutItemInput
+		if args[1] != nil {
+			arg1 = args[1].(*dynamodb.PutItemInput)
+		}
+		var arg2 []func(*dynamodb.Options)
+		variadicArgs := make([]func(*dynamodb.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*dynamodb.Options))
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDDBClient_PutItem_Call) Return(putItemOutput *dynamodb.PutItemOutput, err error) *MockDDBClient_PutItem_Call {
+	_c.Call.Return(putItemOutput, err)
+	return _c
+}
+
+func (_c *MockDDBClient_PutItem_Call) RunAndReturn(run func(ctx context.Context, input *dynamodb.PutItemInput, opts ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)) *MockDDBClient_PutItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Query provides a mock function for the type MockDDBClient
+func (_mock *MockDDBClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	// func(*dynamodb.Options)
+	_va := make([]interface{}, len(optFns))
+	for _i := range optFns {
+		_va[_i] = optFns[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, params)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Query")
+	}
+
+	var r0 *dynamodb.QueryOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.QueryInput, ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)); ok {
+		return returnFunc(ctx, params, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.QueryInput, ...func(*dynamodb.Options)) *dynamodb.QueryOutput); ok {
+		r0 = returnFunc(ctx, params, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dynamodb.QueryOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dynamodb.QueryInput, ...func(*dynamodb.Options)) error); ok {
+		r1 = returnFunc(ctx, params, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDDBClient_Query_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Query'
+type MockDDBClient_Query_Call struct {
+	*mock.Call
+}
+
+// Query is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params *dynamodb.QueryInput
+//   - optFns ...func(*dynamodb.Options)
+func (_e *MockDDBClient_Expecter) Query(ctx interface{}, params interface{}, optFns ...interface{}) *MockDDBClient_Query_Call {
+	return &MockDDBClient_Query_Call{Call: _e.mock.On("Query",
+		append([]interface{}{ctx, params}, optFns...)...)}
+}
+
+func (_c *MockDDBClient_Query_Call) Run(run func(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options))) *MockDDBClient_Query_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dynamodb.QueryInput
+		if args[1] != nil {
+			arg1 = args[1].(*dynamodb.QueryInput)
+		}
+		var arg2 []func(*dynamodb.Options)
+		variadicArgs := make([]func(*dynamodb.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*dynamodb.Options))
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDDBClient_Query_Call) Return(queryOutput *dynamodb.QueryOutput, err error) *MockDDBClient_Query_Call {
+	_c.Call.Return(queryOutput, err)
+	return _c
+}
+
+func (_c *MockDDBClient_Query_Call) RunAndReturn(run func(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)) *MockDDBClient_Query_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Scan provides a mock function for the type MockDDBClient
+func (_mock *MockDDBClient) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	// func(*dynamodb.Options)
+	_va := make([]interface{}, len(optFns))
+	for _i := range optFns {
+		_va[_i] = optFns[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, params)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Scan")
+	}
+
+	var r0 *dynamodb.ScanOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.ScanInput, ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)); ok {
+		return returnFunc(ctx, params, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.ScanInput, ...func(*dynamodb.Options)) *dynamodb.ScanOutput); ok {
+		r0 = returnFunc(ctx, params, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dynamodb.ScanOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dynamodb.ScanInput, ...func(*dynamodb.Options)) error); ok {
+		r1 = returnFunc(ctx, params, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDDBClient_Scan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Scan'
+type MockDDBClient_Scan_Call struct {
+	*mock.Call
+}
+
+// Scan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params *dynamodb.ScanInput
+//   - optFns ...func(*dynamodb.Options)
+func (_e *MockDDBClient_Expecter) Scan(ctx interface{}, params interface{}, optFns ...interface{}) *MockDDBClient_Scan_Call {
+	return &MockDDBClient_Scan_Call{Call: _e.mock.On("Scan",
+		append([]interface{}{ctx, params}, optFns...)...)}
+}
+
+func (_c *MockDDBClient_Scan_Call) Run(run func(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options))) *MockDDBClient_Scan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dynamodb.ScanInput
+		if args[1] != nil {
+			arg1 = args[1].(*dynamodb.ScanInput)
+		}
+		var arg2 []func(*dynamodb.Options)
+		variadicArgs := make([]func(*dynamodb.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*dynamodb.Options))
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDDBClient_Scan_Call) Return(scanOutput *dynamodb.ScanOutput, err error) *MockDDBClient_Scan_Call {
+	_c.Call.Return(scanOutput, err)
+	return _c
+}
+
+func (_c *MockDDBClient_Scan_Call) RunAndReturn(run func(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)) *MockDDBClient_Scan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateItem provides a mock function for the type MockDDBClient
+func (_mock *MockDDBClient) UpdateItem(ctx context.Context, input *dynamodb.UpdateItemInput, opts ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	// func(*dynamodb.Options)
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, input)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateItem")
+	}
+
+	var r0 *dynamodb.UpdateItemOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.UpdateItemInput, ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)); ok {
+		return returnFunc(ctx, input, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.UpdateItemInput, ...func(*dynamodb.Options)) *dynamodb.UpdateItemOutput); ok {
+		r0 = returnFunc(ctx, input, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dynamodb.UpdateItemOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dynamodb.UpdateItemInput, ...func(*dynamodb.Options)) error); ok {
+		r1 = returnFunc(ctx, input, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDDBClient_UpdateItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateItem'
+type MockDDBClient_UpdateItem_Call struct {
+	*mock.Call
+}
+
+// UpdateItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input *dynamodb.UpdateItemInput
+//   - opts ...func(*dynamodb.Options)
+func (_e *MockDDBClient_Expecter) UpdateItem(ctx interface{}, input interface{}, opts ...interface{}) *MockDDBClient_UpdateItem_Call {
+	return &MockDDBClient_UpdateItem_Call{Call: _e.mock.On("UpdateItem",
+		append([]interface{}{ctx, input}, opts...)...)}
+}
+
+func (_c *MockDDBClient_UpdateItem_Call) Run(run func(ctx context.Context, input *dynamodb.UpdateItemInput, opts ...func(*dynamodb.Options))) *MockDDBClient_UpdateItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dynamodb.UpdateItemInput
+		if args[1] != nil {
+			arg1 = args[1].(*dynamodb.UpdateItemInput)
 		}
 		var arg2 []func(*dynamodb.Options)
 		variadicArgs := make([]func(*dynamodb.Options), len(args)-2)
@@ -367,33 +1185,1692 @@ func (_c *MockDDBClient_Scan_Call) Run(run func(ctx context.Context, params *dyn
 				variadicArgs[i] = a.(func(*dynamodb.Options))
 			}
 		}
-		arg2 = variadicArgs
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDDBClient_UpdateItem_Call) Return(updateItemOutput *dynamodb.UpdateItemOutput, err error) *MockDDBClient_UpdateItem_Call {
+	_c.Call.Return(updateItemOutput, err)
+	return _c
+}
+
+func (_c *MockDDBClient_UpdateItem_Call) RunAndReturn(run func(ctx context.Context, input *dynamodb.UpdateItemInput, opts ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)) *MockDDBClient_UpdateItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRequestHistoryRepository creates a new instance of MockRequestHistoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRequestHistoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRequestHistoryRepository {
+	mock := &MockRequestHistoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRequestHistoryRepository is an autogenerated mock type for the RequestHistoryRepository type
+type MockRequestHistoryRepository struct {
+	mock.Mock
+}
+
+type MockRequestHistoryRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRequestHistoryRepository) EXPECT() *MockRequestHistoryRepository_Expecter {
+	return &MockRequestHistoryRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockRequestHistoryRepository
+func (_mock *MockRequestHistoryRepository) Delete(ctx context.Context, phone string) error {
+	ret := _mock.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, phone)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRequestHistoryRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockRequestHistoryRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *MockRequestHistoryRepository_Expecter) Delete(ctx interface{}, phone interface{}) *MockRequestHistoryRepository_Delete_Call {
+	return &MockRequestHistoryRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, phone)}
+}
+
+func (_c *MockRequestHistoryRepository_Delete_Call) Run(run func(ctx context.Context, phone string)) *MockRequestHistoryRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRequestHistoryRepository_Delete_Call) Return(err error) *MockRequestHistoryRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRequestHistoryRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, phone string) error) *MockRequestHistoryRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockRequestHistoryRepository
+func (_mock *MockRequestHistoryRepository) Get(ctx context.Context, phone string) (*domain.RequestHistory, error) {
+	ret := _mock.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.RequestHistory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.RequestHistory, error)); ok {
+		return returnFunc(ctx, phone)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.RequestHistory); ok {
+		r0 = returnFunc(ctx, phone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.RequestHistory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, phone)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRequestHistoryRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockRequestHistoryRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *MockRequestHistoryRepository_Expecter) Get(ctx interface{}, phone interface{}) *MockRequestHistoryRepository_Get_Call {
+	return &MockRequestHistoryRepository_Get_Call{Call: _e.mock.On("Get", ctx, phone)}
+}
+
+func (_c *MockRequestHistoryRepository_Get_Call) Run(run func(ctx context.Context, phone string)) *MockRequestHistoryRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRequestHistoryRepository_Get_Call) Return(requestHistory *domain.RequestHistory, err error) *MockRequestHistoryRepository_Get_Call {
+	_c.Call.Return(requestHistory, err)
+	return _c
+}
+
+func (_c *MockRequestHistoryRepository_Get_Call) RunAndReturn(run func(ctx context.Context, phone string) (*domain.RequestHistory, error)) *MockRequestHistoryRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockRequestHistoryRepository
+func (_mock *MockRequestHistoryRepository) Save(ctx context.Context, history *domain.RequestHistory) error {
+	ret := _mock.Called(ctx, history)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.RequestHistory) error); ok {
+		r0 = returnFunc(ctx, history)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRequestHistoryRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockRequestHistoryRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - history *domain.RequestHistory
+func (_e *MockRequestHistoryRepository_Expecter) Save(ctx interface{}, history interface{}) *MockRequestHistoryRepository_Save_Call {
+	return &MockRequestHistoryRepository_Save_Call{Call: _e.mock.On("Save", ctx, history)}
+}
+
+func (_c *MockRequestHistoryRepository_Save_Call) Run(run func(ctx context.Context, history *domain.RequestHistory)) *MockRequestHistoryRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.RequestHistory
+		if args[1] != nil {
+			arg1 = args[1].(*domain.RequestHistory)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRequestHistoryRepository_Save_Call) Return(err error) *MockRequestHistoryRepository_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRequestHistoryRepository_Save_Call) RunAndReturn(run func(ctx context.Context, history *domain.RequestHistory) error) *MockRequestHistoryRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMemberRepository creates a new instance of MockMemberRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMemberRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMemberRepository {
+	mock := &MockMemberRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMemberRepository is an autogenerated mock type for the MemberRepository type
+type MockMemberRepository struct {
+	mock.Mock
+}
+
+type MockMemberRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMemberRepository) EXPECT() *MockMemberRepository_Expecter {
+	return &MockMemberRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockMemberRepository
+func (_mock *MockMemberRepository) Delete(ctx context.Context, phone string) error {
+	ret := _mock.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, phone)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMemberRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockMemberRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *MockMemberRepository_Expecter) Delete(ctx interface{}, phone interface{}) *MockMemberRepository_Delete_Call {
+	return &MockMemberRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, phone)}
+}
+
+func (_c *MockMemberRepository_Delete_Call) Run(run func(ctx context.Context, phone string)) *MockMemberRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMemberRepository_Delete_Call) Return(err error) *MockMemberRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMemberRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, phone string) error) *MockMemberRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exists provides a mock function for the type MockMemberRepository
+func (_mock *MockMemberRepository) Exists(ctx context.Context, phone string) (bool, error) {
+	ret := _mock.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, phone)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, phone)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, phone)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMemberRepository_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type MockMemberRepository_Exists_Call struct {
+	*mock.Call
+}
+
+// Exists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *MockMemberRepository_Expecter) Exists(ctx interface{}, phone interface{}) *MockMemberRepository_Exists_Call {
+	return &MockMemberRepository_Exists_Call{Call: _e.mock.On("Exists", ctx, phone)}
+}
+
+func (_c *MockMemberRepository_Exists_Call) Run(run func(ctx context.Context, phone string)) *MockMemberRepository_Exists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMemberRepository_Exists_Call) Return(b bool, err error) *MockMemberRepository_Exists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockMemberRepository_Exists_Call) RunAndReturn(run func(ctx context.Context, phone string) (bool, error)) *MockMemberRepository_Exists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockMemberRepository
+func (_mock *MockMemberRepository) Get(ctx context.Context, phone string) (*domain.Member, error) {
+	ret := _mock.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Member
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Member, error)); ok {
+		return returnFunc(ctx, phone)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Member); ok {
+		r0 = returnFunc(ctx, phone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Member)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, phone)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMemberRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockMemberRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *MockMemberRepository_Expecter) Get(ctx interface{}, phone interface{}) *MockMemberRepository_Get_Call {
+	return &MockMemberRepository_Get_Call{Call: _e.mock.On("Get", ctx, phone)}
+}
+
+func (_c *MockMemberRepository_Get_Call) Run(run func(ctx context.Context, phone string)) *MockMemberRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMemberRepository_Get_Call) Return(member *domain.Member, err error) *MockMemberRepository_Get_Call {
+	_c.Call.Return(member, err)
+	return _c
+}
+
+func (_c *MockMemberRepository_Get_Call) RunAndReturn(run func(ctx context.Context, phone string) (*domain.Member, error)) *MockMemberRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function for the type MockMemberRepository
+func (_mock *MockMemberRepository) GetAll(ctx context.Context) ([]domain.Member, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.Member
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.Member, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.Member); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Member)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMemberRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockMemberRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMemberRepository_Expecter) GetAll(ctx interface{}) *MockMemberRepository_GetAll_Call {
+	return &MockMemberRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx)}
+}
+
+func (_c *MockMemberRepository_GetAll_Call) Run(run func(ctx context.Context)) *MockMemberRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMemberRepository_GetAll_Call) Return(members []domain.Member, err error) *MockMemberRepository_GetAll_Call {
+	_c.Call.Return(members, err)
+	return _c
+}
+
+func (_c *MockMemberRepository_GetAll_Call) RunAndReturn(run func(ctx context.Context) ([]domain.Member, error)) *MockMemberRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockMemberRepository
+func (_mock *MockMemberRepository) Save(ctx context.Context, member *domain.Member) error {
+	ret := _mock.Called(ctx, member)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Member) error); ok {
+		r0 = returnFunc(ctx, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMemberRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockMemberRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - member *domain.Member
+func (_e *MockMemberRepository_Expecter) Save(ctx interface{}, member interface{}) *MockMemberRepository_Save_Call {
+	return &MockMemberRepository_Save_Call{Call: _e.mock.On("Save", ctx, member)}
+}
+
+func (_c *MockMemberRepository_Save_Call) Run(run func(ctx context.Context, member *domain.Member)) *MockMemberRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Member
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Member)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMemberRepository_Save_Call) Return(err error) *MockMemberRepository_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMemberRepository_Save_Call) RunAndReturn(run func(ctx context.Context, member *domain.Member) error) *MockMemberRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeferredMessageRepository creates a new instance of MockDeferredMessageRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeferredMessageRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeferredMessageRepository {
+	mock := &MockDeferredMessageRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeferredMessageRepository is an autogenerated mock type for the DeferredMessageRepository type
+type MockDeferredMessageRepository struct {
+	mock.Mock
+}
+
+type MockDeferredMessageRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeferredMessageRepository) EXPECT() *MockDeferredMessageRepository_Expecter {
+	return &MockDeferredMessageRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockDeferredMessageRepository
+func (_mock *MockDeferredMessageRepository) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeferredMessageRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockDeferredMessageRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeferredMessageRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockDeferredMessageRepository_Delete_Call {
+	return &MockDeferredMessageRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockDeferredMessageRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *MockDeferredMessageRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeferredMessageRepository_Delete_Call) Return(err error) *MockDeferredMessageRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeferredMessageRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockDeferredMessageRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function for the type MockDeferredMessageRepository
+func (_mock *MockDeferredMessageRepository) GetAll(ctx context.Context) ([]domain.DeferredMessage, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.DeferredMessage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.DeferredMessage, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.DeferredMessage); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.DeferredMessage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeferredMessageRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockDeferredMessageRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDeferredMessageRepository_Expecter) GetAll(ctx interface{}) *MockDeferredMessageRepository_GetAll_Call {
+	return &MockDeferredMessageRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx)}
+}
+
+func (_c *MockDeferredMessageRepository_GetAll_Call) Run(run func(ctx context.Context)) *MockDeferredMessageRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeferredMessageRepository_GetAll_Call) Return(deferredMessages []domain.DeferredMessage, err error) *MockDeferredMessageRepository_GetAll_Call {
+	_c.Call.Return(deferredMessages, err)
+	return _c
+}
+
+func (_c *MockDeferredMessageRepository_GetAll_Call) RunAndReturn(run func(ctx context.Context) ([]domain.DeferredMessage, error)) *MockDeferredMessageRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockDeferredMessageRepository
+func (_mock *MockDeferredMessageRepository) Save(ctx context.Context, msg *domain.DeferredMessage) error {
+	ret := _mock.Called(ctx, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.DeferredMessage) error); ok {
+		r0 = returnFunc(ctx, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeferredMessageRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockDeferredMessageRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - msg *domain.DeferredMessage
+func (_e *MockDeferredMessageRepository_Expecter) Save(ctx interface{}, msg interface{}) *MockDeferredMessageRepository_Save_Call {
+	return &MockDeferredMessageRepository_Save_Call{Call: _e.mock.On("Save", ctx, msg)}
+}
+
+func (_c *MockDeferredMessageRepository_Save_Call) Run(run func(ctx context.Context, msg *domain.DeferredMessage)) *MockDeferredMessageRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.DeferredMessage
+		if args[1] != nil {
+			arg1 = args[1].(*domain.DeferredMessage)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeferredMessageRepository_Save_Call) Return(err error) *MockDeferredMessageRepository_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeferredMessageRepository_Save_Call) RunAndReturn(run func(ctx context.Context, msg *domain.DeferredMessage) error) *MockDeferredMessageRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSendCountRepository creates a new instance of MockSendCountRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSendCountRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSendCountRepository {
+	mock := &MockSendCountRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSendCountRepository is an autogenerated mock type for the SendCountRepository type
+type MockSendCountRepository struct {
+	mock.Mock
+}
+
+type MockSendCountRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSendCountRepository) EXPECT() *MockSendCountRepository_Expecter {
+	return &MockSendCountRepository_Expecter{mock: &_m.Mock}
+}
+
+// Take provides a mock function for the type MockSendCountRepository
+func (_mock *MockSendCountRepository) Take(ctx context.Context, key string, period string, limit int, expires time.Time) (bool, error) {
+	ret := _mock.Called(ctx, key, period, limit, expires)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Take")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, time.Time) (bool, error)); ok {
+		return returnFunc(ctx, key, period, limit, expires)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, time.Time) bool); ok {
+		r0 = returnFunc(ctx, key, period, limit, expires)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int, time.Time) error); ok {
+		r1 = returnFunc(ctx, key, period, limit, expires)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSendCountRepository_Take_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Take'
+type MockSendCountRepository_Take_Call struct {
+	*mock.Call
+}
+
+// Take is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - period string
+//   - limit int
+//   - expires time.Time
+func (_e *MockSendCountRepository_Expecter) Take(ctx interface{}, key interface{}, period interface{}, limit interface{}, expires interface{}) *MockSendCountRepository_Take_Call {
+	return &MockSendCountRepository_Take_Call{Call: _e.mock.On("Take", ctx, key, period, limit, expires)}
+}
+
+func (_c *MockSendCountRepository_Take_Call) Run(run func(ctx context.Context, key string, period string, limit int, expires time.Time)) *MockSendCountRepository_Take_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSendCountRepository_Take_Call) Return(b bool, err error) *MockSendCountRepository_Take_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockSendCountRepository_Take_Call) RunAndReturn(run func(ctx context.Context, key string, period string, limit int, expires time.Time) (bool, error)) *MockSendCountRepository_Take_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBlockedPhonesRepository creates a new instance of MockBlockedPhonesRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBlockedPhonesRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBlockedPhonesRepository {
+	mock := &MockBlockedPhonesRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBlockedPhonesRepository is an autogenerated mock type for the BlockedPhonesRepository type
+type MockBlockedPhonesRepository struct {
+	mock.Mock
+}
+
+type MockBlockedPhonesRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBlockedPhonesRepository) EXPECT() *MockBlockedPhonesRepository_Expecter {
+	return &MockBlockedPhonesRepository_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function for the type MockBlockedPhonesRepository
+func (_mock *MockBlockedPhonesRepository) Get(ctx context.Context) (*domain.BlockedPhones, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.BlockedPhones
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*domain.BlockedPhones, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *domain.BlockedPhones); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.BlockedPhones)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlockedPhonesRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockBlockedPhonesRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockBlockedPhonesRepository_Expecter) Get(ctx interface{}) *MockBlockedPhonesRepository_Get_Call {
+	return &MockBlockedPhonesRepository_Get_Call{Call: _e.mock.On("Get", ctx)}
+}
+
+func (_c *MockBlockedPhonesRepository_Get_Call) Run(run func(ctx context.Context)) *MockBlockedPhonesRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBlockedPhonesRepository_Get_Call) Return(blockedPhones *domain.BlockedPhones, err error) *MockBlockedPhonesRepository_Get_Call {
+	_c.Call.Return(blockedPhones, err)
+	return _c
+}
+
+func (_c *MockBlockedPhonesRepository_Get_Call) RunAndReturn(run func(ctx context.Context) (*domain.BlockedPhones, error)) *MockBlockedPhonesRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockBlockedPhonesRepository
+func (_mock *MockBlockedPhonesRepository) Save(ctx context.Context, phones *domain.BlockedPhones) error {
+	ret := _mock.Called(ctx, phones)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.BlockedPhones) error); ok {
+		r0 = returnFunc(ctx, phones)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlockedPhonesRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockBlockedPhonesRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phones *domain.BlockedPhones
+func (_e *MockBlockedPhonesRepository_Expecter) Save(ctx interface{}, phones interface{}) *MockBlockedPhonesRepository_Save_Call {
+	return &MockBlockedPhonesRepository_Save_Call{Call: _e.mock.On("Save", ctx, phones)}
+}
+
+func (_c *MockBlockedPhonesRepository_Save_Call) Run(run func(ctx context.Context, phones *domain.BlockedPhones)) *MockBlockedPhonesRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.BlockedPhones
+		if args[1] != nil {
+			arg1 = args[1].(*domain.BlockedPhones)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBlockedPhonesRepository_Save_Call) Return(err error) *MockBlockedPhonesRepository_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlockedPhonesRepository_Save_Call) RunAndReturn(run func(ctx context.Context, phones *domain.BlockedPhones) error) *MockBlockedPhonesRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIntercessorPhonesRepository creates a new instance of MockIntercessorPhonesRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIntercessorPhonesRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIntercessorPhonesRepository {
+	mock := &MockIntercessorPhonesRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockIntercessorPhonesRepository is an autogenerated mock type for the IntercessorPhonesRepository type
+type MockIntercessorPhonesRepository struct {
+	mock.Mock
+}
+
+type MockIntercessorPhonesRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIntercessorPhonesRepository) EXPECT() *MockIntercessorPhonesRepository_Expecter {
+	return &MockIntercessorPhonesRepository_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function for the type MockIntercessorPhonesRepository
+func (_mock *MockIntercessorPhonesRepository) Get(ctx context.Context) (*domain.IntercessorPhones, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.IntercessorPhones
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*domain.IntercessorPhones, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *domain.IntercessorPhones); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.IntercessorPhones)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIntercessorPhonesRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockIntercessorPhonesRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIntercessorPhonesRepository_Expecter) Get(ctx interface{}) *MockIntercessorPhonesRepository_Get_Call {
+	return &MockIntercessorPhonesRepository_Get_Call{Call: _e.mock.On("Get", ctx)}
+}
+
+func (_c *MockIntercessorPhonesRepository_Get_Call) Run(run func(ctx context.Context)) *MockIntercessorPhonesRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIntercessorPhonesRepository_Get_Call) Return(intercessorPhones *domain.IntercessorPhones, err error) *MockIntercessorPhonesRepository_Get_Call {
+	_c.Call.Return(intercessorPhones, err)
+	return _c
+}
+
+func (_c *MockIntercessorPhonesRepository_Get_Call) RunAndReturn(run func(ctx context.Context) (*domain.IntercessorPhones, error)) *MockIntercessorPhonesRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockIntercessorPhonesRepository
+func (_mock *MockIntercessorPhonesRepository) Save(ctx context.Context, phones *domain.IntercessorPhones) error {
+	ret := _mock.Called(ctx, phones)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.IntercessorPhones) error); ok {
+		r0 = returnFunc(ctx, phones)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIntercessorPhonesRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockIntercessorPhonesRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phones *domain.IntercessorPhones
+func (_e *MockIntercessorPhonesRepository_Expecter) Save(ctx interface{}, phones interface{}) *MockIntercessorPhonesRepository_Save_Call {
+	return &MockIntercessorPhonesRepository_Save_Call{Call: _e.mock.On("Save", ctx, phones)}
+}
+
+func (_c *MockIntercessorPhonesRepository_Save_Call) Run(run func(ctx context.Context, phones *domain.IntercessorPhones)) *MockIntercessorPhonesRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.IntercessorPhones
+		if args[1] != nil {
+			arg1 = args[1].(*domain.IntercessorPhones)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIntercessorPhonesRepository_Save_Call) Return(err error) *MockIntercessorPhonesRepository_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIntercessorPhonesRepository_Save_Call) RunAndReturn(run func(ctx context.Context, phones *domain.IntercessorPhones) error) *MockIntercessorPhonesRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOptedOutPhonesRepository creates a new instance of MockOptedOutPhonesRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOptedOutPhonesRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOptedOutPhonesRepository {
+	mock := &MockOptedOutPhonesRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOptedOutPhonesRepository is an autogenerated mock type for the OptedOutPhonesRepository type
+type MockOptedOutPhonesRepository struct {
+	mock.Mock
+}
+
+type MockOptedOutPhonesRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOptedOutPhonesRepository) EXPECT() *MockOptedOutPhonesRepository_Expecter {
+	return &MockOptedOutPhonesRepository_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function for the type MockOptedOutPhonesRepository
+func (_mock *MockOptedOutPhonesRepository) Get(ctx context.Context) (*domain.OptedOutPhones, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.OptedOutPhones
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*domain.OptedOutPhones, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *domain.OptedOutPhones); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OptedOutPhones)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOptedOutPhonesRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockOptedOutPhonesRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockOptedOutPhonesRepository_Expecter) Get(ctx interface{}) *MockOptedOutPhonesRepository_Get_Call {
+	return &MockOptedOutPhonesRepository_Get_Call{Call: _e.mock.On("Get", ctx)}
+}
+
+func (_c *MockOptedOutPhonesRepository_Get_Call) Run(run func(ctx context.Context)) *MockOptedOutPhonesRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOptedOutPhonesRepository_Get_Call) Return(optedOutPhones *domain.OptedOutPhones, err error) *MockOptedOutPhonesRepository_Get_Call {
+	_c.Call.Return(optedOutPhones, err)
+	return _c
+}
+
+func (_c *MockOptedOutPhonesRepository_Get_Call) RunAndReturn(run func(ctx context.Context) (*domain.OptedOutPhones, error)) *MockOptedOutPhonesRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockOptedOutPhonesRepository
+func (_mock *MockOptedOutPhonesRepository) Save(ctx context.Context, phones *domain.OptedOutPhones) error {
+	ret := _mock.Called(ctx, phones)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.OptedOutPhones) error); ok {
+		r0 = returnFunc(ctx, phones)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOptedOutPhonesRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockOptedOutPhonesRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phones *domain.OptedOutPhones
+func (_e *MockOptedOutPhonesRepository_Expecter) Save(ctx interface{}, phones interface{}) *MockOptedOutPhonesRepository_Save_Call {
+	return &MockOptedOutPhonesRepository_Save_Call{Call: _e.mock.On("Save", ctx, phones)}
+}
+
+func (_c *MockOptedOutPhonesRepository_Save_Call) Run(run func(ctx context.Context, phones *domain.OptedOutPhones)) *MockOptedOutPhonesRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.OptedOutPhones
+		if args[1] != nil {
+			arg1 = args[1].(*domain.OptedOutPhones)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOptedOutPhonesRepository_Save_Call) Return(err error) *MockOptedOutPhonesRepository_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOptedOutPhonesRepository_Save_Call) RunAndReturn(run func(ctx context.Context, phones *domain.OptedOutPhones) error) *MockOptedOutPhonesRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPrayerRepository creates a new instance of MockPrayerRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPrayerRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPrayerRepository {
+	mock := &MockPrayerRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPrayerRepository is an autogenerated mock type for the PrayerRepository type
+type MockPrayerRepository struct {
+	mock.Mock
+}
+
+type MockPrayerRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPrayerRepository) EXPECT() *MockPrayerRepository_Expecter {
+	return &MockPrayerRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockPrayerRepository
+func (_mock *MockPrayerRepository) Delete(ctx context.Context, key string, queued bool) error {
+	ret := _mock.Called(ctx, key, queued)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = returnFunc(ctx, key, queued)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPrayerRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockPrayerRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - queued bool
+func (_e *MockPrayerRepository_Expecter) Delete(ctx interface{}, key interface{}, queued interface{}) *MockPrayerRepository_Delete_Call {
+	return &MockPrayerRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, key, queued)}
+}
+
+func (_c *MockPrayerRepository_Delete_Call) Run(run func(ctx context.Context, key string, queued bool)) *MockPrayerRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPrayerRepository_Delete_Call) Return(err error) *MockPrayerRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPrayerRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, key string, queued bool) error) *MockPrayerRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exists provides a mock function for the type MockPrayerRepository
+func (_mock *MockPrayerRepository) Exists(ctx context.Context, phone string) (bool, error) {
+	ret := _mock.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, phone)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, phone)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, phone)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPrayerRepository_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type MockPrayerRepository_Exists_Call struct {
+	*mock.Call
+}
+
+// Exists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *MockPrayerRepository_Expecter) Exists(ctx interface{}, phone interface{}) *MockPrayerRepository_Exists_Call {
+	return &MockPrayerRepository_Exists_Call{Call: _e.mock.On("Exists", ctx, phone)}
+}
+
+func (_c *MockPrayerRepository_Exists_Call) Run(run func(ctx context.Context, phone string)) *MockPrayerRepository_Exists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPrayerRepository_Exists_Call) Return(b bool, err error) *MockPrayerRepository_Exists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockPrayerRepository_Exists_Call) RunAndReturn(run func(ctx context.Context, phone string) (bool, error)) *MockPrayerRepository_Exists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockPrayerRepository
+func (_mock *MockPrayerRepository) Get(ctx context.Context, key string, queued bool) (*domain.Prayer, error) {
+	ret := _mock.Called(ctx, key, queued)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Prayer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) (*domain.Prayer, error)); ok {
+		return returnFunc(ctx, key, queued)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) *domain.Prayer); ok {
+		r0 = returnFunc(ctx, key, queued)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Prayer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = returnFunc(ctx, key, queued)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPrayerRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockPrayerRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - queued bool
+func (_e *MockPrayerRepository_Expecter) Get(ctx interface{}, key interface{}, queued interface{}) *MockPrayerRepository_Get_Call {
+	return &MockPrayerRepository_Get_Call{Call: _e.mock.On("Get", ctx, key, queued)}
+}
+
+func (_c *MockPrayerRepository_Get_Call) Run(run func(ctx context.Context, key string, queued bool)) *MockPrayerRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPrayerRepository_Get_Call) Return(prayer *domain.Prayer, err error) *MockPrayerRepository_Get_Call {
+	_c.Call.Return(prayer, err)
+	return _c
+}
+
+func (_c *MockPrayerRepository_Get_Call) RunAndReturn(run func(ctx context.Context, key string, queued bool) (*domain.Prayer, error)) *MockPrayerRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function for the type MockPrayerRepository
+func (_mock *MockPrayerRepository) GetAll(ctx context.Context, queued bool) ([]domain.Prayer, error) {
+	ret := _mock.Called(ctx, queued)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []domain.Prayer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bool) ([]domain.Prayer, error)); ok {
+		return returnFunc(ctx, queued)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bool) []domain.Prayer); ok {
+		r0 = returnFunc(ctx, queued)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Prayer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = returnFunc(ctx, queued)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPrayerRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockPrayerRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - queued bool
+func (_e *MockPrayerRepository_Expecter) GetAll(ctx interface{}, queued interface{}) *MockPrayerRepository_GetAll_Call {
+	return &MockPrayerRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx, queued)}
+}
+
+func (_c *MockPrayerRepository_GetAll_Call) Run(run func(ctx context.Context, queued bool)) *MockPrayerRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPrayerRepository_GetAll_Call) Return(prayers []domain.Prayer, err error) *MockPrayerRepository_GetAll_Call {
+	_c.Call.Return(prayers, err)
+	return _c
+}
+
+func (_c *MockPrayerRepository_GetAll_Call) RunAndReturn(run func(ctx context.Context, queued bool) ([]domain.Prayer, error)) *MockPrayerRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockPrayerRepository
+func (_mock *MockPrayerRepository) Save(ctx context.Context, prayer *domain.Prayer, queued bool) error {
+	ret := _mock.Called(ctx, prayer, queued)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Prayer, bool) error); ok {
+		r0 = returnFunc(ctx, prayer, queued)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPrayerRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockPrayerRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prayer *domain.Prayer
+//   - queued bool
+func (_e *MockPrayerRepository_Expecter) Save(ctx interface{}, prayer interface{}, queued interface{}) *MockPrayerRepository_Save_Call {
+	return &MockPrayerRepository_Save_Call{Call: _e.mock.On("Save", ctx, prayer, queued)}
+}
+
+func (_c *MockPrayerRepository_Save_Call) Run(run func(ctx context.Context, prayer *domain.Prayer, queued bool)) *MockPrayerRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Prayer
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Prayer)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
 		run(
 			arg0,
 			arg1,
-			arg2...,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDDBClient_Scan_Call) Return(scanOutput *dynamodb.ScanOutput, err error) *MockDDBClient_Scan_Call {
-	_c.Call.Return(scanOutput, err)
+func (_c *MockPrayerRepository_Save_Call) Return(err error) *MockPrayerRepository_Save_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDDBClient_Scan_Call) RunAndReturn(run func(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)) *MockDDBClient_Scan_Call {
+func (_c *MockPrayerRepository_Save_Call) RunAndReturn(run func(ctx context.Context, prayer *domain.Prayer, queued bool) error) *MockPrayerRepository_Save_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockMemberRepository creates a new instance of MockMemberRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockCompletedPrayerRepository creates a new instance of MockCompletedPrayerRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockMemberRepository(t interface {
+func NewMockCompletedPrayerRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockMemberRepository {
-	mock := &MockMemberRepository{}
+}) *MockCompletedPrayerRepository {
+	mock := &MockCompletedPrayerRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -401,123 +2878,119 @@ func NewMockMemberRepository(t interface {
 	return mock
 }
 
-// MockMemberRepository is an autogenerated mock type for the MemberRepository type
-type MockMemberRepository struct {
+// MockCompletedPrayerRepository is an autogenerated mock type for the CompletedPrayerRepository type
+type MockCompletedPrayerRepository struct {
 	mock.Mock
 }
 
-type MockMemberRepository_Expecter struct {
+type MockCompletedPrayerRepository_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockMemberRepository) EXPECT() *MockMemberRepository_Expecter {
-	return &MockMemberRepository_Expecter{mock: &_m.Mock}
+func (_m *MockCompletedPrayerRepository) EXPECT() *MockCompletedPrayerRepository_Expecter {
+	return &MockCompletedPrayerRepository_Expecter{mock: &_m.Mock}
 }
 
-// Delete provides a mock function for the type MockMemberRepository
-func (_mock *MockMemberRepository) Delete(ctx context.Context, phone string) error {
-	ret := _mock.Called(ctx, phone)
+// GetAll provides a mock function for the type MockCompletedPrayerRepository
+func (_mock *MockCompletedPrayerRepository) GetAll(ctx context.Context) ([]domain.CompletedPrayer, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for GetAll")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, phone)
+	var r0 []domain.CompletedPrayer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.CompletedPrayer, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.CompletedPrayer); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CompletedPrayer)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockMemberRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockMemberRepository_Delete_Call struct {
+// MockCompletedPrayerRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockCompletedPrayerRepository_GetAll_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
+// GetAll is a helper method to define mock.On call
 //   - ctx context.Context
-//   - phone string
-func (_e *MockMemberRepository_Expecter) Delete(ctx interface{}, phone interface{}) *MockMemberRepository_Delete_Call {
-	return &MockMemberRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, phone)}
+func (_e *MockCompletedPrayerRepository_Expecter) GetAll(ctx interface{}) *MockCompletedPrayerRepository_GetAll_Call {
+	return &MockCompletedPrayerRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx)}
 }
 
-func (_c *MockMemberRepository_Delete_Call) Run(run func(ctx context.Context, phone string)) *MockMemberRepository_Delete_Call {
+func (_c *MockCompletedPrayerRepository_GetAll_Call) Run(run func(ctx context.Context)) *MockCompletedPrayerRepository_GetAll_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMemberRepository_Delete_Call) Return(err error) *MockMemberRepository_Delete_Call {
-	_c.Call.Return(err)
+func (_c *MockCompletedPrayerRepository_GetAll_Call) Return(completedPrayers []domain.CompletedPrayer, err error) *MockCompletedPrayerRepository_GetAll_Call {
+	_c.Call.Return(completedPrayers, err)
 	return _c
 }
 
-func (_c *MockMemberRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, phone string) error) *MockMemberRepository_Delete_Call {
+func (_c *MockCompletedPrayerRepository_GetAll_Call) RunAndReturn(run func(ctx context.Context) ([]domain.CompletedPrayer, error)) *MockCompletedPrayerRepository_GetAll_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Exists provides a mock function for the type MockMemberRepository
-func (_mock *MockMemberRepository) Exists(ctx context.Context, phone string) (bool, error) {
-	ret := _mock.Called(ctx, phone)
+// Save provides a mock function for the type MockCompletedPrayerRepository
+func (_mock *MockCompletedPrayerRepository) Save(ctx context.Context, prayer *domain.CompletedPrayer) error {
+	ret := _mock.Called(ctx, prayer)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Exists")
+		panic("no return value specified for Save")
 	}
 
-	var r0 bool
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
-		return returnFunc(ctx, phone)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = returnFunc(ctx, phone)
-	} else {
-		r0 = ret.Get(0).(bool)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, phone)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.CompletedPrayer) error); ok {
+		r0 = returnFunc(ctx, prayer)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockMemberRepository_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
-type MockMemberRepository_Exists_Call struct {
+// MockCompletedPrayerRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockCompletedPrayerRepository_Save_Call struct {
 	*mock.Call
 }
 
-// Exists is a helper method to define mock.On call
+// Save is a helper method to define mock.On call
 //   - ctx context.Context
-//   - phone string
-func (_e *MockMemberRepository_Expecter) Exists(ctx interface{}, phone interface{}) *MockMemberRepository_Exists_Call {
-	return &MockMemberRepository_Exists_Call{Call: _e.mock.On("Exists", ctx, phone)}
+//   - prayer *domain.CompletedPrayer
+func (_e *MockCompletedPrayerRepository_Expecter) Save(ctx interface{}, prayer interface{}) *MockCompletedPrayerRepository_Save_Call {
+	return &MockCompletedPrayerRepository_Save_Call{Call: _e.mock.On("Save", ctx, prayer)}
 }
 
-func (_c *MockMemberRepository_Exists_Call) Run(run func(ctx context.Context, phone string)) *MockMemberRepository_Exists_Call {
+func (_c *MockCompletedPrayerRepository_Save_Call) Run(run func(ctx context.Context, prayer *domain.CompletedPrayer)) *MockCompletedPrayerRepository_Save_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *domain.CompletedPrayer
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*domain.CompletedPrayer)
 		}
 		run(
 			arg0,
@@ -527,65 +3000,81 @@ func (_c *MockMemberRepository_Exists_Call) Run(run func(ctx context.Context, ph
 	return _c
 }
 
-func (_c *MockMemberRepository_Exists_Call) Return(b bool, err error) *MockMemberRepository_Exists_Call {
-	_c.Call.Return(b, err)
+func (_c *MockCompletedPrayerRepository_Save_Call) Return(err error) *MockCompletedPrayerRepository_Save_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMemberRepository_Exists_Call) RunAndReturn(run func(ctx context.Context, phone string) (bool, error)) *MockMemberRepository_Exists_Call {
+func (_c *MockCompletedPrayerRepository_Save_Call) RunAndReturn(run func(ctx context.Context, prayer *domain.CompletedPrayer) error) *MockCompletedPrayerRepository_Save_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Get provides a mock function for the type MockMemberRepository
-func (_mock *MockMemberRepository) Get(ctx context.Context, phone string) (*domain.Member, error) {
-	ret := _mock.Called(ctx, phone)
+// NewMockPendingPrayerRepository creates a new instance of MockPendingPrayerRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPendingPrayerRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPendingPrayerRepository {
+	mock := &MockPendingPrayerRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPendingPrayerRepository is an autogenerated mock type for the PendingPrayerRepository type
+type MockPendingPrayerRepository struct {
+	mock.Mock
+}
+
+type MockPendingPrayerRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPendingPrayerRepository) EXPECT() *MockPendingPrayerRepository_Expecter {
+	return &MockPendingPrayerRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockPendingPrayerRepository
+func (_mock *MockPendingPrayerRepository) Create(ctx context.Context, prayer *domain.PendingPrayer) error {
+	ret := _mock.Called(ctx, prayer)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for Create")
 	}
 
-	var r0 *domain.Member
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Member, error)); ok {
-		return returnFunc(ctx, phone)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Member); ok {
-		r0 = returnFunc(ctx, phone)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Member)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, phone)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.PendingPrayer) error); ok {
+		r0 = returnFunc(ctx, prayer)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockMemberRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockMemberRepository_Get_Call struct {
+// MockPendingPrayerRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockPendingPrayerRepository_Create_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
+// Create is a helper method to define mock.On call
 //   - ctx context.Context
-//   - phone string
-func (_e *MockMemberRepository_Expecter) Get(ctx interface{}, phone interface{}) *MockMemberRepository_Get_Call {
-	return &MockMemberRepository_Get_Call{Call: _e.mock.On("Get", ctx, phone)}
+//   - prayer *domain.PendingPrayer
+func (_e *MockPendingPrayerRepository_Expecter) Create(ctx interface{}, prayer interface{}) *MockPendingPrayerRepository_Create_Call {
+	return &MockPendingPrayerRepository_Create_Call{Call: _e.mock.On("Create", ctx, prayer)}
 }
 
-func (_c *MockMemberRepository_Get_Call) Run(run func(ctx context.Context, phone string)) *MockMemberRepository_Get_Call {
+func (_c *MockPendingPrayerRepository_Create_Call) Run(run func(ctx context.Context, prayer *domain.PendingPrayer)) *MockPendingPrayerRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *domain.PendingPrayer
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*domain.PendingPrayer)
 		}
 		run(
 			arg0,
@@ -595,54 +3084,54 @@ func (_c *MockMemberRepository_Get_Call) Run(run func(ctx context.Context, phone
 	return _c
 }
 
-func (_c *MockMemberRepository_Get_Call) Return(member *domain.Member, err error) *MockMemberRepository_Get_Call {
-	_c.Call.Return(member, err)
+func (_c *MockPendingPrayerRepository_Create_Call) Return(err error) *MockPendingPrayerRepository_Create_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMemberRepository_Get_Call) RunAndReturn(run func(ctx context.Context, phone string) (*domain.Member, error)) *MockMemberRepository_Get_Call {
+func (_c *MockPendingPrayerRepository_Create_Call) RunAndReturn(run func(ctx context.Context, prayer *domain.PendingPrayer) error) *MockPendingPrayerRepository_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Save provides a mock function for the type MockMemberRepository
-func (_mock *MockMemberRepository) Save(ctx context.Context, member *domain.Member) error {
-	ret := _mock.Called(ctx, member)
+// Delete provides a mock function for the type MockPendingPrayerRepository
+func (_mock *MockPendingPrayerRepository) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Save")
+		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Member) error); ok {
-		r0 = returnFunc(ctx, member)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockMemberRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
-type MockMemberRepository_Save_Call struct {
+// MockPendingPrayerRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockPendingPrayerRepository_Delete_Call struct {
 	*mock.Call
 }
 
-// Save is a helper method to define mock.On call
+// Delete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - member *domain.Member
-func (_e *MockMemberRepository_Expecter) Save(ctx interface{}, member interface{}) *MockMemberRepository_Save_Call {
-	return &MockMemberRepository_Save_Call{Call: _e.mock.On("Save", ctx, member)}
+//   - id string
+func (_e *MockPendingPrayerRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockPendingPrayerRepository_Delete_Call {
+	return &MockPendingPrayerRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
 }
 
-func (_c *MockMemberRepository_Save_Call) Run(run func(ctx context.Context, member *domain.Member)) *MockMemberRepository_Save_Call {
+func (_c *MockPendingPrayerRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *MockPendingPrayerRepository_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.Member
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*domain.Member)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -652,169 +3141,153 @@ func (_c *MockMemberRepository_Save_Call) Run(run func(ctx context.Context, memb
 	return _c
 }
 
-func (_c *MockMemberRepository_Save_Call) Return(err error) *MockMemberRepository_Save_Call {
+func (_c *MockPendingPrayerRepository_Delete_Call) Return(err error) *MockPendingPrayerRepository_Delete_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMemberRepository_Save_Call) RunAndReturn(run func(ctx context.Context, member *domain.Member) error) *MockMemberRepository_Save_Call {
+func (_c *MockPendingPrayerRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockPendingPrayerRepository_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockBlockedPhonesRepository creates a new instance of MockBlockedPhonesRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockBlockedPhonesRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockBlockedPhonesRepository {
-	mock := &MockBlockedPhonesRepository{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockBlockedPhonesRepository is an autogenerated mock type for the BlockedPhonesRepository type
-type MockBlockedPhonesRepository struct {
-	mock.Mock
-}
-
-type MockBlockedPhonesRepository_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockBlockedPhonesRepository) EXPECT() *MockBlockedPhonesRepository_Expecter {
-	return &MockBlockedPhonesRepository_Expecter{mock: &_m.Mock}
-}
-
-// Get provides a mock function for the type MockBlockedPhonesRepository
-func (_mock *MockBlockedPhonesRepository) Get(ctx context.Context) (*domain.BlockedPhones, error) {
-	ret := _mock.Called(ctx)
+// Get provides a mock function for the type MockPendingPrayerRepository
+func (_mock *MockPendingPrayerRepository) Get(ctx context.Context, id string) (*domain.PendingPrayer, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
 	}
 
-	var r0 *domain.BlockedPhones
+	var r0 *domain.PendingPrayer
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (*domain.BlockedPhones, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.PendingPrayer, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) *domain.BlockedPhones); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.PendingPrayer); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.BlockedPhones)
+			r0 = ret.Get(0).(*domain.PendingPrayer)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockBlockedPhonesRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockBlockedPhonesRepository_Get_Call struct {
+// MockPendingPrayerRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockPendingPrayerRepository_Get_Call struct {
 	*mock.Call
 }
 
 // Get is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockBlockedPhonesRepository_Expecter) Get(ctx interface{}) *MockBlockedPhonesRepository_Get_Call {
-	return &MockBlockedPhonesRepository_Get_Call{Call: _e.mock.On("Get", ctx)}
+//   - id string
+func (_e *MockPendingPrayerRepository_Expecter) Get(ctx interface{}, id interface{}) *MockPendingPrayerRepository_Get_Call {
+	return &MockPendingPrayerRepository_Get_Call{Call: _e.mock.On("Get", ctx, id)}
 }
 
-func (_c *MockBlockedPhonesRepository_Get_Call) Run(run func(ctx context.Context)) *MockBlockedPhonesRepository_Get_Call {
+func (_c *MockPendingPrayerRepository_Get_Call) Run(run func(ctx context.Context, id string)) *MockPendingPrayerRepository_Get_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockBlockedPhonesRepository_Get_Call) Return(blockedPhones *domain.BlockedPhones, err error) *MockBlockedPhonesRepository_Get_Call {
-	_c.Call.Return(blockedPhones, err)
+func (_c *MockPendingPrayerRepository_Get_Call) Return(pendingPrayer *domain.PendingPrayer, err error) *MockPendingPrayerRepository_Get_Call {
+	_c.Call.Return(pendingPrayer, err)
 	return _c
 }
 
-func (_c *MockBlockedPhonesRepository_Get_Call) RunAndReturn(run func(ctx context.Context) (*domain.BlockedPhones, error)) *MockBlockedPhonesRepository_Get_Call {
+func (_c *MockPendingPrayerRepository_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.PendingPrayer, error)) *MockPendingPrayerRepository_Get_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Save provides a mock function for the type MockBlockedPhonesRepository
-func (_mock *MockBlockedPhonesRepository) Save(ctx context.Context, phones *domain.BlockedPhones) error {
-	ret := _mock.Called(ctx, phones)
+// GetAll provides a mock function for the type MockPendingPrayerRepository
+func (_mock *MockPendingPrayerRepository) GetAll(ctx context.Context) ([]domain.PendingPrayer, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Save")
+		panic("no return value specified for GetAll")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.BlockedPhones) error); ok {
-		r0 = returnFunc(ctx, phones)
+	var r0 []domain.PendingPrayer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.PendingPrayer, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.PendingPrayer); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PendingPrayer)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockBlockedPhonesRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
-type MockBlockedPhonesRepository_Save_Call struct {
+// MockPendingPrayerRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockPendingPrayerRepository_GetAll_Call struct {
 	*mock.Call
 }
 
-// Save is a helper method to define mock.On call
+// GetAll is a helper method to define mock.On call
 //   - ctx context.Context
-//   - phones *domain.BlockedPhones
-func (_e *MockBlockedPhonesRepository_Expecter) Save(ctx interface{}, phones interface{}) *MockBlockedPhonesRepository_Save_Call {
-	return &MockBlockedPhonesRepository_Save_Call{Call: _e.mock.On("Save", ctx, phones)}
+func (_e *MockPendingPrayerRepository_Expecter) GetAll(ctx interface{}) *MockPendingPrayerRepository_GetAll_Call {
+	return &MockPendingPrayerRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx)}
 }
 
-func (_c *MockBlockedPhonesRepository_Save_Call) Run(run func(ctx context.Context, phones *domain.BlockedPhones)) *MockBlockedPhonesRepository_Save_Call {
+func (_c *MockPendingPrayerRepository_GetAll_Call) Run(run func(ctx context.Context)) *MockPendingPrayerRepository_GetAll_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.BlockedPhones
-		if args[1] != nil {
-			arg1 = args[1].(*domain.BlockedPhones)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockBlockedPhonesRepository_Save_Call) Return(err error) *MockBlockedPhonesRepository_Save_Call {
-	_c.Call.Return(err)
+func (_c *MockPendingPrayerRepository_GetAll_Call) Return(pendingPrayers []domain.PendingPrayer, err error) *MockPendingPrayerRepository_GetAll_Call {
+	_c.Call.Return(pendingPrayers, err)
 	return _c
 }
 
-func (_c *MockBlockedPhonesRepository_Save_Call) RunAndReturn(run func(ctx context.Context, phones *domain.BlockedPhones) error) *MockBlockedPhonesRepository_Save_Call {
+func (_c *MockPendingPrayerRepository_GetAll_Call) RunAndReturn(run func(ctx context.Context) ([]domain.PendingPrayer, error)) *MockPendingPrayerRepository_GetAll_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockIntercessorPhonesRepository creates a new instance of MockIntercessorPhonesRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockProfanityListsRepository creates a new instance of MockProfanityListsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockIntercessorPhonesRepository(t interface {
+func NewMockProfanityListsRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockIntercessorPhonesRepository {
-	mock := &MockIntercessorPhonesRepository{}
+}) *MockProfanityListsRepository {
+	mock := &MockProfanityListsRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -822,37 +3295,37 @@ func NewMockIntercessorPhonesRepository(t interface {
 	return mock
 }
 
-// MockIntercessorPhonesRepository is an autogenerated mock type for the IntercessorPhonesRepository type
-type MockIntercessorPhonesRepository struct {
+// MockProfanityListsRepository is an autogenerated mock type for the ProfanityListsRepository type
+type MockProfanityListsRepository struct {
 	mock.Mock
 }
 
-type MockIntercessorPhonesRepository_Expecter struct {
+type MockProfanityListsRepository_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockIntercessorPhonesRepository) EXPECT() *MockIntercessorPhonesRepository_Expecter {
-	return &MockIntercessorPhonesRepository_Expecter{mock: &_m.Mock}
+func (_m *MockProfanityListsRepository) EXPECT() *MockProfanityListsRepository_Expecter {
+	return &MockProfanityListsRepository_Expecter{mock: &_m.Mock}
 }
 
-// Get provides a mock function for the type MockIntercessorPhonesRepository
-func (_mock *MockIntercessorPhonesRepository) Get(ctx context.Context) (*domain.IntercessorPhones, error) {
+// Get provides a mock function for the type MockProfanityListsRepository
+func (_mock *MockProfanityListsRepository) Get(ctx context.Context) (*domain.ProfanityLists, error) {
 	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
 	}
 
-	var r0 *domain.IntercessorPhones
+	var r0 *domain.ProfanityLists
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (*domain.IntercessorPhones, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*domain.ProfanityLists, error)); ok {
 		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) *domain.IntercessorPhones); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *domain.ProfanityLists); ok {
 		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.IntercessorPhones)
+			r0 = ret.Get(0).(*domain.ProfanityLists)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -863,18 +3336,18 @@ func (_mock *MockIntercessorPhonesRepository) Get(ctx context.Context) (*domain.
 	return r0, r1
 }
 
-// MockIntercessorPhonesRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockIntercessorPhonesRepository_Get_Call struct {
+// MockProfanityListsRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockProfanityListsRepository_Get_Call struct {
 	*mock.Call
 }
 
 // Get is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockIntercessorPhonesRepository_Expecter) Get(ctx interface{}) *MockIntercessorPhonesRepository_Get_Call {
-	return &MockIntercessorPhonesRepository_Get_Call{Call: _e.mock.On("Get", ctx)}
+func (_e *MockProfanityListsRepository_Expecter) Get(ctx interface{}) *MockProfanityListsRepository_Get_Call {
+	return &MockProfanityListsRepository_Get_Call{Call: _e.mock.On("Get", ctx)}
 }
 
-func (_c *MockIntercessorPhonesRepository_Get_Call) Run(run func(ctx context.Context)) *MockIntercessorPhonesRepository_Get_Call {
+func (_c *MockProfanityListsRepository_Get_Call) Run(run func(ctx context.Context)) *MockProfanityListsRepository_Get_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -887,54 +3360,54 @@ func (_c *MockIntercessorPhonesRepository_Get_Call) Run(run func(ctx context.Con
 	return _c
 }
 
-func (_c *MockIntercessorPhonesRepository_Get_Call) Return(intercessorPhones *domain.IntercessorPhones, err error) *MockIntercessorPhonesRepository_Get_Call {
-	_c.Call.Return(intercessorPhones, err)
+func (_c *MockProfanityListsRepository_Get_Call) Return(profanityLists *domain.ProfanityLists, err error) *MockProfanityListsRepository_Get_Call {
+	_c.Call.Return(profanityLists, err)
 	return _c
 }
 
-func (_c *MockIntercessorPhonesRepository_Get_Call) RunAndReturn(run func(ctx context.Context) (*domain.IntercessorPhones, error)) *MockIntercessorPhonesRepository_Get_Call {
+func (_c *MockProfanityListsRepository_Get_Call) RunAndReturn(run func(ctx context.Context) (*domain.ProfanityLists, error)) *MockProfanityListsRepository_Get_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Save provides a mock function for the type MockIntercessorPhonesRepository
-func (_mock *MockIntercessorPhonesRepository) Save(ctx context.Context, phones *domain.IntercessorPhones) error {
-	ret := _mock.Called(ctx, phones)
+// Save provides a mock function for the type MockProfanityListsRepository
+func (_mock *MockProfanityListsRepository) Save(ctx context.Context, lists *domain.ProfanityLists) error {
+	ret := _mock.Called(ctx, lists)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.IntercessorPhones) error); ok {
-		r0 = returnFunc(ctx, phones)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.ProfanityLists) error); ok {
+		r0 = returnFunc(ctx, lists)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockIntercessorPhonesRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
-type MockIntercessorPhonesRepository_Save_Call struct {
+// MockProfanityListsRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockProfanityListsRepository_Save_Call struct {
 	*mock.Call
 }
 
 // Save is a helper method to define mock.On call
 //   - ctx context.Context
-//   - phones *domain.IntercessorPhones
-func (_e *MockIntercessorPhonesRepository_Expecter) Save(ctx interface{}, phones interface{}) *MockIntercessorPhonesRepository_Save_Call {
-	return &MockIntercessorPhonesRepository_Save_Call{Call: _e.mock.On("Save", ctx, phones)}
+//   - lists *domain.ProfanityLists
+func (_e *MockProfanityListsRepository_Expecter) Save(ctx interface{}, lists interface{}) *MockProfanityListsRepository_Save_Call {
+	return &MockProfanityListsRepository_Save_Call{Call: _e.mock.On("Save", ctx, lists)}
 }
 
-func (_c *MockIntercessorPhonesRepository_Save_Call) Run(run func(ctx context.Context, phones *domain.IntercessorPhones)) *MockIntercessorPhonesRepository_Save_Call {
+func (_c *MockProfanityListsRepository_Save_Call) Run(run func(ctx context.Context, lists *domain.ProfanityLists)) *MockProfanityListsRepository_Save_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.IntercessorPhones
+		var arg1 *domain.ProfanityLists
 		if args[1] != nil {
-			arg1 = args[1].(*domain.IntercessorPhones)
+			arg1 = args[1].(*domain.ProfanityLists)
 		}
 		run(
 			arg0,
@@ -944,23 +3417,23 @@ func (_c *MockIntercessorPhonesRepository_Save_Call) Run(run func(ctx context.Co
 	return _c
 }
 
-func (_c *MockIntercessorPhonesRepository_Save_Call) Return(err error) *MockIntercessorPhonesRepository_Save_Call {
+func (_c *MockProfanityListsRepository_Save_Call) Return(err error) *MockProfanityListsRepository_Save_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockIntercessorPhonesRepository_Save_Call) RunAndReturn(run func(ctx context.Context, phones *domain.IntercessorPhones) error) *MockIntercessorPhonesRepository_Save_Call {
+func (_c *MockProfanityListsRepository_Save_Call) RunAndReturn(run func(ctx context.Context, lists *domain.ProfanityLists) error) *MockProfanityListsRepository_Save_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockPrayerRepository creates a new instance of MockPrayerRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockTranscriptRepository creates a new instance of MockTranscriptRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockPrayerRepository(t interface {
+func NewMockTranscriptRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockPrayerRepository {
-	mock := &MockPrayerRepository{}
+}) *MockTranscriptRepository {
+	mock := &MockTranscriptRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -968,50 +3441,49 @@ func NewMockPrayerRepository(t interface {
 	return mock
 }
 
-// MockPrayerRepository is an autogenerated mock type for the PrayerRepository type
-type MockPrayerRepository struct {
+// MockTranscriptRepository is an autogenerated mock type for the TranscriptRepository type
+type MockTranscriptRepository struct {
 	mock.Mock
 }
 
-type MockPrayerRepository_Expecter struct {
+type MockTranscriptRepository_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockPrayerRepository) EXPECT() *MockPrayerRepository_Expecter {
-	return &MockPrayerRepository_Expecter{mock: &_m.Mock}
+func (_m *MockTranscriptRepository) EXPECT() *MockTranscriptRepository_Expecter {
+	return &MockTranscriptRepository_Expecter{mock: &_m.Mock}
 }
 
-// Delete provides a mock function for the type MockPrayerRepository
-func (_mock *MockPrayerRepository) Delete(ctx context.Context, key string, queued bool) error {
-	ret := _mock.Called(ctx, key, queued)
+// DeleteThread provides a mock function for the type MockTranscriptRepository
+func (_mock *MockTranscriptRepository) DeleteThread(ctx context.Context, phone string) error {
+	ret := _mock.Called(ctx, phone)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for DeleteThread")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
-		r0 = returnFunc(ctx, key, queued)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, phone)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockPrayerRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockPrayerRepository_Delete_Call struct {
+// MockTranscriptRepository_DeleteThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteThread'
+type MockTranscriptRepository_DeleteThread_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
+// DeleteThread is a helper method to define mock.On call
 //   - ctx context.Context
-//   - key string
-//   - queued bool
-func (_e *MockPrayerRepository_Expecter) Delete(ctx interface{}, key interface{}, queued interface{}) *MockPrayerRepository_Delete_Call {
-	return &MockPrayerRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, key, queued)}
+//   - phone string
+func (_e *MockTranscriptRepository_Expecter) DeleteThread(ctx interface{}, phone interface{}) *MockTranscriptRepository_DeleteThread_Call {
+	return &MockTranscriptRepository_DeleteThread_Call{Call: _e.mock.On("DeleteThread", ctx, phone)}
 }
 
-func (_c *MockPrayerRepository_Delete_Call) Run(run func(ctx context.Context, key string, queued bool)) *MockPrayerRepository_Delete_Call {
+func (_c *MockTranscriptRepository_DeleteThread_Call) Run(run func(ctx context.Context, phone string)) *MockTranscriptRepository_DeleteThread_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1021,46 +3493,43 @@ func (_c *MockPrayerRepository_Delete_Call) Run(run func(ctx context.Context, ke
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 bool
-		if args[2] != nil {
-			arg2 = args[2].(bool)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockPrayerRepository_Delete_Call) Return(err error) *MockPrayerRepository_Delete_Call {
+func (_c *MockTranscriptRepository_DeleteThread_Call) Return(err error) *MockTranscriptRepository_DeleteThread_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockPrayerRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, key string, queued bool) error) *MockPrayerRepository_Delete_Call {
+func (_c *MockTranscriptRepository_DeleteThread_Call) RunAndReturn(run func(ctx context.Context, phone string) error) *MockTranscriptRepository_DeleteThread_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Exists provides a mock function for the type MockPrayerRepository
-func (_mock *MockPrayerRepository) Exists(ctx context.Context, phone string) (bool, error) {
+// GetThread provides a mock function for the type MockTranscriptRepository
+func (_mock *MockTranscriptRepository) GetThread(ctx context.Context, phone string) ([]domain.TranscriptMessage, error) {
 	ret := _mock.Called(ctx, phone)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Exists")
+		panic("no return value specified for GetThread")
 	}
 
-	var r0 bool
+	var r0 []domain.TranscriptMessage
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.TranscriptMessage, error)); ok {
 		return returnFunc(ctx, phone)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.TranscriptMessage); ok {
 		r0 = returnFunc(ctx, phone)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TranscriptMessage)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
 		r1 = returnFunc(ctx, phone)
@@ -1070,88 +3539,19 @@ func (_mock *MockPrayerRepository) Exists(ctx context.Context, phone string) (bo
 	return r0, r1
 }
 
-// MockPrayerRepository_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
-type MockPrayerRepository_Exists_Call struct {
+// MockTranscriptRepository_GetThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetThread'
+type MockTranscriptRepository_GetThread_Call struct {
 	*mock.Call
 }
 
-// Exists is a helper method to define mock.On call
+// GetThread is a helper method to define mock.On call
 //   - ctx context.Context
 //   - phone string
-func (_e *MockPrayerRepository_Expecter) Exists(ctx interface{}, phone interface{}) *MockPrayerRepository_Exists_Call {
-	return &MockPrayerRepository_Exists_Call{Call: _e.mock.On("Exists", ctx, phone)}
-}
-
-func (_c *MockPrayerRepository_Exists_Call) Run(run func(ctx context.Context, phone string)) *MockPrayerRepository_Exists_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
-		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		run(
-			arg0,
-			arg1,
-		)
-	})
-	return _c
-}
-
-func (_c *MockPrayerRepository_Exists_Call) Return(b bool, err error) *MockPrayerRepository_Exists_Call {
-	_c.Call.Return(b, err)
-	return _c
-}
-
-func (_c *MockPrayerRepository_Exists_Call) RunAndReturn(run func(ctx context.Context, phone string) (bool, error)) *MockPrayerRepository_Exists_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// Get provides a mock function for the type MockPrayerRepository
-func (_mock *MockPrayerRepository) Get(ctx context.Context, key string, queued bool) (*domain.Prayer, error) {
-	ret := _mock.Called(ctx, key, queued)
-
-	if len(ret) == 0 {
-		panic("no return value specified for Get")
-	}
-
-	var r0 *domain.Prayer
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) (*domain.Prayer, error)); ok {
-		return returnFunc(ctx, key, queued)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) *domain.Prayer); ok {
-		r0 = returnFunc(ctx, key, queued)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Prayer)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
-		r1 = returnFunc(ctx, key, queued)
-	} else {
-		r1 = ret.Error(1)
-	}
-	return r0, r1
-}
-
-// MockPrayerRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockPrayerRepository_Get_Call struct {
-	*mock.Call
-}
-
-// Get is a helper method to define mock.On call
-//   - ctx context.Context
-//   - key string
-//   - queued bool
-func (_e *MockPrayerRepository_Expecter) Get(ctx interface{}, key interface{}, queued interface{}) *MockPrayerRepository_Get_Call {
-	return &MockPrayerRepository_Get_Call{Call: _e.mock.On("Get", ctx, key, queued)}
+func (_e *MockTranscriptRepository_Expecter) GetThread(ctx interface{}, phone interface{}) *MockTranscriptRepository_GetThread_Call {
+	return &MockTranscriptRepository_GetThread_Call{Call: _e.mock.On("GetThread", ctx, phone)}
 }
 
-func (_c *MockPrayerRepository_Get_Call) Run(run func(ctx context.Context, key string, queued bool)) *MockPrayerRepository_Get_Call {
+func (_c *MockTranscriptRepository_GetThread_Call) Run(run func(ctx context.Context, phone string)) *MockTranscriptRepository_GetThread_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1161,79 +3561,6 @@ func (_c *MockPrayerRepository_Get_Call) Run(run func(ctx context.Context, key s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 bool
-		if args[2] != nil {
-			arg2 = args[2].(bool)
-		}
-		run(
-			arg0,
-			arg1,
-			arg2,
-		)
-	})
-	return _c
-}
-
-func (_c *MockPrayerRepository_Get_Call) Return(prayer *domain.Prayer, err error) *MockPrayerRepository_Get_Call {
-	_c.Call.Return(prayer, err)
-	return _c
-}
-
-func (_c *MockPrayerRepository_Get_Call) RunAndReturn(run func(ctx context.Context, key string, queued bool) (*domain.Prayer, error)) *MockPrayerRepository_Get_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// GetAll provides a mock function for the type MockPrayerRepository
-func (_mock *MockPrayerRepository) GetAll(ctx context.Context, queued bool) ([]domain.Prayer, error) {
-	ret := _mock.Called(ctx, queued)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetAll")
-	}
-
-	var r0 []domain.Prayer
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, bool) ([]domain.Prayer, error)); ok {
-		return returnFunc(ctx, queued)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, bool) []domain.Prayer); ok {
-		r0 = returnFunc(ctx, queued)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Prayer)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, bool) error); ok {
-		r1 = returnFunc(ctx, queued)
-	} else {
-		r1 = ret.Error(1)
-	}
-	return r0, r1
-}
-
-// MockPrayerRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
-type MockPrayerRepository_GetAll_Call struct {
-	*mock.Call
-}
-
-// GetAll is a helper method to define mock.On call
-//   - ctx context.Context
-//   - queued bool
-func (_e *MockPrayerRepository_Expecter) GetAll(ctx interface{}, queued interface{}) *MockPrayerRepository_GetAll_Call {
-	return &MockPrayerRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx, queued)}
-}
-
-func (_c *MockPrayerRepository_GetAll_Call) Run(run func(ctx context.Context, queued bool)) *MockPrayerRepository_GetAll_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
-		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 bool
-		if args[1] != nil {
-			arg1 = args[1].(bool)
-		}
 		run(
 			arg0,
 			arg1,
@@ -1242,75 +3569,69 @@ func (_c *MockPrayerRepository_GetAll_Call) Run(run func(ctx context.Context, qu
 	return _c
 }
 
-func (_c *MockPrayerRepository_GetAll_Call) Return(prayers []domain.Prayer, err error) *MockPrayerRepository_GetAll_Call {
-	_c.Call.Return(prayers, err)
+func (_c *MockTranscriptRepository_GetThread_Call) Return(transcriptMessages []domain.TranscriptMessage, err error) *MockTranscriptRepository_GetThread_Call {
+	_c.Call.Return(transcriptMessages, err)
 	return _c
 }
 
-func (_c *MockPrayerRepository_GetAll_Call) RunAndReturn(run func(ctx context.Context, queued bool) ([]domain.Prayer, error)) *MockPrayerRepository_GetAll_Call {
+func (_c *MockTranscriptRepository_GetThread_Call) RunAndReturn(run func(ctx context.Context, phone string) ([]domain.TranscriptMessage, error)) *MockTranscriptRepository_GetThread_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Save provides a mock function for the type MockPrayerRepository
-func (_mock *MockPrayerRepository) Save(ctx context.Context, prayer *domain.Prayer, queued bool) error {
-	ret := _mock.Called(ctx, prayer, queued)
+// Save provides a mock function for the type MockTranscriptRepository
+func (_mock *MockTranscriptRepository) Save(ctx context.Context, msg *domain.TranscriptMessage) error {
+	ret := _mock.Called(ctx, msg)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Prayer, bool) error); ok {
-		r0 = returnFunc(ctx, prayer, queued)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.TranscriptMessage) error); ok {
+		r0 = returnFunc(ctx, msg)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockPrayerRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
-type MockPrayerRepository_Save_Call struct {
+// MockTranscriptRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockTranscriptRepository_Save_Call struct {
 	*mock.Call
 }
 
 // Save is a helper method to define mock.On call
 //   - ctx context.Context
-//   - prayer *domain.Prayer
-//   - queued bool
-func (_e *MockPrayerRepository_Expecter) Save(ctx interface{}, prayer interface{}, queued interface{}) *MockPrayerRepository_Save_Call {
-	return &MockPrayerRepository_Save_Call{Call: _e.mock.On("Save", ctx, prayer, queued)}
+//   - msg *domain.TranscriptMessage
+func (_e *MockTranscriptRepository_Expecter) Save(ctx interface{}, msg interface{}) *MockTranscriptRepository_Save_Call {
+	return &MockTranscriptRepository_Save_Call{Call: _e.mock.On("Save", ctx, msg)}
 }
 
-func (_c *MockPrayerRepository_Save_Call) Run(run func(ctx context.Context, prayer *domain.Prayer, queued bool)) *MockPrayerRepository_Save_Call {
+func (_c *MockTranscriptRepository_Save_Call) Run(run func(ctx context.Context, msg *domain.TranscriptMessage)) *MockTranscriptRepository_Save_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.Prayer
+		var arg1 *domain.TranscriptMessage
 		if args[1] != nil {
-			arg1 = args[1].(*domain.Prayer)
-		}
-		var arg2 bool
-		if args[2] != nil {
-			arg2 = args[2].(bool)
+			arg1 = args[1].(*domain.TranscriptMessage)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockPrayerRepository_Save_Call) Return(err error) *MockPrayerRepository_Save_Call {
+func (_c *MockTranscriptRepository_Save_Call) Return(err error) *MockTranscriptRepository_Save_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockPrayerRepository_Save_Call) RunAndReturn(run func(ctx context.Context, prayer *domain.Prayer, queued bool) error) *MockPrayerRepository_Save_Call {
+func (_c *MockTranscriptRepository_Save_Call) RunAndReturn(run func(ctx context.Context, msg *domain.TranscriptMessage) error) *MockTranscriptRepository_Save_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,265 @@
+/*
+Package phone parses phone numbers written in the many ways people type them and normalizes them to E.164, the format
+that SMS providers use and that every phone number is stored in. Numbers written without a country code are read as
+national numbers of a default region.
+*/
+package phone
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	maxE164Digits   = 15
+	maxCodeDigits   = 3
+	maxJoinedTokens = 6
+	nanpCode        = "1"
+	nanpExitCode    = "011"
+	exitCode        = "00"
+)
+
+var (
+	ErrInvalid       = errors.New("invalid phone number")
+	ErrUnknownRegion = errors.New("unknown phone region")
+)
+
+// plan is the range of lengths that a national significant number (the number after the country code, without any
+// trunk prefix) may have in a country.
+type plan struct {
+	min int
+	max int
+}
+
+// genericPlan is used for calling codes that have no specific plan below. It is deliberately loose; it only rejects
+// numbers that could not be a phone number anywhere.
+var genericPlan = plan{4, 12}
+
+// plans maps every assigned country calling code to its numbering plan.
+var plans = func() map[string]plan {
+	specific := map[string]plan{
+		"1": {10, 10}, "7": {10, 10}, "20": {8, 10}, "27": {9, 9}, "30": {10, 10}, "31": {9, 9}, "32": {8, 9},
+		"33": {9, 9}, "34": {9, 9}, "36": {8, 9}, "39": {6, 11}, "40": {9, 9}, "41": {9, 9}, "43": {4, 13},
+		"44": {9, 10}, "45": {8, 8}, "46": {7, 10}, "47": {8, 8}, "48": {9, 9}, "49": {6, 13}, "51": {8, 9},
+		"52": {10, 10}, "53": {8, 8}, "54": {10, 11}, "55": {10, 11}, "56": {9, 9}, "57": {10, 10}, "58": {10, 10},
+		"60": {8, 10}, "61": {9, 9}, "62": {8, 12}, "63": {8, 10}, "64": {8, 10}, "65": {8, 8}, "66": {8, 9},
+		"81": {9, 10}, "82": {8, 10}, "84": {9, 10}, "86": {10, 11}, "90": {10, 10}, "91": {10, 10},
+		"92": {9, 10}, "93": {9, 9}, "94": {9, 9}, "95": {7, 10}, "98": {10, 10}, "233": {9, 9}, "234": {8, 10},
+		"250": {9, 9}, "254": {9, 9}, "255": {9, 9}, "256": {9, 9}, "351": {9, 9}, "353": {7, 9},
+		"358": {5, 12}, "380": {9, 9}, "852": {8, 8}, "886": {8, 9}, "966": {9, 9}, "971": {8, 9}, "972": {8, 9},
+	}
+	generic := "211 212 213 216 218 220 221 222 223 224 225 226 227 228 229 230 231 232 235 236 237 238 239 240 " +
+		"241 242 243 244 245 246 247 248 249 251 252 253 257 258 260 261 262 263 264 265 266 267 268 269 290 291 " +
+		"297 298 299 350 352 354 355 356 357 359 370 371 372 373 374 375 376 377 378 379 381 382 383 385 386 387 " +
+		"389 420 421 423 500 501 502 503 504 505 506 507 508 509 590 591 592 593 594 595 596 597 598 599 670 672 " +
+		"673 674 675 676 677 678 679 680 681 682 683 685 686 687 688 689 690 691 692 800 808 850 853 855 856 870 " +
+		"878 880 881 882 883 888 960 961 962 963 964 965 967 968 970 973 974 975 976 977 979 992 993 994 995 996 " +
+		"998"
+
+	all := make(map[string]plan, len(specific))
+	for _, code := range strings.Fields(generic) {
+		all[code] = genericPlan
+	}
+	for code, p := range specific {
+		all[code] = p
+	}
+	return all
+}()
+
+// region is how numbers are dialed nationally in a country: its calling code and the trunk prefix that national
+// numbers are written with, if any.
+type region struct {
+	code  string
+	trunk string
+}
+
+var regions = map[string]region{
+	"AR": {"54", "0"}, "AU": {"61", "0"}, "BR": {"55", "0"}, "CA": {"1", "1"}, "CN": {"86", "0"},
+	"DE": {"49", "0"}, "ES": {"34", ""}, "FR": {"33", "0"}, "GB": {"44", "0"}, "GH": {"233", "0"},
+	"IE": {"353", "0"}, "IN": {"91", "0"}, "IT": {"39", ""}, "JP": {"81", "0"}, "KE": {"254", "0"},
+	"KR": {"82", "0"}, "MX": {"52", ""}, "NG": {"234", "0"}, "NL": {"31", "0"}, "NZ": {"64", "0"},
+	"PH": {"63", "0"}, "PR": {"1", "1"}, "SG": {"65", ""}, "UG": {"256", "0"}, "US": {"1", "1"},
+	"ZA": {"27", "0"},
+}
+
+// Normalize parses raw and returns it in E.164 format, for example +447911123456. raw may contain the usual separators
+// (spaces, dashes, dots, slashes and parentheses) and may start with +, an international exit code (00, or 011 in
+// North America) or neither, in which case it is read as a national number of defaultRegion, an ISO 3166 country code
+// such as US or GB.
+func Normalize(raw string, defaultRegion string) (string, error) {
+	digits, international, err := clean(raw)
+	if err != nil {
+		return "", err
+	}
+
+	reg, ok := regions[strings.ToUpper(defaultRegion)]
+	if !ok {
+		return "", ErrUnknownRegion
+	}
+
+	switch {
+	case international:
+	case reg.code == nanpCode && strings.HasPrefix(digits, nanpExitCode):
+		digits = strings.TrimPrefix(digits, nanpExitCode)
+	case strings.HasPrefix(digits, exitCode):
+		digits = strings.TrimPrefix(digits, exitCode)
+	default:
+		digits = reg.code + nationalNumber(digits, reg)
+	}
+
+	return validate(digits)
+}
+
+// Valid reports whether raw is a phone number that Normalize accepts.
+func Valid(raw string, defaultRegion string) bool {
+	_, err := Normalize(raw, defaultRegion)
+	return err == nil
+}
+
+// Extract finds the first phone number written in text, which may span several words such as "+44 7911 123456". It
+// returns the number in E.164 format along with text with the number removed.
+func Extract(text string, defaultRegion string) (string, string, error) {
+	words := strings.Fields(text)
+	for start := range words {
+		if !isPhoneWord(words[start]) {
+			continue
+		}
+
+		number, end, err := extractAt(words, start, defaultRegion)
+		if err != nil {
+			return "", text, err
+		}
+		if number != "" {
+			rest := append(append([]string{}, words[:start]...), words[end:]...)
+			return number, strings.Join(rest, " "), nil
+		}
+	}
+
+	return "", text, ErrInvalid
+}
+
+// extractAt joins words from start until they form a valid number and returns it along with the index of the first
+// word after it, or an empty number when they never do. Once the number is valid, a following word is only joined
+// when it has at least as many digits as the word before it, as the groups of "123 456 7890" do, and the result is
+// still valid. That keeps "123 456" from cutting a number short without joining the count in "1234567890 2 weeks".
+func extractAt(words []string, start int, defaultRegion string) (string, int, error) {
+	number, end := "", start
+	for i := start; i < len(words) && i-start < maxJoinedTokens && isPhoneWord(words[i]); i++ {
+		if number != "" && digitCount(words[i]) < digitCount(words[i-1]) {
+			break
+		}
+
+		joined, err := Normalize(strings.Join(words[start:i+1], " "), defaultRegion)
+		if errors.Is(err, ErrUnknownRegion) {
+			return "", 0, err
+		}
+		if err == nil {
+			number, end = joined, i+1
+		} else if number != "" {
+			break
+		}
+	}
+
+	return number, end, nil
+}
+
+// clean strips separators from raw and returns its digits and whether it was written with a leading +.
+func clean(raw string) (string, bool, error) {
+	raw = strings.TrimSpace(raw)
+	international := strings.HasPrefix(raw, "+")
+	raw = strings.TrimPrefix(raw, "+")
+
+	var digits strings.Builder
+	for _, r := range raw {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case isSeparator(r):
+		default:
+			return "", false, ErrInvalid
+		}
+	}
+
+	if digits.Len() == 0 {
+		return "", false, ErrInvalid
+	}
+	return digits.String(), international, nil
+}
+
+// nationalNumber removes the trunk prefix from a nationally written number. In North America the trunk prefix is only
+// present when the number has 11 digits.
+func nationalNumber(digits string, reg region) string {
+	const nanpWithTrunk = 11
+	if reg.trunk == "" || !strings.HasPrefix(digits, reg.trunk) {
+		return digits
+	}
+	if reg.code == nanpCode && len(digits) != nanpWithTrunk {
+		return digits
+	}
+	return strings.TrimPrefix(digits, reg.trunk)
+}
+
+// validate checks digits, a full international number without the +, against the numbering plan of its calling code.
+func validate(digits string) (string, error) {
+	if len(digits) > maxE164Digits {
+		return "", ErrInvalid
+	}
+
+	for size := 1; size <= maxCodeDigits && size < len(digits); size++ {
+		code := digits[:size]
+		p, ok := plans[code]
+		if !ok {
+			continue
+		}
+
+		national := digits[size:]
+		national = dropMexicanMobilePrefix(code, national)
+		if len(national) < p.min || len(national) > p.max {
+			return "", ErrInvalid
+		}
+		return "+" + code + national, nil
+	}
+
+	return "", ErrInvalid
+}
+
+// dropMexicanMobilePrefix removes the 1 that Mexican mobile numbers used to be dialed with from abroad. It is no longer
+// part of the number but is still commonly written.
+func dropMexicanMobilePrefix(code, national string) string {
+	const mexicanMobileDigits = 11
+	if code == "52" && len(national) == mexicanMobileDigits && strings.HasPrefix(national, "1") {
+		return national[1:]
+	}
+	return national
+}
+
+func isSeparator(r rune) bool {
+	return strings.ContainsRune(" -./()\u00a0", r)
+}
+
+// digitCount returns the number of digits in word.
+func digitCount(word string) int {
+	count := 0
+	for _, r := range word {
+		if r >= '0' && r <= '9' {
+			count++
+		}
+	}
+	return count
+}
+
+// isPhoneWord reports whether word only contains characters that a written phone number can contain.
+func isPhoneWord(word string) bool {
+	word = strings.TrimPrefix(word, "+")
+	hasDigit := false
+	for _, r := range word {
+		switch {
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		case isSeparator(r):
+		default:
+			return false
+		}
+	}
+	return hasDigit
+}
@@ -0,0 +1,118 @@
+package phone_test
+
+import (
+	"testing"
+
+	"github.com/4JesusApps/prayertexter/internal/phone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    string
+		region string
+		want   string
+	}{
+		{"us dashes", "123-456-7890", "US", "+11234567890"},
+		{"us parentheses", "(123) 456-7890", "US", "+11234567890"},
+		{"us dots", "123.456.7890", "US", "+11234567890"},
+		{"us trunk prefix", "1 123 456 7890", "US", "+11234567890"},
+		{"us already e164", "+11234567890", "US", "+11234567890"},
+		{"us exit code", "011 44 7911 123456", "US", "+447911123456"},
+		{"canada", "+1 (416) 555-0199", "CA", "+14165550199"},
+		{"uk mobile national", "07911 123456", "GB", "+447911123456"},
+		{"uk mobile international", "+44 7911 123456", "US", "+447911123456"},
+		{"uk exit code", "0044 7911 123456", "GB", "+447911123456"},
+		{"germany mobile", "+49 151 23456789", "US", "+4915123456789"},
+		{"germany national", "030 123456", "DE", "+4930123456"},
+		{"france", "06 12 34 56 78", "FR", "+33612345678"},
+		{"italy keeps leading zero", "06 1234 5678", "IT", "+390612345678"},
+		{"spain", "612 34 56 78", "ES", "+34612345678"},
+		{"mexico old mobile prefix", "+52 1 55 1234 5678", "US", "+525512345678"},
+		{"brazil", "+55 11 91234-5678", "US", "+5511912345678"},
+		{"india", "098765 43210", "IN", "+919876543210"},
+		{"philippines", "0917 123 4567", "PH", "+639171234567"},
+		{"nigeria", "0803 123 4567", "NG", "+2348031234567"},
+		{"kenya", "+254 712 345678", "US", "+254712345678"},
+		{"australia", "0412 345 678", "AU", "+61412345678"},
+		{"new zealand", "021 123 4567", "NZ", "+64211234567"},
+		{"japan", "090-1234-5678", "JP", "+819012345678"},
+		{"south korea", "010-1234-5678", "KR", "+821012345678"},
+		{"china", "+86 138 0013 8000", "US", "+8613800138000"},
+		{"south africa", "082 123 4567", "ZA", "+27821234567"},
+		{"ireland", "087 123 4567", "IE", "+353871234567"},
+		{"singapore", "8123 4567", "SG", "+6581234567"},
+		{"generic plan country", "+502 2345 6789", "US", "+50223456789"},
+		{"lower case region", "123-456-7890", "us", "+11234567890"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := phone.Normalize(tc.raw, tc.region)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNormalizeInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    string
+		region string
+	}{
+		{"empty", "", "US"},
+		{"letters", "123-456-CALL", "US"},
+		{"us too short", "456-7890", "US"},
+		{"us too long", "223-456-78901", "US"},
+		{"uk too short", "+44 7911 123", "US"},
+		{"unassigned calling code", "+999 1234 5678", "US"},
+		{"longer than e164 allows", "+49 1234 5678 9012 345", "US"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := phone.Normalize(tc.raw, tc.region)
+			require.ErrorIs(t, err, phone.ErrInvalid)
+		})
+	}
+
+	t.Run("unknown region", func(t *testing.T) {
+		_, err := phone.Normalize("123-456-7890", "XX")
+		require.ErrorIs(t, err, phone.ErrUnknownRegion)
+	})
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantNum  string
+		wantRest string
+	}{
+		{"dashed number", "#block 123-456-7890 7d spamming", "+11234567890", "#block 7d spamming"},
+		{"number split by spaces", "#lookup 123 456 7890", "+11234567890", "#lookup"},
+		{"international number", "#block +44 7911 123456 abuse", "+447911123456", "#block abuse"},
+		{"number followed by a count", "#block 123-456-7890 12 times", "+11234567890", "#block 12 times"},
+		{"number followed by a digit", "#block 1234567890 2 weeks spam", "+11234567890", "#block 2 weeks spam"},
+		{"number in groups", "#block +33 6 12 34 56 78", "+33612345678", "#block"},
+		{"number first", "(123) 456-7890 #remove", "+11234567890", "#remove"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			num, rest, err := phone.Extract(tc.text, "US")
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantNum, num)
+			assert.Equal(t, tc.wantRest, rest)
+		})
+	}
+
+	t.Run("no number", func(t *testing.T) {
+		_, rest, err := phone.Extract("#block someone 7d", "US")
+		require.ErrorIs(t, err, phone.ErrInvalid)
+		assert.Equal(t, "#block someone 7d", rest)
+	})
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/4JesusApps/prayertexter/internal/domain"
+)
+
+// Global secondary indexes on the AdminAudit table. Both are sorted by Timestamp.
+const (
+	AuditAdminIndex  = "AdminPhone-index"
+	AuditTargetIndex = "Target-index"
+)
+
+// AuditRepository is append only: entries can be added and read but never changed or removed.
+type AuditRepository interface {
+	Save(ctx context.Context, entry *domain.AuditEntry) error
+	GetByAdmin(ctx context.Context, adminPhone string) ([]domain.AuditEntry, error)
+	GetByTarget(ctx context.Context, target string) ([]domain.AuditEntry, error)
+}
+
+type auditRepository struct {
+	repo *DynamoDBRepository[domain.AuditEntry]
+}
+
+func NewAuditRepository(client DDBClient, table string, timeout int) AuditRepository {
+	return &auditRepository{
+		repo: NewDynamoDBRepository[domain.AuditEntry](client, table, "ID", timeout),
+	}
+}
+
+// Save adds entry to the audit log. It returns ErrItemExists rather than overwrite an entry with the same ID.
+func (r *auditRepository) Save(ctx context.Context, entry *domain.AuditEntry) error {
+	return r.repo.Create(ctx, entry)
+}
+
+// GetByAdmin returns every action taken by adminPhone, oldest first.
+func (r *auditRepository) GetByAdmin(ctx context.Context, adminPhone string) ([]domain.AuditEntry, error) {
+	return r.repo.QueryIndex(ctx, AuditAdminIndex, "AdminPhone", adminPhone)
+}
+
+// GetByTarget returns every action taken against target, oldest first.
+func (r *auditRepository) GetByTarget(ctx context.Context, target string) ([]domain.AuditEntry, error) {
+	return r.repo.QueryIndex(ctx, AuditTargetIndex, "Target", target)
+}
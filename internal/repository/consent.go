@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/4JesusApps/prayertexter/internal/domain"
+)
+
+type ConsentRecordRepository interface {
+	Get(ctx context.Context, phone string) (*domain.ConsentRecord, error)
+	Save(ctx context.Context, record *domain.ConsentRecord) error
+}
+
+type consentRecordRepository struct {
+	repo *DynamoDBRepository[domain.ConsentRecord]
+}
+
+func NewConsentRecordRepository(client DDBClient, table string, timeout int) ConsentRecordRepository {
+	return &consentRecordRepository{
+		repo: NewDynamoDBRepository[domain.ConsentRecord](client, table, "Phone", timeout),
+	}
+}
+
+func (r *consentRecordRepository) Get(ctx context.Context, phone string) (*domain.ConsentRecord, error) {
+	return r.repo.Get(ctx, phone)
+}
+
+func (r *consentRecordRepository) Save(ctx context.Context, record *domain.ConsentRecord) error {
+	return r.repo.Save(ctx, record)
+}
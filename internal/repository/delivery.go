@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/4JesusApps/prayertexter/internal/domain"
+)
+
+type DeliveryReceiptRepository interface {
+	Get(ctx context.Context, messageID string) (*domain.DeliveryReceipt, error)
+	GetAll(ctx context.Context) ([]domain.DeliveryReceipt, error)
+	Save(ctx context.Context, receipt *domain.DeliveryReceipt) error
+	Delete(ctx context.Context, messageID string) error
+}
+
+type deliveryReceiptRepository struct {
+	repo *DynamoDBRepository[domain.DeliveryReceipt]
+}
+
+func NewDeliveryReceiptRepository(client DDBClient, table string, timeout int) DeliveryReceiptRepository {
+	return &deliveryReceiptRepository{
+		repo: NewDynamoDBRepository[domain.DeliveryReceipt](client, table, "MessageID", timeout),
+	}
+}
+
+func (r *deliveryReceiptRepository) Get(ctx context.Context, messageID string) (*domain.DeliveryReceipt, error) {
+	return r.repo.Get(ctx, messageID)
+}
+
+func (r *deliveryReceiptRepository) GetAll(ctx context.Context) ([]domain.DeliveryReceipt, error) {
+	return r.repo.GetAll(ctx)
+}
+
+func (r *deliveryReceiptRepository) Save(ctx context.Context, receipt *domain.DeliveryReceipt) error {
+	return r.repo.Save(ctx, receipt)
+}
+
+func (r *deliveryReceiptRepository) Delete(ctx context.Context, messageID string) error {
+	return r.repo.Delete(ctx, messageID)
+}
@@ -2,10 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/4JesusApps/prayertexter/internal/apperr"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
@@ -27,25 +29,50 @@ type DDBClient interface {
 		input *dynamodb.DeleteItemInput,
 		opts ...func(*dynamodb.Options),
 	) (*dynamodb.DeleteItemOutput, error)
+	UpdateItem(
+		ctx context.Context,
+		input *dynamodb.UpdateItemInput,
+		opts ...func(*dynamodb.Options),
+	) (*dynamodb.UpdateItemOutput, error)
 	Scan(
 		ctx context.Context,
 		params *dynamodb.ScanInput,
 		optFns ...func(*dynamodb.Options),
 	) (*dynamodb.ScanOutput, error)
+	Query(
+		ctx context.Context,
+		params *dynamodb.QueryInput,
+		optFns ...func(*dynamodb.Options),
+	) (*dynamodb.QueryOutput, error)
 }
 
+// DynamoDBRepository stores items of type T in a DynamoDB table. Every item is saved with the latest version of the
+// table's schema in SchemaVersionField, and items saved with an older version are upgraded as they are read.
 type DynamoDBRepository[T any] struct {
 	client   DDBClient
 	table    string
 	keyField string
+	schema   Schema
 	timeout  int
 }
 
+// NewDynamoDBRepository returns a repository for a table whose items have never changed shape, so are all version 0.
 func NewDynamoDBRepository[T any](client DDBClient, table, keyField string, timeout int) *DynamoDBRepository[T] {
+	return NewVersionedDynamoDBRepository[T](client, table, keyField, nil, timeout)
+}
+
+// NewVersionedDynamoDBRepository returns a repository for a table whose items are upgraded on read by schema.
+func NewVersionedDynamoDBRepository[T any](
+	client DDBClient,
+	table, keyField string,
+	schema Schema,
+	timeout int,
+) *DynamoDBRepository[T] {
 	return &DynamoDBRepository[T]{
 		client:   client,
 		table:    table,
 		keyField: keyField,
+		schema:   schema,
 		timeout:  timeout,
 	}
 }
@@ -67,21 +94,43 @@ func (r *DynamoDBRepository[T]) Get(ctx context.Context, key string) (*T, error)
 		return nil, apperr.WrapError(err, fmt.Sprintf("failed to get item from table %s", r.table))
 	}
 
-	var item T
-	if err = attributevalue.UnmarshalMap(resp.Item, &item); err != nil {
-		return nil, apperr.WrapError(err, fmt.Sprintf("failed to unmarshal item from table %s", r.table))
+	item, err := r.unmarshal(resp.Item)
+	if err != nil {
+		return nil, err
 	}
 
 	return &item, nil
 }
 
+// unmarshal upgrades item to the latest schema version and unmarshals it.
+func (r *DynamoDBRepository[T]) unmarshal(item map[string]types.AttributeValue) (T, error) {
+	var obj T
+	if _, err := r.schema.Upgrade(item); err != nil {
+		return obj, apperr.WrapError(err, fmt.Sprintf("failed to upgrade item from table %s", r.table))
+	}
+	if err := attributevalue.UnmarshalMap(item, &obj); err != nil {
+		return obj, apperr.WrapError(err, fmt.Sprintf("failed to unmarshal item from table %s", r.table))
+	}
+	return obj, nil
+}
+
+// marshal marshals item with the latest schema version.
+func (r *DynamoDBRepository[T]) marshal(item *T) (map[string]types.AttributeValue, error) {
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return nil, apperr.WrapError(err, fmt.Sprintf("failed to marshal item for table %s", r.table))
+	}
+	setItemVersion(av, r.schema.Version())
+	return av, nil
+}
+
 func (r *DynamoDBRepository[T]) Save(ctx context.Context, item *T) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Duration(r.timeout)*time.Second)
 	defer cancel()
 
-	av, err := attributevalue.MarshalMap(item)
+	av, err := r.marshal(item)
 	if err != nil {
-		return apperr.WrapError(err, fmt.Sprintf("failed to marshal item for table %s", r.table))
+		return err
 	}
 
 	input := &dynamodb.PutItemInput{
@@ -94,15 +143,76 @@ func (r *DynamoDBRepository[T]) Save(ctx context.Context, item *T) error {
 	return apperr.WrapError(err, fmt.Sprintf("failed to put item in table %s", r.table))
 }
 
+// Create puts item only when no item with the same key exists, so that existing items are never overwritten. It returns
+// ErrItemExists otherwise.
+func (r *DynamoDBRepository[T]) Create(ctx context.Context, item *T) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(r.timeout)*time.Second)
+	defer cancel()
+
+	av, err := r.marshal(item)
+	if err != nil {
+		return err
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName:           &r.table,
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(#key)"),
+		ExpressionAttributeNames: map[string]string{
+			"#key": r.keyField,
+		},
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityNone,
+	}
+
+	_, err = r.client.PutItem(ctx, input)
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return apperr.WrapError(ErrItemExists, fmt.Sprintf("failed to create item in table %s", r.table))
+	}
+	return apperr.WrapError(err, fmt.Sprintf("failed to put item in table %s", r.table))
+}
+
+// Update applies the update in input to the item with key, filling in the table and key. It returns
+// ErrConditionFailed when input has a condition that the item does not meet.
+func (r *DynamoDBRepository[T]) Update(ctx context.Context, key string, input *dynamodb.UpdateItemInput) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(r.timeout)*time.Second)
+	defer cancel()
+
+	input.TableName = &r.table
+	input.Key = map[string]types.AttributeValue{
+		r.keyField: &types.AttributeValueMemberS{Value: key},
+	}
+
+	_, err := r.client.UpdateItem(ctx, input)
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return apperr.WrapError(ErrConditionFailed, fmt.Sprintf("failed to update item in table %s", r.table))
+	}
+	return apperr.WrapError(err, fmt.Sprintf("failed to update item in table %s", r.table))
+}
+
 func (r *DynamoDBRepository[T]) Delete(ctx context.Context, key string) error {
+	return r.delete(ctx, map[string]types.AttributeValue{
+		r.keyField: &types.AttributeValueMemberS{Value: key},
+	})
+}
+
+// DeleteSorted deletes the item whose partition key is key and whose sort key, sortField, is sortKey. This is for
+// tables that also have a sort key.
+func (r *DynamoDBRepository[T]) DeleteSorted(ctx context.Context, key, sortField, sortKey string) error {
+	return r.delete(ctx, map[string]types.AttributeValue{
+		r.keyField: &types.AttributeValueMemberS{Value: key},
+		sortField:  &types.AttributeValueMemberS{Value: sortKey},
+	})
+}
+
+func (r *DynamoDBRepository[T]) delete(ctx context.Context, key map[string]types.AttributeValue) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Duration(r.timeout)*time.Second)
 	defer cancel()
 
 	input := &dynamodb.DeleteItemInput{
-		TableName: &r.table,
-		Key: map[string]types.AttributeValue{
-			r.keyField: &types.AttributeValueMemberS{Value: key},
-		},
+		TableName:              &r.table,
+		Key:                    key,
 		ReturnConsumedCapacity: types.ReturnConsumedCapacityNone,
 	}
 
@@ -127,11 +237,61 @@ func (r *DynamoDBRepository[T]) GetAll(ctx context.Context) ([]T, error) {
 	items := make([]T, 0, len(resp.Items))
 	for _, item := range resp.Items {
 		var obj T
-		if err = attributevalue.UnmarshalMap(item, &obj); err != nil {
-			return nil, apperr.WrapError(err, fmt.Sprintf("failed to unmarshal item from table %s", r.table))
+		if obj, err = r.unmarshal(item); err != nil {
+			return nil, err
 		}
 		items = append(items, obj)
 	}
 
 	return items, nil
 }
+
+// Query returns every item whose partition key equals key, following pagination. This is for tables that also have a
+// sort key, where items are returned in ascending sort key order.
+func (r *DynamoDBRepository[T]) Query(ctx context.Context, key string) ([]T, error) {
+	return r.query(ctx, nil, r.keyField, key)
+}
+
+// QueryIndex is like Query but reads the global secondary index named index, whose partition key is keyField.
+func (r *DynamoDBRepository[T]) QueryIndex(ctx context.Context, index, keyField, key string) ([]T, error) {
+	return r.query(ctx, &index, keyField, key)
+}
+
+func (r *DynamoDBRepository[T]) query(ctx context.Context, index *string, keyField, key string) ([]T, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(r.timeout)*time.Second)
+	defer cancel()
+
+	input := &dynamodb.QueryInput{
+		TableName:              &r.table,
+		IndexName:              index,
+		KeyConditionExpression: aws.String("#key = :key"),
+		ExpressionAttributeNames: map[string]string{
+			"#key": keyField,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":key": &types.AttributeValueMemberS{Value: key},
+		},
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityNone,
+	}
+
+	var items []T
+	for {
+		resp, err := r.client.Query(ctx, input)
+		if err != nil {
+			return nil, apperr.WrapError(err, fmt.Sprintf("failed to query table %s", r.table))
+		}
+
+		for _, item := range resp.Items {
+			var obj T
+			if obj, err = r.unmarshal(item); err != nil {
+				return nil, err
+			}
+			items = append(items, obj)
+		}
+
+		if len(resp.LastEvaluatedKey) == 0 {
+			return items, nil
+		}
+		input.ExclusiveStartKey = resp.LastEvaluatedKey
+	}
+}
@@ -73,6 +73,19 @@ func (s *DynamoDBRepoSuite) TestDelete_Success() {
 	s.Require().NoError(err)
 }
 
+func (s *DynamoDBRepoSuite) TestDeleteSorted() {
+	s.client.EXPECT().
+		DeleteItem(mock.Anything, mock.MatchedBy(func(in *dynamodb.DeleteItemInput) bool {
+			phn, _ := in.Key["Phone"].(*types.AttributeValueMemberS)
+			ts, _ := in.Key["Timestamp"].(*types.AttributeValueMemberS)
+			return len(in.Key) == 2 && phn.Value == "+11234567890" && ts.Value == "2024-01-01T00:00:00Z"
+		})).
+		Return(&dynamodb.DeleteItemOutput{}, nil)
+
+	err := s.repo.DeleteSorted(s.ctx, "+11234567890", "Timestamp", "2024-01-01T00:00:00Z")
+	s.Require().NoError(err)
+}
+
 func (s *DynamoDBRepoSuite) TestGetAll_Success() {
 	mem1, _ := attributevalue.MarshalMap(&domain.Member{Phone: "+11111111111", Name: "A"})
 	mem2, _ := attributevalue.MarshalMap(&domain.Member{Phone: "+12222222222", Name: "B"})
@@ -90,6 +103,67 @@ func (s *DynamoDBRepoSuite) TestGetAll_Success() {
 	s.Equal("B", members[1].Name)
 }
 
+func (s *DynamoDBRepoSuite) TestQuery_Paginates() {
+	mem1, _ := attributevalue.MarshalMap(&domain.Member{Phone: "+11111111111", Name: "A"})
+	mem2, _ := attributevalue.MarshalMap(&domain.Member{Phone: "+11111111111", Name: "B"})
+	lastKey := map[string]types.AttributeValue{"Phone": &types.AttributeValueMemberS{Value: "+11111111111"}}
+
+	s.client.EXPECT().
+		Query(mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+			return in.ExclusiveStartKey == nil
+		})).
+		Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{mem1}, LastEvaluatedKey: lastKey}, nil).
+		Once()
+	s.client.EXPECT().
+		Query(mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+			return in.ExclusiveStartKey != nil
+		})).
+		Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{mem2}}, nil).
+		Once()
+
+	members, err := s.repo.Query(s.ctx, "+11111111111")
+	s.Require().NoError(err)
+	s.Require().Len(members, 2)
+	s.Equal("A", members[0].Name)
+	s.Equal("B", members[1].Name)
+}
+
+func (s *DynamoDBRepoSuite) TestCreate_Success() {
+	s.client.EXPECT().
+		PutItem(mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+			return *in.ConditionExpression == "attribute_not_exists(#key)" &&
+				in.ExpressionAttributeNames["#key"] == "Phone"
+		})).
+		Return(&dynamodb.PutItemOutput{}, nil)
+
+	err := s.repo.Create(s.ctx, &domain.Member{Phone: "+11234567890"})
+	s.Require().NoError(err)
+}
+
+func (s *DynamoDBRepoSuite) TestCreate_Exists() {
+	s.client.EXPECT().
+		PutItem(mock.Anything, mock.Anything).
+		Return(nil, &types.ConditionalCheckFailedException{})
+
+	err := s.repo.Create(s.ctx, &domain.Member{Phone: "+11234567890"})
+	s.Require().ErrorIs(err, repository.ErrItemExists)
+}
+
+func (s *DynamoDBRepoSuite) TestQueryIndex() {
+	mem, _ := attributevalue.MarshalMap(&domain.Member{Phone: "+11111111111", Name: "A"})
+
+	s.client.EXPECT().
+		Query(mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+			return *in.IndexName == "Name-index" && in.ExpressionAttributeNames["#key"] == "Name"
+		})).
+		Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{mem}}, nil)
+
+	members, err := s.repo.QueryIndex(s.ctx, "Name-index", "Name", "A")
+	s.Require().NoError(err)
+	s.Require().Len(members, 1)
+	s.Equal("+11111111111", members[0].Phone)
+}
+
 func TestDynamoDBRepoSuite(t *testing.T) {
 	suite.Run(t, new(DynamoDBRepoSuite))
 }
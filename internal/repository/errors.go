@@ -0,0 +1,16 @@
+package repository
+
+type constError string
+
+func (err constError) Error() string {
+	return string(err)
+}
+
+// ErrItemExists is returned by Create when the table already holds an item with the same key.
+const ErrItemExists = constError("item already exists")
+
+// ErrConditionFailed is returned by Update when the item does not meet the update's condition.
+const ErrConditionFailed = constError("condition failed")
+
+// ErrSchemaVersion is returned when an item's schema version attribute cannot be read.
+const ErrSchemaVersion = constError("invalid schema version")
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/4JesusApps/prayertexter/internal/domain"
+)
+
+type RequestHistoryRepository interface {
+	Get(ctx context.Context, phone string) (*domain.RequestHistory, error)
+	Save(ctx context.Context, history *domain.RequestHistory) error
+	Delete(ctx context.Context, phone string) error
+}
+
+type requestHistoryRepository struct {
+	repo *DynamoDBRepository[domain.RequestHistory]
+}
+
+func NewRequestHistoryRepository(client DDBClient, table string, timeout int) RequestHistoryRepository {
+	return &requestHistoryRepository{
+		repo: NewDynamoDBRepository[domain.RequestHistory](client, table, "Phone", timeout),
+	}
+}
+
+func (r *requestHistoryRepository) Get(ctx context.Context, phone string) (*domain.RequestHistory, error) {
+	return r.repo.Get(ctx, phone)
+}
+
+func (r *requestHistoryRepository) Save(ctx context.Context, history *domain.RequestHistory) error {
+	return r.repo.Save(ctx, history)
+}
+
+func (r *requestHistoryRepository) Delete(ctx context.Context, phone string) error {
+	return r.repo.Delete(ctx, phone)
+}
@@ -11,6 +11,7 @@ type MemberRepository interface {
 	Save(ctx context.Context, member *domain.Member) error
 	Delete(ctx context.Context, phone string) error
 	Exists(ctx context.Context, phone string) (bool, error)
+	GetAll(ctx context.Context) ([]domain.Member, error)
 }
 
 type memberRepository struct {
@@ -19,7 +20,7 @@ type memberRepository struct {
 
 func NewMemberRepository(client DDBClient, table string, timeout int) MemberRepository {
 	return &memberRepository{
-		repo: NewDynamoDBRepository[domain.Member](client, table, "Phone", timeout),
+		repo: NewVersionedDynamoDBRepository[domain.Member](client, table, "Phone", MemberSchema, timeout),
 	}
 }
 
@@ -42,3 +43,7 @@ func (r *memberRepository) Exists(ctx context.Context, phone string) (bool, erro
 	}
 	return mem.SetupStatus != "", nil
 }
+
+func (r *memberRepository) GetAll(ctx context.Context) ([]domain.Member, error) {
+	return r.repo.GetAll(ctx)
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/apperr"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Migration rewrites the items of one table that were saved with an older schema version than the latest, so that
+// they no longer need upgrading on read.
+type Migration struct {
+	client   DDBClient
+	table    string
+	keyField string
+	schema   Schema
+	timeout  int
+}
+
+func NewMigration(client DDBClient, table, keyField string, schema Schema, timeout int) *Migration {
+	return &Migration{
+		client:   client,
+		table:    table,
+		keyField: keyField,
+		schema:   schema,
+		timeout:  timeout,
+	}
+}
+
+// MigrationResult counts the items that a migration scanned and upgraded. Skipped counts items that were saved or
+// deleted by prayertexter while the migration was upgrading them, which are left as prayertexter left them.
+type MigrationResult struct {
+	Scanned  int
+	Upgraded int
+	Skipped  int
+}
+
+// Run scans the table one page at a time and upgrades every item older than the latest schema version, saving it
+// back unless dryRun is true. After each page but the last, checkpoint is called with the key of the last item
+// scanned. Passing that key as start resumes an interrupted migration after it; an empty start begins at the start of
+// the table.
+func (m *Migration) Run(
+	ctx context.Context,
+	start string,
+	dryRun bool,
+	checkpoint func(key string),
+) (MigrationResult, error) {
+	var result MigrationResult
+	input := &dynamodb.ScanInput{
+		TableName:              &m.table,
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityNone,
+	}
+	if start != "" {
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			m.keyField: &types.AttributeValueMemberS{Value: start},
+		}
+	}
+
+	for {
+		resp, err := m.scan(ctx, input)
+		if err != nil {
+			return result, err
+		}
+
+		for _, item := range resp.Items {
+			result.Scanned++
+			var outcome migrationOutcome
+			if outcome, err = m.upgrade(ctx, item, dryRun); err != nil {
+				return result, err
+			}
+			switch outcome {
+			case itemUpgraded:
+				result.Upgraded++
+			case itemChanged:
+				result.Skipped++
+			case itemCurrent:
+			}
+		}
+
+		if len(resp.LastEvaluatedKey) == 0 {
+			return result, nil
+		}
+		key, _ := resp.LastEvaluatedKey[m.keyField].(*types.AttributeValueMemberS)
+		if key != nil {
+			checkpoint(key.Value)
+		}
+		input.ExclusiveStartKey = resp.LastEvaluatedKey
+	}
+}
+
+func (m *Migration) scan(ctx context.Context, input *dynamodb.ScanInput) (*dynamodb.ScanOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(m.timeout)*time.Second)
+	defer cancel()
+
+	resp, err := m.client.Scan(ctx, input)
+	return resp, apperr.WrapError(err, fmt.Sprintf("failed to scan table %s", m.table))
+}
+
+// migrationOutcome is what happened to an item during a migration.
+type migrationOutcome int
+
+const (
+	// itemCurrent items already had the latest schema version.
+	itemCurrent migrationOutcome = iota
+	// itemUpgraded items were upgraded, or would have been in a dry run.
+	itemUpgraded
+	// itemChanged items were saved or deleted by prayertexter after they were scanned.
+	itemChanged
+)
+
+// upgrade upgrades item and saves it unless dryRun is true. The save only goes ahead when the item still exists and
+// has the version it was read with, so that a newer save by prayertexter is never overwritten and a deleted item is
+// never recreated.
+func (m *Migration) upgrade(
+	ctx context.Context,
+	item map[string]types.AttributeValue,
+	dryRun bool,
+) (migrationOutcome, error) {
+	version, err := ItemVersion(item)
+	if err != nil {
+		return itemCurrent, apperr.WrapError(err, fmt.Sprintf("failed to read item version in table %s", m.table))
+	}
+	upgraded, err := m.schema.Upgrade(item)
+	if err != nil {
+		return itemCurrent, apperr.WrapError(err, fmt.Sprintf("failed to upgrade item in table %s", m.table))
+	}
+	if !upgraded {
+		return itemCurrent, nil
+	}
+	if dryRun {
+		return itemUpgraded, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(m.timeout)*time.Second)
+	defer cancel()
+
+	_, err = m.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: &m.table,
+		Item:      item,
+		ConditionExpression: aws.String(
+			"attribute_exists(#key) AND (attribute_not_exists(#version) OR #version = :version)",
+		),
+		ExpressionAttributeNames: map[string]string{
+			"#key":     m.keyField,
+			"#version": SchemaVersionField,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":version": &types.AttributeValueMemberN{Value: strconv.Itoa(version)},
+		},
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityNone,
+	})
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return itemChanged, nil
+	}
+	if err != nil {
+		return itemCurrent, apperr.WrapError(err, fmt.Sprintf("failed to put item in table %s", m.table))
+	}
+	return itemUpgraded, nil
+}
@@ -0,0 +1,138 @@
+package repository_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/4JesusApps/prayertexter/internal/repository"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	repomocks "github.com/4JesusApps/prayertexter/internal/mocks/repository"
+)
+
+type MigrationSuite struct {
+	suite.Suite
+	client    *repomocks.MockDDBClient
+	migration *repository.Migration
+	ctx       context.Context
+}
+
+func (s *MigrationSuite) SetupTest() {
+	s.client = repomocks.NewMockDDBClient(s.T())
+	s.migration = repository.NewMigration(s.client, "Member", "Phone", repository.MemberSchema, 60)
+	s.ctx = context.Background()
+}
+
+// member returns a member item for phn, with version when version is not empty.
+func (s *MigrationSuite) member(phn, version string) map[string]types.AttributeValue {
+	item, err := attributevalue.MarshalMap(map[string]any{"Phone": phn, "Administrator": true})
+	s.Require().NoError(err)
+	if version != "" {
+		item[repository.SchemaVersionField] = &types.AttributeValueMemberN{Value: version}
+	}
+	return item
+}
+
+func key(phn string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{"Phone": &types.AttributeValueMemberS{Value: phn}}
+}
+
+// expectPages expects a scan starting after start that returns first, then one that returns second.
+func (s *MigrationSuite) expectPages(start string, first, second []map[string]types.AttributeValue) {
+	s.client.EXPECT().
+		Scan(mock.Anything, mock.MatchedBy(func(in *dynamodb.ScanInput) bool {
+			if start == "" {
+				return in.ExclusiveStartKey == nil
+			}
+			k, _ := in.ExclusiveStartKey["Phone"].(*types.AttributeValueMemberS)
+			return k != nil && k.Value == start
+		})).
+		Return(&dynamodb.ScanOutput{Items: first, LastEvaluatedKey: key("+12222222222")}, nil).
+		Once()
+	s.client.EXPECT().
+		Scan(mock.Anything, mock.MatchedBy(func(in *dynamodb.ScanInput) bool {
+			k, _ := in.ExclusiveStartKey["Phone"].(*types.AttributeValueMemberS)
+			return k != nil && k.Value == "+12222222222"
+		})).
+		Return(&dynamodb.ScanOutput{Items: second}, nil).
+		Once()
+}
+
+func (s *MigrationSuite) TestRun() {
+	s.expectPages("",
+		[]map[string]types.AttributeValue{s.member("+11111111111", ""), s.member("+12222222222", "1")},
+		[]map[string]types.AttributeValue{s.member("+13333333333", ""), s.member("+14444444444", "")},
+	)
+	var saved []string
+	s.client.EXPECT().
+		PutItem(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.PutItemOutput, error,
+		) {
+			phn, _ := in.Item["Phone"].(*types.AttributeValueMemberS)
+			s.Require().NotNil(phn)
+			s.NotContains(in.Item, "Administrator")
+			s.Require().NotNil(in.ConditionExpression)
+			if phn.Value == "+14444444444" {
+				return nil, &types.ConditionalCheckFailedException{}
+			}
+			saved = append(saved, phn.Value)
+			return &dynamodb.PutItemOutput{}, nil
+		})
+
+	var checkpoints []string
+	result, err := s.migration.Run(s.ctx, "", false, func(key string) {
+		checkpoints = append(checkpoints, key)
+	})
+	s.Require().NoError(err)
+	s.Equal(repository.MigrationResult{Scanned: 4, Upgraded: 2, Skipped: 1}, result)
+	s.Equal([]string{"+11111111111", "+13333333333"}, saved)
+	s.Equal([]string{"+12222222222"}, checkpoints)
+}
+
+func (s *MigrationSuite) TestRun_DryRunResumes() {
+	s.expectPages("+10000000000",
+		[]map[string]types.AttributeValue{s.member("+11111111111", "")},
+		[]map[string]types.AttributeValue{s.member("+13333333333", "")},
+	)
+
+	result, err := s.migration.Run(s.ctx, "+10000000000", true, func(string) {})
+	s.Require().NoError(err)
+	s.Equal(repository.MigrationResult{Scanned: 2, Upgraded: 2}, result)
+	s.client.AssertNotCalled(s.T(), "PutItem", mock.Anything, mock.Anything)
+}
+
+func (s *MigrationSuite) TestRun_SkipsDeletedItems() {
+	s.client.EXPECT().Scan(mock.Anything, mock.Anything).
+		Return(&dynamodb.ScanOutput{Items: []map[string]types.AttributeValue{s.member("+11111111111", "")}}, nil)
+
+	// The member is deleted after the scan, so only a put that requires the item to exist is refused.
+	recreated := false
+	s.client.EXPECT().
+		PutItem(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (
+			*dynamodb.PutItemOutput, error,
+		) {
+			s.Require().NotNil(in.ConditionExpression)
+			if strings.Contains(*in.ConditionExpression, "attribute_exists(#key)") &&
+				in.ExpressionAttributeNames["#key"] == "Phone" {
+				return nil, &types.ConditionalCheckFailedException{}
+			}
+			recreated = true
+			return &dynamodb.PutItemOutput{}, nil
+		})
+
+	result, err := s.migration.Run(s.ctx, "", false, func(string) {})
+	s.Require().NoError(err)
+	s.Equal(repository.MigrationResult{Scanned: 1, Skipped: 1}, result)
+	s.False(recreated)
+}
+
+func TestMigrationSuite(t *testing.T) {
+	suite.Run(t, new(MigrationSuite))
+}
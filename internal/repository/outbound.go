@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type DeferredMessageRepository interface {
+	GetAll(ctx context.Context) ([]domain.DeferredMessage, error)
+	Save(ctx context.Context, msg *domain.DeferredMessage) error
+	Delete(ctx context.Context, id string) error
+}
+
+type SendCountRepository interface {
+	// Take counts one message against key in period and returns true, or returns false when limit messages already
+	// count against it. Counts from an earlier period are discarded. The count expires at expires.
+	Take(ctx context.Context, key, period string, limit int, expires time.Time) (bool, error)
+}
+
+type deferredMessageRepository struct {
+	repo *DynamoDBRepository[domain.DeferredMessage]
+}
+
+func NewDeferredMessageRepository(client DDBClient, table string, timeout int) DeferredMessageRepository {
+	return &deferredMessageRepository{
+		repo: NewDynamoDBRepository[domain.DeferredMessage](client, table, "ID", timeout),
+	}
+}
+
+func (r *deferredMessageRepository) GetAll(ctx context.Context) ([]domain.DeferredMessage, error) {
+	return r.repo.GetAll(ctx)
+}
+
+func (r *deferredMessageRepository) Save(ctx context.Context, msg *domain.DeferredMessage) error {
+	return r.repo.Save(ctx, msg)
+}
+
+func (r *deferredMessageRepository) Delete(ctx context.Context, id string) error {
+	return r.repo.Delete(ctx, id)
+}
+
+type sendCountRepository struct {
+	repo *DynamoDBRepository[domain.SendCount]
+}
+
+func NewSendCountRepository(client DDBClient, table string, timeout int) SendCountRepository {
+	return &sendCountRepository{
+		repo: NewDynamoDBRepository[domain.SendCount](client, table, "Phone", timeout),
+	}
+}
+
+// Take updates the count atomically, so that concurrent senders never lose counts or exceed limit together. It first
+// adds to the count if it is for period and under limit, then starts a new count if it is for another period. When a
+// concurrent sender started the new count first, it tries adding once more.
+func (r *sendCountRepository) Take(
+	ctx context.Context,
+	key, period string,
+	limit int,
+	expires time.Time,
+) (bool, error) {
+	names := map[string]string{"#period": "Period", "#count": "Count", "#expires": "ExpiresAt"}
+	values := func() map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			":period":  &types.AttributeValueMemberS{Value: period},
+			":one":     &types.AttributeValueMemberN{Value: "1"},
+			":limit":   &types.AttributeValueMemberN{Value: strconv.Itoa(limit)},
+			":expires": &types.AttributeValueMemberN{Value: strconv.FormatInt(expires.Unix(), 10)},
+		}
+	}
+	add := func() error {
+		return r.repo.Update(ctx, key, &dynamodb.UpdateItemInput{
+			UpdateExpression:          aws.String("ADD #count :one SET #expires = :expires"),
+			ConditionExpression:       aws.String("#period = :period AND #count < :limit"),
+			ExpressionAttributeNames:  names,
+			ExpressionAttributeValues: values(),
+		})
+	}
+	start := func() error {
+		vals := values()
+		delete(vals, ":limit")
+		return r.repo.Update(ctx, key, &dynamodb.UpdateItemInput{
+			UpdateExpression:          aws.String("SET #period = :period, #count = :one, #expires = :expires"),
+			ConditionExpression:       aws.String("attribute_not_exists(#period) OR #period <> :period"),
+			ExpressionAttributeNames:  names,
+			ExpressionAttributeValues: vals,
+		})
+	}
+
+	for _, update := range []func() error{add, start, add} {
+		err := update()
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, ErrConditionFailed) {
+			return false, err
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,70 @@
+package repository_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/repository"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	repomocks "github.com/4JesusApps/prayertexter/internal/mocks/repository"
+)
+
+type SendCountRepoSuite struct {
+	suite.Suite
+	client *repomocks.MockDDBClient
+	counts repository.SendCountRepository
+	ctx    context.Context
+}
+
+func (s *SendCountRepoSuite) SetupTest() {
+	s.client = repomocks.NewMockDDBClient(s.T())
+	s.counts = repository.NewSendCountRepository(s.client, "SendCount", 60)
+	s.ctx = context.Background()
+}
+
+// expectUpdate expects an update whose expression starts with prefix, returning err.
+func (s *SendCountRepoSuite) expectUpdate(prefix string, err error) {
+	s.client.EXPECT().
+		UpdateItem(mock.Anything, mock.MatchedBy(func(in *dynamodb.UpdateItemInput) bool {
+			return strings.HasPrefix(*in.UpdateExpression, prefix) && in.ConditionExpression != nil
+		})).
+		Return(&dynamodb.UpdateItemOutput{}, err).
+		Once()
+}
+
+func (s *SendCountRepoSuite) TestTake_Adds() {
+	s.expectUpdate("ADD", nil)
+
+	ok, err := s.counts.Take(s.ctx, "+11234567890", "2025-01-01", 2, time.Now())
+	s.Require().NoError(err)
+	s.True(ok)
+}
+
+func (s *SendCountRepoSuite) TestTake_StartsNewPeriod() {
+	s.expectUpdate("ADD", &types.ConditionalCheckFailedException{})
+	s.expectUpdate("SET", nil)
+
+	ok, err := s.counts.Take(s.ctx, "+11234567890", "2025-01-02", 2, time.Now())
+	s.Require().NoError(err)
+	s.True(ok)
+}
+
+func (s *SendCountRepoSuite) TestTake_AtLimit() {
+	s.expectUpdate("ADD", &types.ConditionalCheckFailedException{})
+	s.expectUpdate("SET", &types.ConditionalCheckFailedException{})
+	s.expectUpdate("ADD", &types.ConditionalCheckFailedException{})
+
+	ok, err := s.counts.Take(s.ctx, "+11234567890", "2025-01-01", 2, time.Now())
+	s.Require().NoError(err)
+	s.False(ok)
+}
+
+func TestSendCountRepoSuite(t *testing.T) {
+	suite.Run(t, new(SendCountRepoSuite))
+}
@@ -10,6 +10,7 @@ const (
 	phonesKeyField            = "Key"
 	blockedPhonesKeyValue     = "BlockedPhones"
 	intercessorPhonesKeyValue = "IntercessorPhones"
+	optedOutPhonesKeyValue    = "OptedOutPhones"
 )
 
 type BlockedPhonesRepository interface {
@@ -22,6 +23,11 @@ type IntercessorPhonesRepository interface {
 	Save(ctx context.Context, phones *domain.IntercessorPhones) error
 }
 
+type OptedOutPhonesRepository interface {
+	Get(ctx context.Context) (*domain.OptedOutPhones, error)
+	Save(ctx context.Context, phones *domain.OptedOutPhones) error
+}
+
 type blockedPhonesRepository struct {
 	repo *DynamoDBRepository[domain.BlockedPhones]
 }
@@ -59,3 +65,22 @@ func (r *intercessorPhonesRepository) Save(ctx context.Context, phones *domain.I
 	phones.Key = intercessorPhonesKeyValue
 	return r.repo.Save(ctx, phones)
 }
+
+type optedOutPhonesRepository struct {
+	repo *DynamoDBRepository[domain.OptedOutPhones]
+}
+
+func NewOptedOutPhonesRepository(client DDBClient, table string, timeout int) OptedOutPhonesRepository {
+	return &optedOutPhonesRepository{
+		repo: NewDynamoDBRepository[domain.OptedOutPhones](client, table, phonesKeyField, timeout),
+	}
+}
+
+func (r *optedOutPhonesRepository) Get(ctx context.Context) (*domain.OptedOutPhones, error) {
+	return r.repo.Get(ctx, optedOutPhonesKeyValue)
+}
+
+func (r *optedOutPhonesRepository) Save(ctx context.Context, phones *domain.OptedOutPhones) error {
+	phones.Key = optedOutPhonesKeyValue
+	return r.repo.Save(ctx, phones)
+}
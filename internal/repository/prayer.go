@@ -17,12 +17,25 @@ type PrayerRepository interface {
 type prayerRepository struct {
 	activeRepo *DynamoDBRepository[domain.Prayer]
 	queuedRepo *DynamoDBRepository[domain.Prayer]
+	members    MemberRepository
 }
 
-func NewPrayerRepository(client DDBClient, activeTable, queuedTable string, timeout int) PrayerRepository {
+// NewPrayerRepository returns a PrayerRepository whose Get and GetAll refresh the names of the members that prayers
+// refer to from members.
+func NewPrayerRepository(
+	client DDBClient,
+	members MemberRepository,
+	activeTable, queuedTable string,
+	timeout int,
+) PrayerRepository {
 	return &prayerRepository{
-		activeRepo: NewDynamoDBRepository[domain.Prayer](client, activeTable, "IntercessorPhone", timeout),
-		queuedRepo: NewDynamoDBRepository[domain.Prayer](client, queuedTable, "IntercessorPhone", timeout),
+		activeRepo: NewVersionedDynamoDBRepository[domain.Prayer](
+			client, activeTable, "IntercessorPhone", PrayerSchema, timeout,
+		),
+		queuedRepo: NewVersionedDynamoDBRepository[domain.Prayer](
+			client, queuedTable, "IntercessorPhone", PrayerSchema, timeout,
+		),
+		members: members,
 	}
 }
 
@@ -34,7 +47,14 @@ func (r *prayerRepository) selectRepo(queued bool) *DynamoDBRepository[domain.Pr
 }
 
 func (r *prayerRepository) Get(ctx context.Context, key string, queued bool) (*domain.Prayer, error) {
-	return r.selectRepo(queued).Get(ctx, key)
+	pryr, err := r.selectRepo(queued).Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if err = hydrateRefs(ctx, r.members, &pryr.Intercessor, &pryr.Requestor); err != nil {
+		return nil, err
+	}
+	return pryr, nil
 }
 
 func (r *prayerRepository) Save(ctx context.Context, prayer *domain.Prayer, queued bool) error {
@@ -54,5 +74,127 @@ func (r *prayerRepository) Exists(ctx context.Context, phone string) (bool, erro
 }
 
 func (r *prayerRepository) GetAll(ctx context.Context, queued bool) ([]domain.Prayer, error) {
-	return r.selectRepo(queued).GetAll(ctx)
+	prayers, err := r.selectRepo(queued).GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]*domain.MemberRef, 0, len(prayers)*2)
+	for i := range prayers {
+		refs = append(refs, &prayers[i].Intercessor, &prayers[i].Requestor)
+	}
+	if err = hydrateRefs(ctx, r.members, refs...); err != nil {
+		return nil, err
+	}
+	return prayers, nil
+}
+
+// hydrateRefs sets the name on each of refs to the current name of the member it refers to, so that prayers show names
+// that members have changed since. Each member is read once. Refs to no one, to members who no longer exist and to
+// anonymous requests keep the name they were saved with.
+func hydrateRefs(ctx context.Context, members MemberRepository, refs ...*domain.MemberRef) error {
+	names := make(map[string]string)
+	for _, ref := range refs {
+		if ref.Phone == "" || ref.Name == domain.AnonymousName {
+			continue
+		}
+		name, ok := names[ref.Phone]
+		if !ok {
+			mem, err := members.Get(ctx, ref.Phone)
+			if err != nil {
+				return err
+			}
+			name = mem.Name
+			names[ref.Phone] = name
+		}
+		if name != "" {
+			ref.Name = name
+		}
+	}
+	return nil
+}
+
+type CompletedPrayerRepository interface {
+	Save(ctx context.Context, prayer *domain.CompletedPrayer) error
+	GetAll(ctx context.Context) ([]domain.CompletedPrayer, error)
+}
+
+type completedPrayerRepository struct {
+	repo *DynamoDBRepository[domain.CompletedPrayer]
+}
+
+func NewCompletedPrayerRepository(client DDBClient, table string, timeout int) CompletedPrayerRepository {
+	return &completedPrayerRepository{
+		repo: NewDynamoDBRepository[domain.CompletedPrayer](client, table, "ID", timeout),
+	}
+}
+
+func (r *completedPrayerRepository) Save(ctx context.Context, prayer *domain.CompletedPrayer) error {
+	return r.repo.Save(ctx, prayer)
+}
+
+func (r *completedPrayerRepository) GetAll(ctx context.Context) ([]domain.CompletedPrayer, error) {
+	return r.repo.GetAll(ctx)
+}
+
+type PendingPrayerRepository interface {
+	Get(ctx context.Context, id string) (*domain.PendingPrayer, error)
+	Create(ctx context.Context, prayer *domain.PendingPrayer) error
+	Delete(ctx context.Context, id string) error
+	GetAll(ctx context.Context) ([]domain.PendingPrayer, error)
+}
+
+type pendingPrayerRepository struct {
+	repo    *DynamoDBRepository[domain.PendingPrayer]
+	members MemberRepository
+}
+
+// NewPendingPrayerRepository returns a PendingPrayerRepository whose Get and GetAll refresh requestor names from
+// members.
+func NewPendingPrayerRepository(
+	client DDBClient,
+	members MemberRepository,
+	table string,
+	timeout int,
+) PendingPrayerRepository {
+	return &pendingPrayerRepository{
+		repo: NewVersionedDynamoDBRepository[domain.PendingPrayer](
+			client, table, "ID", PendingPrayerSchema, timeout,
+		),
+		members: members,
+	}
+}
+
+func (r *pendingPrayerRepository) Get(ctx context.Context, id string) (*domain.PendingPrayer, error) {
+	pryr, err := r.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = hydrateRefs(ctx, r.members, &pryr.Requestor); err != nil {
+		return nil, err
+	}
+	return pryr, nil
+}
+
+// Create saves prayer. It returns ErrItemExists rather than overwrite a pending prayer with the same ID.
+func (r *pendingPrayerRepository) Create(ctx context.Context, prayer *domain.PendingPrayer) error {
+	return r.repo.Create(ctx, prayer)
+}
+
+func (r *pendingPrayerRepository) Delete(ctx context.Context, id string) error {
+	return r.repo.Delete(ctx, id)
+}
+
+func (r *pendingPrayerRepository) GetAll(ctx context.Context) ([]domain.PendingPrayer, error) {
+	prayers, err := r.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]*domain.MemberRef, 0, len(prayers))
+	for i := range prayers {
+		refs = append(refs, &prayers[i].Requestor)
+	}
+	if err = hydrateRefs(ctx, r.members, refs...); err != nil {
+		return nil, err
+	}
+	return prayers, nil
 }
@@ -0,0 +1,93 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/repository"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	repomocks "github.com/4JesusApps/prayertexter/internal/mocks/repository"
+)
+
+// legacyMember is the part of a member record that prayers kept a copy of before schema version 1.
+type legacyMember struct {
+	Administrator     bool
+	Name              string
+	Phone             string
+	WeeklyPrayerLimit int
+}
+
+// legacyPrayer is a prayer as it was stored before schema version 1.
+type legacyPrayer struct {
+	Intercessor      legacyMember
+	IntercessorPhone string
+	Request          string
+	Requestor        legacyMember
+}
+
+type PrayerRepoSuite struct {
+	suite.Suite
+	client  *repomocks.MockDDBClient
+	members *repomocks.MockMemberRepository
+	prayers repository.PrayerRepository
+	ctx     context.Context
+}
+
+func (s *PrayerRepoSuite) SetupTest() {
+	s.client = repomocks.NewMockDDBClient(s.T())
+	s.members = repomocks.NewMockMemberRepository(s.T())
+	s.prayers = repository.NewPrayerRepository(s.client, s.members, "ActivePrayer", "QueuedPrayer", 60)
+	s.ctx = context.Background()
+}
+
+func (s *PrayerRepoSuite) scan(table string, items ...any) {
+	avs := make([]map[string]types.AttributeValue, 0, len(items))
+	for _, item := range items {
+		av, err := attributevalue.MarshalMap(item)
+		s.Require().NoError(err)
+		avs = append(avs, av)
+	}
+	s.client.EXPECT().
+		Scan(mock.Anything, mock.MatchedBy(func(in *dynamodb.ScanInput) bool {
+			return *in.TableName == table
+		})).
+		Return(&dynamodb.ScanOutput{Items: avs}, nil).
+		Once()
+}
+
+func (s *PrayerRepoSuite) TestGetAll_HydratesNames() {
+	s.scan("ActivePrayer",
+		legacyPrayer{
+			Intercessor:      legacyMember{Phone: "+11111111111", Name: "Old Name", Administrator: true},
+			IntercessorPhone: "+11111111111",
+			Request:          "first",
+			Requestor:        legacyMember{Phone: "+12222222222", Name: domain.AnonymousName},
+		},
+		legacyPrayer{
+			Intercessor:      legacyMember{Phone: "+11111111111", Name: "Old Name"},
+			IntercessorPhone: "+11111111111",
+			Request:          "second",
+			Requestor:        legacyMember{Phone: "+13333333333", Name: "Gone"},
+		},
+	)
+	s.members.EXPECT().Get(s.ctx, "+11111111111").Return(&domain.Member{Phone: "+11111111111", Name: "New"}, nil).Once()
+	s.members.EXPECT().Get(s.ctx, "+13333333333").Return(&domain.Member{}, nil).Once()
+
+	prayers, err := s.prayers.GetAll(s.ctx, false)
+	s.Require().NoError(err)
+	s.Require().Len(prayers, 2)
+	s.Equal(domain.MemberRef{Phone: "+11111111111", Name: "New"}, prayers[0].Intercessor)
+	s.Equal(domain.MemberRef{Phone: "+12222222222", Name: domain.AnonymousName}, prayers[0].Requestor)
+	s.Equal(domain.MemberRef{Phone: "+11111111111", Name: "New"}, prayers[1].Intercessor)
+	s.Equal(domain.MemberRef{Phone: "+13333333333", Name: "Gone"}, prayers[1].Requestor)
+}
+
+func TestPrayerRepoSuite(t *testing.T) {
+	suite.Run(t, new(PrayerRepoSuite))
+}
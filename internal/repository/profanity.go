@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/4JesusApps/prayertexter/internal/domain"
+)
+
+const profanityListsKeyValue = "ProfanityLists"
+
+type ProfanityListsRepository interface {
+	Get(ctx context.Context) (*domain.ProfanityLists, error)
+	Save(ctx context.Context, lists *domain.ProfanityLists) error
+}
+
+type profanityListsRepository struct {
+	repo *DynamoDBRepository[domain.ProfanityLists]
+}
+
+func NewProfanityListsRepository(client DDBClient, table string, timeout int) ProfanityListsRepository {
+	return &profanityListsRepository{
+		repo: NewDynamoDBRepository[domain.ProfanityLists](client, table, phonesKeyField, timeout),
+	}
+}
+
+func (r *profanityListsRepository) Get(ctx context.Context) (*domain.ProfanityLists, error) {
+	return r.repo.Get(ctx, profanityListsKeyValue)
+}
+
+func (r *profanityListsRepository) Save(ctx context.Context, lists *domain.ProfanityLists) error {
+	lists.Key = profanityListsKeyValue
+	return r.repo.Save(ctx, lists)
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"strconv"
+
+	"github.com/4JesusApps/prayertexter/internal/apperr"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// SchemaVersionField is the attribute that records the schema version an item was saved with. Items saved before
+// versioning do not have it and are version 0.
+const SchemaVersionField = "SchemaVersion"
+
+// Upgrade changes an item in place from the schema version before it to its own. Upgrades work on the stored
+// attributes rather than on a domain type, so that they can still read fields the domain type no longer has.
+type Upgrade func(item map[string]types.AttributeValue) error
+
+// Schema lists the upgrades for the items of one table in order. Upgrade i takes an item from version i to version
+// i+1, so the latest version is the number of upgrades. Upgrades are only ever appended.
+type Schema []Upgrade
+
+// Version returns the latest schema version, which every item is saved with.
+func (s Schema) Version() int {
+	return len(s)
+}
+
+// Upgrade applies the upgrades that item has not had yet and sets its version to the latest. It reports whether there
+// were any to apply.
+func (s Schema) Upgrade(item map[string]types.AttributeValue) (bool, error) {
+	if len(item) == 0 {
+		return false, nil
+	}
+
+	version, err := ItemVersion(item)
+	if err != nil {
+		return false, err
+	}
+	// Items saved by a newer version of prayertexter are left as they are.
+	if version >= s.Version() {
+		return false, nil
+	}
+
+	for _, upgrade := range s[version:] {
+		if err = upgrade(item); err != nil {
+			return false, err
+		}
+	}
+	setItemVersion(item, s.Version())
+	return true, nil
+}
+
+// ItemVersion returns the schema version that item was saved with.
+func ItemVersion(item map[string]types.AttributeValue) (int, error) {
+	attr, ok := item[SchemaVersionField]
+	if !ok {
+		return 0, nil
+	}
+	num, ok := attr.(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, apperr.WrapError(ErrSchemaVersion, "not a number")
+	}
+	version, err := strconv.Atoi(num.Value)
+	if err != nil {
+		return 0, apperr.WrapError(ErrSchemaVersion, num.Value)
+	}
+	return version, nil
+}
+
+func setItemVersion(item map[string]types.AttributeValue, version int) {
+	item[SchemaVersionField] = &types.AttributeValueMemberN{Value: strconv.Itoa(version)}
+}
+
+// MemberSchema is the schema of the members table.
+var MemberSchema = Schema{
+	// Version 1 replaced the Administrator flag with roles. Members who had it become owners unless they already have
+	// a role.
+	func(item map[string]types.AttributeValue) error {
+		admin, _ := item["Administrator"].(*types.AttributeValueMemberBOOL)
+		delete(item, "Administrator")
+		if admin == nil || !admin.Value {
+			return nil
+		}
+		if role, _ := item["Role"].(*types.AttributeValueMemberS); role == nil || role.Value == "" {
+			item["Role"] = &types.AttributeValueMemberS{Value: string(domain.RoleOwner)}
+		}
+		return nil
+	},
+}
+
+// PrayerSchema is the schema of the active and queued prayer tables.
+var PrayerSchema = Schema{
+	// Version 1 stores the intercessor and requestor as MemberRefs instead of full copies of their member records.
+	func(item map[string]types.AttributeValue) error {
+		trimMemberRef(item, "Intercessor")
+		trimMemberRef(item, "Requestor")
+		return nil
+	},
+}
+
+// PendingPrayerSchema is the schema of the pending prayer table.
+var PendingPrayerSchema = Schema{
+	// Version 1 stores the requestor as a MemberRef instead of a full copy of their member record.
+	func(item map[string]types.AttributeValue) error {
+		trimMemberRef(item, "Requestor")
+		return nil
+	},
+}
+
+// trimMemberRef removes every attribute but those of a MemberRef from the member stored in field of item.
+func trimMemberRef(item map[string]types.AttributeValue, field string) {
+	mem, ok := item[field].(*types.AttributeValueMemberM)
+	if !ok {
+		return
+	}
+	ref := make(map[string]types.AttributeValue)
+	for _, name := range []string{"Name", "Phone"} {
+		if attr, found := mem.Value[name]; found {
+			ref[name] = attr
+		}
+	}
+	item[field] = &types.AttributeValueMemberM{Value: ref}
+}
@@ -0,0 +1,121 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/repository"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	repomocks "github.com/4JesusApps/prayertexter/internal/mocks/repository"
+)
+
+type SchemaSuite struct {
+	suite.Suite
+	client *repomocks.MockDDBClient
+	ctx    context.Context
+}
+
+func (s *SchemaSuite) SetupTest() {
+	s.client = repomocks.NewMockDDBClient(s.T())
+	s.ctx = context.Background()
+}
+
+func (s *SchemaSuite) item(v any) map[string]types.AttributeValue {
+	av, err := attributevalue.MarshalMap(v)
+	s.Require().NoError(err)
+	return av
+}
+
+func (s *SchemaSuite) TestUpgrade_MemberRole() {
+	tests := []struct {
+		name   string
+		legacy map[string]any
+		want   domain.Role
+	}{
+		{"administrator", map[string]any{"Phone": "+11111111111", "Administrator": true}, domain.RoleOwner},
+		{
+			"administrator with role",
+			map[string]any{"Administrator": true, "Role": "coordinator"},
+			domain.RoleCoordinator,
+		},
+		{"member", map[string]any{"Phone": "+11111111111", "Administrator": false}, domain.RoleNone},
+	}
+	for _, tt := range tests {
+		item := s.item(tt.legacy)
+		upgraded, err := repository.MemberSchema.Upgrade(item)
+		s.Require().NoError(err, tt.name)
+		s.True(upgraded, tt.name)
+		s.NotContains(item, "Administrator", tt.name)
+
+		var mem domain.Member
+		s.Require().NoError(attributevalue.UnmarshalMap(item, &mem))
+		s.Equal(tt.want, mem.Role, tt.name)
+		version, err := repository.ItemVersion(item)
+		s.Require().NoError(err)
+		s.Equal(repository.MemberSchema.Version(), version, tt.name)
+	}
+}
+
+func (s *SchemaSuite) TestUpgrade_CurrentAndNewerItemsUnchanged() {
+	for _, version := range []string{"1", "2"} {
+		item := s.item(map[string]any{"Administrator": true})
+		item[repository.SchemaVersionField] = &types.AttributeValueMemberN{Value: version}
+
+		upgraded, err := repository.MemberSchema.Upgrade(item)
+		s.Require().NoError(err)
+		s.False(upgraded)
+		s.Contains(item, "Administrator")
+	}
+}
+
+func (s *SchemaSuite) TestUpgrade_InvalidVersion() {
+	item := s.item(map[string]any{repository.SchemaVersionField: "one"})
+	_, err := repository.MemberSchema.Upgrade(item)
+	s.ErrorIs(err, repository.ErrSchemaVersion)
+}
+
+func (s *SchemaSuite) TestUpgrade_PrayerMemberRefs() {
+	item := s.item(map[string]any{
+		"IntercessorPhone": "+11111111111",
+		"Intercessor":      map[string]any{"Phone": "+11111111111", "Name": "Bob", "WeeklyPrayerLimit": 5},
+		"Requestor":        map[string]any{"Phone": "+12222222222", "Name": "Jane", "Administrator": true},
+	})
+
+	_, err := repository.PrayerSchema.Upgrade(item)
+	s.Require().NoError(err)
+
+	var stored map[string]any
+	s.Require().NoError(attributevalue.UnmarshalMap(item, &stored))
+	s.Equal(map[string]any{"Name": "Bob", "Phone": "+11111111111"}, stored["Intercessor"])
+	s.Equal(map[string]any{"Name": "Jane", "Phone": "+12222222222"}, stored["Requestor"])
+}
+
+func (s *SchemaSuite) TestRepository_UpgradesOnReadAndVersionsOnSave() {
+	repo := repository.NewMemberRepository(s.client, "Member", 60)
+	legacy := s.item(map[string]any{"Phone": "+11111111111", "Administrator": true})
+	s.client.EXPECT().
+		GetItem(mock.Anything, mock.Anything).
+		Return(&dynamodb.GetItemOutput{Item: legacy}, nil)
+	s.client.EXPECT().
+		PutItem(mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+			version, err := repository.ItemVersion(in.Item)
+			_, legacy := in.Item["Administrator"]
+			return err == nil && version == repository.MemberSchema.Version() && !legacy
+		})).
+		Return(&dynamodb.PutItemOutput{}, nil)
+
+	mem, err := repo.Get(s.ctx, "+11111111111")
+	s.Require().NoError(err)
+	s.Equal(domain.RoleOwner, mem.Role)
+	s.Require().NoError(repo.Save(s.ctx, mem))
+}
+
+func TestSchemaSuite(t *testing.T) {
+	suite.Run(t, new(SchemaSuite))
+}
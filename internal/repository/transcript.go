@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/4JesusApps/prayertexter/internal/domain"
+)
+
+type TranscriptRepository interface {
+	Save(ctx context.Context, msg *domain.TranscriptMessage) error
+	GetThread(ctx context.Context, phone string) ([]domain.TranscriptMessage, error)
+	DeleteThread(ctx context.Context, phone string) error
+}
+
+type transcriptRepository struct {
+	repo *DynamoDBRepository[domain.TranscriptMessage]
+}
+
+func NewTranscriptRepository(client DDBClient, table string, timeout int) TranscriptRepository {
+	return &transcriptRepository{
+		repo: NewDynamoDBRepository[domain.TranscriptMessage](client, table, "Phone", timeout),
+	}
+}
+
+func (r *transcriptRepository) Save(ctx context.Context, msg *domain.TranscriptMessage) error {
+	return r.repo.Save(ctx, msg)
+}
+
+// GetThread returns every recorded message to and from phone, oldest first.
+func (r *transcriptRepository) GetThread(ctx context.Context, phone string) ([]domain.TranscriptMessage, error) {
+	return r.repo.Query(ctx, phone)
+}
+
+// DeleteThread deletes every recorded message to and from phone.
+func (r *transcriptRepository) DeleteThread(ctx context.Context, phone string) error {
+	thread, err := r.repo.Query(ctx, phone)
+	if err != nil {
+		return err
+	}
+	for _, msg := range thread {
+		if err = r.repo.DeleteSorted(ctx, phone, "Timestamp", msg.Timestamp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,79 +3,983 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"regexp"
 	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
+	"github.com/4JesusApps/prayertexter/internal/apperr"
+	"github.com/4JesusApps/prayertexter/internal/config"
 	"github.com/4JesusApps/prayertexter/internal/domain"
 	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/4JesusApps/prayertexter/internal/phone"
 	"github.com/4JesusApps/prayertexter/internal/repository"
 )
 
+const (
+	CmdBlock   = "#block"
+	CmdUnblock = "#unblock"
+	CmdStats   = "#stats"
+	CmdQueue   = "#queue"
+	CmdLookup  = "#lookup"
+	CmdPromote = "#promote"
+	CmdDemote  = "#demote"
+	CmdRemove  = "#remove"
+	CmdApprove = "#approve"
+	CmdEdit    = "#edit"
+	CmdReject  = "#reject"
+)
+
+const (
+	maxQueueListed   = 10
+	queueRequestSize = 80
+	blockDay         = 24 * time.Hour
+	blockWeek        = 7 * blockDay
+)
+
 type AdminService struct {
 	members   repository.MemberRepository
 	blocked   repository.BlockedPhonesRepository
+	prayers   repository.PrayerRepository
+	audit     repository.AuditRepository
+	consents  repository.ConsentRecordRepository
 	sender    messaging.MessageSender
 	memberSvc *MemberService
+	prayerSvc *PrayerService
+	cfg       config.Config
 }
 
 func NewAdminService(
 	members repository.MemberRepository,
 	blocked repository.BlockedPhonesRepository,
+	prayers repository.PrayerRepository,
+	audit repository.AuditRepository,
+	consents repository.ConsentRecordRepository,
 	sender messaging.MessageSender,
 	memberSvc *MemberService,
+	prayerSvc *PrayerService,
+	cfg config.Config,
 ) *AdminService {
 	return &AdminService{
 		members:   members,
 		blocked:   blocked,
+		prayers:   prayers,
+		audit:     audit,
+		consents:  consents,
 		sender:    sender,
 		memberSvc: memberSvc,
+		prayerSvc: prayerSvc,
+		cfg:       cfg,
+	}
+}
+
+// AdminCommand returns the admin command contained in body, or an empty string when body does not contain one.
+// Commands may appear anywhere in the message. Only known commands are matched so that an ordinary prayer request
+// containing a hashtag is not treated as a command.
+func AdminCommand(body string) string {
+	commands := []string{
+		CmdBlock, CmdUnblock, CmdStats, CmdQueue, CmdLookup, CmdPromote, CmdDemote, CmdRemove, CmdApprove, CmdEdit,
+		CmdReject,
+	}
+	for _, word := range strings.Fields(strings.ToLower(body)) {
+		if slices.Contains(commands, word) {
+			return word
+		}
+	}
+	return ""
+}
+
+// HandleCommand runs the admin command in msg on behalf of mem.
+func (s *AdminService) HandleCommand(
+	ctx context.Context,
+	msg domain.TextMessage,
+	mem domain.Member,
+	blockedPhones *domain.BlockedPhones,
+) error {
+	switch AdminCommand(msg.Body) {
+	case CmdBlock:
+		return s.BlockUser(ctx, msg, mem, blockedPhones)
+	case CmdUnblock:
+		return s.UnblockUser(ctx, msg, mem, blockedPhones)
+	case CmdStats:
+		return s.Stats(ctx, mem)
+	case CmdQueue:
+		return s.Queue(ctx, mem)
+	case CmdLookup:
+		return s.Lookup(ctx, msg, mem)
+	case CmdPromote:
+		return s.Promote(ctx, msg, mem)
+	case CmdDemote:
+		return s.Demote(ctx, msg, mem)
+	case CmdRemove:
+		return s.RemoveMember(ctx, msg, mem)
+	case CmdApprove, CmdEdit, CmdReject:
+		return s.Moderate(ctx, msg, mem)
+	default:
+		if ok, err := s.authorize(ctx, mem, domain.PermView); !ok {
+			return err
+		}
+		return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgUnknownCommand)
 	}
 }
 
+// BlockUser adds the phone in msg to the block list and removes its member. Anything after the phone is recorded as the
+// reason for the block, and when the first word after the phone is a duration such as 12h, 7d or 2w the block expires
+// after that long. For example: #block 123-456-7890 7d spamming requests.
 func (s *AdminService) BlockUser(ctx context.Context, msg domain.TextMessage, mem domain.Member, blockedPhones *domain.BlockedPhones) error {
-	if !mem.Administrator {
-		return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgUnauthorized)
+	if ok, err := s.authorize(ctx, mem, domain.PermBlock); !ok {
+		return err
 	}
 
-	phone, err := extractPhone(msg.Body)
-	if errors.Is(err, ErrInvalidPhone) {
-		return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgInvalidPhone)
+	target, ok, err := s.targetPhone(ctx, msg, mem)
+	if !ok {
+		return err
 	}
 
-	phone = "+1" + phone
-	if slices.Contains(blockedPhones.Phones, phone) {
+	duration, reason := parseBlockDetails(msg.Body, s.cfg.PhoneRegion)
+	err = s.block(ctx, blockedPhones, mem.Phone, target, duration, reason)
+	if errors.Is(err, ErrAlreadyBlocked) {
 		return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgUserAlreadyBlocked)
+	} else if errors.Is(err, ErrLastOwner) {
+		return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgLastOwner)
+	} else if err != nil {
+		return err
 	}
 
-	blockedPhones.AddPhone(phone)
-	if err = s.blocked.Save(ctx, blockedPhones); err != nil {
+	return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgSuccessfullyBlocked)
+}
+
+// Block adds phone to the block list on behalf of adminPhone and removes its member. A block with a duration of 0
+// never expires.
+func (s *AdminService) Block(ctx context.Context, adminPhone, phn string, duration time.Duration, reason string) error {
+	target, err := phone.Normalize(phn, s.cfg.PhoneRegion)
+	if err != nil {
+		return apperr.WrapError(ErrInvalidPhone, phn)
+	}
+
+	blockedPhones, err := s.blocked.Get(ctx)
+	if err != nil {
 		return err
 	}
 
-	blockedUser, err := s.members.Get(ctx, phone)
+	return s.block(ctx, blockedPhones, adminPhone, target, duration, reason)
+}
+
+func (s *AdminService) block(
+	ctx context.Context,
+	blockedPhones *domain.BlockedPhones,
+	adminPhone, target string,
+	duration time.Duration,
+	reason string,
+) error {
+	if slices.Contains(blockedPhones.Phones, target) {
+		return ErrAlreadyBlocked
+	}
+
+	now := time.Now()
+	entry := domain.BlockEntry{
+		Phone:     target,
+		BlockedBy: adminPhone,
+		BlockedAt: now.Format(time.RFC3339),
+		Reason:    reason,
+	}
+	if duration > 0 {
+		entry.ExpiresAt = now.Add(duration).Format(time.RFC3339)
+	}
+
+	detail := reason
+	if entry.ExpiresAt != "" {
+		detail = strings.TrimSpace(reason + " (expires " + entry.ExpiresAt + ")")
+	}
+
+	if err := s.applyBlock(ctx, blockedPhones, entry); err != nil {
+		return s.RecordFailure(ctx, adminPhone, domain.AuditActionBlock, target, detail, err)
+	}
+	return s.RecordAction(ctx, adminPhone, domain.AuditActionBlock, target, detail)
+}
+
+// applyBlock saves entry to the block list, removes the blocked member and tells them they were blocked. The only
+// owner cannot be blocked.
+func (s *AdminService) applyBlock(
+	ctx context.Context,
+	blockedPhones *domain.BlockedPhones,
+	entry domain.BlockEntry,
+) error {
+	blockedUser, err := s.members.Get(ctx, entry.Phone)
 	if err != nil {
 		return err
 	}
+	if err = s.checkLastOwner(ctx, *blockedUser); err != nil {
+		return err
+	}
+
+	blockedPhones.Block(entry)
+	if err = s.blocked.Save(ctx, blockedPhones); err != nil {
+		return err
+	}
 
 	if err = s.memberSvc.Delete(ctx, *blockedUser); err != nil {
 		return err
 	}
 
-	if err = s.sender.SendMessage(ctx, phone, messaging.MsgBlockedNotification+messaging.MsgHelp); err != nil {
+	return s.sender.SendMessage(ctx, entry.Phone, messaging.MsgBlockedNotification+messaging.MsgHelp)
+}
+
+func (s *AdminService) UnblockUser(
+	ctx context.Context,
+	msg domain.TextMessage,
+	mem domain.Member,
+	blockedPhones *domain.BlockedPhones,
+) error {
+	if ok, err := s.authorize(ctx, mem, domain.PermBlock); !ok {
 		return err
 	}
 
-	return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgSuccessfullyBlocked)
+	target, ok, err := s.targetPhone(ctx, msg, mem)
+	if !ok {
+		return err
+	}
+
+	err = s.unblock(ctx, blockedPhones, mem.Phone, target)
+	if errors.Is(err, ErrNotBlocked) {
+		return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgUserNotBlocked)
+	} else if err != nil {
+		return err
+	}
+
+	return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgSuccessfullyUnblocked)
 }
 
-var phoneRE = regexp.MustCompile(`\(?\b(\d{3})\)?[\s\-]?(\d{3})[\s\-]?(\d{4})\b`)
+// Unblock removes phone from the block list on behalf of adminPhone.
+func (s *AdminService) Unblock(ctx context.Context, adminPhone, phn string) error {
+	target, err := phone.Normalize(phn, s.cfg.PhoneRegion)
+	if err != nil {
+		return apperr.WrapError(ErrInvalidPhone, phn)
+	}
+
+	blockedPhones, err := s.blocked.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.unblock(ctx, blockedPhones, adminPhone, target)
+}
+
+func (s *AdminService) unblock(
+	ctx context.Context,
+	blockedPhones *domain.BlockedPhones,
+	adminPhone, target string,
+) error {
+	if !slices.Contains(blockedPhones.Phones, target) {
+		return ErrNotBlocked
+	}
+
+	blockedPhones.RemovePhone(target)
+	if err := s.blocked.Save(ctx, blockedPhones); err != nil {
+		return s.RecordFailure(ctx, adminPhone, domain.AuditActionUnblock, target, "", err)
+	}
+
+	return s.RecordAction(ctx, adminPhone, domain.AuditActionUnblock, target, "")
+}
+
+// Blocks returns every entry on the block list. Phones that were blocked before block details were recorded are
+// returned with only their phone set.
+func (s *AdminService) Blocks(ctx context.Context) ([]domain.BlockEntry, error) {
+	blockedPhones, err := s.blocked.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]domain.BlockEntry, 0, len(blockedPhones.Phones))
+	for _, phn := range blockedPhones.Phones {
+		entry, ok := blockedPhones.Entry(phn)
+		if !ok {
+			entry = domain.BlockEntry{Phone: phn}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// ExpireBlocks removes blocks whose expiry has passed. It is run by the statecontroller.
+func (s *AdminService) ExpireBlocks(ctx context.Context) error {
+	blockedPhones, err := s.blocked.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	expired := blockedPhones.Expired(time.Now())
+	if len(expired) == 0 {
+		return nil
+	}
+
+	for _, target := range expired {
+		blockedPhones.RemovePhone(target)
+	}
+	if err = s.blocked.Save(ctx, blockedPhones); err != nil {
+		return err
+	}
+
+	for _, target := range expired {
+		if err = s.RecordAction(ctx, domain.AuditSystemActor, domain.AuditActionExpireBlock, target, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stats replies with the number of members, intercessors, active prayers and queued prayers.
+func (s *AdminService) Stats(ctx context.Context, mem domain.Member) error {
+	if ok, err := s.authorize(ctx, mem, domain.PermView); !ok {
+		return err
+	}
+
+	members, err := s.members.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	active, err := s.prayers.GetAll(ctx, false)
+	if err != nil {
+		return err
+	}
+	queued, err := s.prayers.GetAll(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	var memberCount, intercessorCount int
+	for _, m := range members {
+		if m.SetupStatus != domain.MemberSetupComplete {
+			continue
+		}
+		memberCount++
+		if m.Intercessor {
+			intercessorCount++
+		}
+	}
+
+	if err = s.RecordAction(ctx, mem.Phone, domain.AuditActionStats, "", ""); err != nil {
+		return err
+	}
+
+	stats, err := messaging.Render(messaging.StatsTmpl, struct {
+		Members, Intercessors, Active, Queued int
+	}{memberCount, intercessorCount, len(active), len(queued)})
+	if err != nil {
+		return err
+	}
+
+	return s.sender.SendMessage(ctx, mem.Phone, stats)
+}
+
+// Queue replies with the queued prayers, listing at most maxQueueListed of them.
+func (s *AdminService) Queue(ctx context.Context, mem domain.Member) error {
+	if ok, err := s.authorize(ctx, mem, domain.PermView); !ok {
+		return err
+	}
+
+	queued, err := s.prayers.GetAll(ctx, true)
+	if err != nil {
+		return err
+	}
+	if err = s.RecordAction(ctx, mem.Phone, domain.AuditActionQueue, "", ""); err != nil {
+		return err
+	}
+	if len(queued) == 0 {
+		return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgQueueEmpty)
+	}
+
+	lines := make([]string, 0, min(len(queued), maxQueueListed)+1)
+	for i, pryr := range queued[:min(len(queued), maxQueueListed)] {
+		var line string
+		line, err = messaging.Render(messaging.QueueItemTmpl, struct {
+			Number               int
+			Name, Phone, Request string
+		}{i + 1, pryr.Requestor.Name, pryr.Requestor.Phone, messaging.Truncate(pryr.Request, queueRequestSize)})
+		if err != nil {
+			return err
+		}
+		lines = append(lines, line)
+	}
+	if len(queued) > maxQueueListed {
+		lines = append(lines, fmt.Sprintf("...and %d more", len(queued)-maxQueueListed))
+	}
+
+	return s.sender.SendMessage(ctx, mem.Phone, strings.Join(lines, "\n"))
+}
+
+// Lookup replies with the profile of the member whose phone is in msg.
+func (s *AdminService) Lookup(ctx context.Context, msg domain.TextMessage, mem domain.Member) error {
+	if ok, err := s.authorize(ctx, mem, domain.PermView); !ok {
+		return err
+	}
+
+	target, ok, err := s.targetMember(ctx, msg, mem)
+	if !ok {
+		return err
+	}
+
+	if err = s.RecordAction(ctx, mem.Phone, domain.AuditActionLookup, target.Phone, ""); err != nil {
+		return err
+	}
+
+	profile, err := messaging.Render(messaging.MemberProfileTmpl, target)
+	if err != nil {
+		return err
+	}
+
+	return s.sender.SendMessage(ctx, mem.Phone, profile)
+}
+
+// Promote gives the member whose phone is in msg the role named after the phone, or the moderator role when no role
+// is named. For example: #promote 123-456-7890 coordinator.
+func (s *AdminService) Promote(ctx context.Context, msg domain.TextMessage, mem domain.Member) error {
+	if ok, err := s.authorize(ctx, mem, domain.PermManageAdmins); !ok {
+		return err
+	}
+
+	role, ok := parsePromoteRole(msg.Body, s.cfg.PhoneRegion)
+	if !ok {
+		return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgInvalidRole)
+	}
+
+	if ok, err := s.setRole(ctx, msg, mem, role); !ok {
+		return err
+	}
+
+	reply, err := messaging.Render(messaging.RoleGrantedTmpl, struct{ Role domain.Role }{role})
+	if err != nil {
+		return err
+	}
+	return s.sender.SendMessage(ctx, mem.Phone, reply)
+}
+
+// Demote removes the role of the member whose phone is in msg, so they are no longer an admin.
+func (s *AdminService) Demote(ctx context.Context, msg domain.TextMessage, mem domain.Member) error {
+	if ok, err := s.authorize(ctx, mem, domain.PermManageAdmins); !ok {
+		return err
+	}
+
+	if ok, err := s.setRole(ctx, msg, mem, domain.RoleNone); !ok {
+		return err
+	}
+	return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgSuccessfullyDemoted)
+}
+
+// setRole gives the member whose phone is in msg role. Admins may not change their own role and the only owner may not
+// lose theirs, so that nobody is left to manage admins. When the role is not changed the admin is told why and ok is
+// false.
+func (s *AdminService) setRole(
+	ctx context.Context,
+	msg domain.TextMessage,
+	mem domain.Member,
+	role domain.Role,
+) (bool, error) {
+	target, ok, err := s.targetMember(ctx, msg, mem)
+	if !ok {
+		return false, err
+	}
+	if target.Phone == mem.Phone {
+		return false, s.sender.SendMessage(ctx, mem.Phone, messaging.MsgCannotChangeOwnRole)
+	}
+	if role != domain.RoleOwner {
+		err = s.checkLastOwner(ctx, *target)
+		if errors.Is(err, ErrLastOwner) {
+			return false, s.sender.SendMessage(ctx, mem.Phone, messaging.MsgLastOwner)
+		} else if err != nil {
+			return false, err
+		}
+	}
 
-func extractPhone(msg string) (string, error) {
-	matchNum := 4
-	matches := phoneRE.FindStringSubmatch(msg)
-	if len(matches) != matchNum {
-		return "", ErrInvalidPhone
+	target.Role = role
+	if err = s.members.Save(ctx, target); err != nil {
+		return false, err
 	}
 
-	return matches[1] + matches[2] + matches[3], nil
+	action, detail := domain.AuditActionDemote, ""
+	if role.IsAdmin() {
+		action, detail = domain.AuditActionPromote, string(role)
+	}
+	if err = s.RecordAction(ctx, mem.Phone, action, target.Phone, detail); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RemoveMember removes the member whose phone is in msg without blocking them, so they can sign up again later. Admins
+// may not remove themselves or the only owner.
+func (s *AdminService) RemoveMember(ctx context.Context, msg domain.TextMessage, mem domain.Member) error {
+	if ok, err := s.authorize(ctx, mem, domain.PermBlock); !ok {
+		return err
+	}
+
+	target, ok, err := s.targetMember(ctx, msg, mem)
+	if !ok {
+		return err
+	}
+	if target.Phone == mem.Phone {
+		return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgCannotRemoveSelf)
+	}
+
+	err = s.remove(ctx, mem.Phone, *target)
+	if errors.Is(err, ErrLastOwner) {
+		return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgLastOwner)
+	} else if err != nil {
+		return err
+	}
+
+	return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgSuccessfullyRemoved)
+}
+
+// Remove removes the member with phone on behalf of adminPhone without blocking them. It returns ErrLastOwner when
+// the member is the only owner.
+func (s *AdminService) Remove(ctx context.Context, adminPhone, phn string) error {
+	target, err := s.Member(ctx, phn)
+	if err != nil {
+		return err
+	}
+
+	return s.remove(ctx, adminPhone, *target)
+}
+
+func (s *AdminService) remove(ctx context.Context, adminPhone string, target domain.Member) error {
+	if err := s.checkLastOwner(ctx, target); err != nil {
+		return err
+	}
+
+	if err := s.memberSvc.Delete(ctx, target); err != nil {
+		return s.RecordFailure(ctx, adminPhone, domain.AuditActionRemove, target.Phone, "", err)
+	}
+
+	return s.RecordAction(ctx, adminPhone, domain.AuditActionRemove, target.Phone, "")
+}
+
+// Moderate approves, edits or rejects the prayer request held for review whose ID follows the command in msg. An edit
+// replaces the request with the text after the ID, and a rejection passes the text after the ID on to the requestor
+// as the reason. For example: #approve 1a2b3c, #edit 1a2b3c Please pray for my family or #reject 1a2b3c.
+func (s *AdminService) Moderate(ctx context.Context, msg domain.TextMessage, mem domain.Member) error {
+	if ok, err := s.authorize(ctx, mem, domain.PermModerate); !ok {
+		return err
+	}
+
+	command := AdminCommand(msg.Body)
+	id, text := commandArgs(msg.Body, command)
+	if id == "" {
+		return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgPendingNotFound)
+	}
+
+	var err error
+	var action, detail, reply string
+	switch command {
+	case CmdApprove:
+		action, reply = domain.AuditActionApprove, messaging.MsgSuccessfullyApproved
+		err = s.prayerSvc.Approve(ctx, id, "")
+	case CmdEdit:
+		if text == "" {
+			return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgEditMissingRequest)
+		}
+		action, detail, reply = domain.AuditActionApprove, "edited", messaging.MsgSuccessfullyApproved
+		err = s.prayerSvc.Approve(ctx, id, text)
+	default:
+		action, detail, reply = domain.AuditActionReject, text, messaging.MsgSuccessfullyRejected
+		err = s.prayerSvc.Reject(ctx, id, text)
+	}
+
+	if errors.Is(err, ErrPrayerNotFound) {
+		return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgPendingNotFound)
+	} else if err != nil {
+		return s.RecordFailure(ctx, mem.Phone, action, id, detail, err)
+	}
+
+	if err = s.RecordAction(ctx, mem.Phone, action, id, detail); err != nil {
+		return err
+	}
+	return s.sender.SendMessage(ctx, mem.Phone, reply)
+}
+
+// MemberUpdate holds the member fields that an admin may change. Nil fields are left unchanged.
+type MemberUpdate struct {
+	Name              *string
+	WeeklyPrayerLimit *int
+	Role              *domain.Role
+}
+
+// Members returns every member whose phone or name contains query, ignoring case, sorted by phone. An empty query
+// matches every member.
+func (s *AdminService) Members(ctx context.Context, query string) ([]domain.Member, error) {
+	members, err := s.members.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	matches := make([]domain.Member, 0, len(members))
+	for _, mem := range members {
+		if strings.Contains(mem.Phone, query) || strings.Contains(strings.ToLower(mem.Name), query) {
+			matches = append(matches, mem)
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b domain.Member) int { return strings.Compare(a.Phone, b.Phone) })
+	return matches, nil
+}
+
+// Member returns the member with phone, or ErrMemberNotFound.
+func (s *AdminService) Member(ctx context.Context, phn string) (*domain.Member, error) {
+	target, err := phone.Normalize(phn, s.cfg.PhoneRegion)
+	if err != nil {
+		return nil, apperr.WrapError(ErrInvalidPhone, phn)
+	}
+
+	mem, err := s.members.Get(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	if mem.SetupStatus == "" {
+		return nil, ErrMemberNotFound
+	}
+	return mem, nil
+}
+
+// UpdateMember applies update to the member with phone on behalf of adminPhone and returns the updated member. It
+// returns ErrLastOwner when the update takes the owner role from the only owner.
+func (s *AdminService) UpdateMember(
+	ctx context.Context,
+	adminPhone, phn string,
+	update MemberUpdate,
+) (*domain.Member, error) {
+	mem, err := s.Member(ctx, phn)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	if update.Name != nil {
+		if !isNameValid(*update.Name) {
+			return nil, apperr.WrapError(ErrInvalidMemberUpdate, "name is not valid")
+		}
+		mem.Name = *update.Name
+		changed = append(changed, "name")
+	}
+	if update.WeeklyPrayerLimit != nil {
+		if *update.WeeklyPrayerLimit < 1 {
+			return nil, apperr.WrapError(ErrInvalidMemberUpdate, "weekly prayer limit must be at least 1")
+		}
+		mem.WeeklyPrayerLimit = *update.WeeklyPrayerLimit
+		changed = append(changed, "weekly prayer limit")
+	}
+	if update.Role != nil {
+		role, ok := domain.ParseRole(string(*update.Role))
+		if !ok {
+			return nil, apperr.WrapError(ErrInvalidMemberUpdate, "role must be moderator, coordinator, owner or none")
+		}
+		if role != domain.RoleOwner {
+			if err = s.checkLastOwner(ctx, *mem); err != nil {
+				return nil, err
+			}
+		}
+		mem.Role = role
+		changed = append(changed, "role")
+	}
+	if len(changed) == 0 {
+		return mem, nil
+	}
+
+	if err = s.members.Save(ctx, mem); err != nil {
+		return nil, err
+	}
+
+	detail := "changed " + strings.Join(changed, ", ")
+	if err = s.RecordAction(ctx, adminPhone, domain.AuditActionEdit, mem.Phone, detail); err != nil {
+		return nil, err
+	}
+	return mem, nil
+}
+
+// Announce sends body on behalf of adminPhone to every phone in phones, or to every signed up member when phones is
+// empty. Phones may be written in any format that the phone package accepts. A failed send is logged and skipped so
+// that one bad number does not stop the announcement; the number of messages sent is returned.
+func (s *AdminService) Announce(ctx context.Context, adminPhone, body string, phones []string) (int, error) {
+	if strings.TrimSpace(body) == "" {
+		return 0, ErrEmptyAnnouncement
+	}
+
+	recipients, err := s.announcementRecipients(ctx, phones)
+	if errors.Is(err, ErrInvalidPhone) {
+		return 0, err
+	} else if err != nil {
+		return 0, s.RecordFailure(ctx, adminPhone, domain.AuditActionAnnounce, "", "", err)
+	}
+
+	sent := 0
+	for _, recipient := range recipients {
+		if err = s.sender.SendMessage(ctx, recipient, body); err != nil {
+			apperr.LogError(ctx, err, "failed to send announcement", "phone", recipient)
+			continue
+		}
+		sent++
+	}
+
+	detail := fmt.Sprintf("sent to %d of %d phones", sent, len(recipients))
+	if err = s.RecordAction(ctx, adminPhone, domain.AuditActionAnnounce, "", detail); err != nil {
+		return sent, err
+	}
+
+	return sent, nil
+}
+
+func (s *AdminService) announcementRecipients(ctx context.Context, phones []string) ([]string, error) {
+	if len(phones) > 0 {
+		recipients := make([]string, 0, len(phones))
+		for _, raw := range phones {
+			number, err := phone.Normalize(raw, s.cfg.PhoneRegion)
+			if err != nil {
+				return nil, apperr.WrapError(ErrInvalidPhone, raw)
+			}
+			if !slices.Contains(recipients, number) {
+				recipients = append(recipients, number)
+			}
+		}
+		return recipients, nil
+	}
+
+	members, err := s.members.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var recipients []string
+	for _, mem := range members {
+		if mem.SetupStatus == domain.MemberSetupComplete && !mem.Inactive {
+			recipients = append(recipients, mem.Phone)
+		}
+	}
+	return recipients, nil
+}
+
+// Authorize returns ErrForbidden when role does not have perm. Every privileged command and admin API endpoint is
+// checked with it.
+func Authorize(role domain.Role, perm domain.Permission) error {
+	if role.Can(perm) {
+		return nil
+	}
+	return apperr.WrapError(ErrForbidden, string(perm))
+}
+
+// authorize reports whether mem has perm. Members who do not are told so, in which case the returned error is the
+// result of sending that reply.
+func (s *AdminService) authorize(ctx context.Context, mem domain.Member, perm domain.Permission) (bool, error) {
+	if Authorize(mem.Role, perm) == nil {
+		return true, nil
+	}
+	return false, s.sender.SendMessage(ctx, mem.Phone, messaging.MsgUnauthorized)
+}
+
+// checkLastOwner returns ErrLastOwner when mem is the only owner, since removing them or taking their role would leave
+// nobody who can manage admins.
+func (s *AdminService) checkLastOwner(ctx context.Context, mem domain.Member) error {
+	if mem.Role != domain.RoleOwner {
+		return nil
+	}
+
+	members, err := s.members.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		if m.Role == domain.RoleOwner && m.Phone != mem.Phone {
+			return nil
+		}
+	}
+	return ErrLastOwner
+}
+
+// targetPhone returns the phone number that msg refers to. When msg does not contain a valid phone number the admin is
+// told so and ok is false.
+func (s *AdminService) targetPhone(
+	ctx context.Context,
+	msg domain.TextMessage,
+	mem domain.Member,
+) (string, bool, error) {
+	target, _, err := phone.Extract(msg.Body, s.cfg.PhoneRegion)
+	if errors.Is(err, phone.ErrInvalid) {
+		return "", false, s.sender.SendMessage(ctx, mem.Phone, messaging.MsgInvalidPhone)
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	return target, true, nil
+}
+
+// targetMember returns the member that msg refers to. When there is no such member the admin is told so and ok is
+// false.
+func (s *AdminService) targetMember(
+	ctx context.Context,
+	msg domain.TextMessage,
+	mem domain.Member,
+) (*domain.Member, bool, error) {
+	number, ok, err := s.targetPhone(ctx, msg, mem)
+	if !ok {
+		return nil, false, err
+	}
+
+	target, err := s.members.Get(ctx, number)
+	if err != nil {
+		return nil, false, err
+	}
+	if target.SetupStatus == "" {
+		return nil, false, s.sender.SendMessage(ctx, mem.Phone, messaging.MsgMemberNotFound)
+	}
+
+	return target, true, nil
+}
+
+// RecordAction appends a successful action to the admin audit log.
+func (s *AdminService) RecordAction(ctx context.Context, adminPhone, action, target, detail string) error {
+	return s.record(ctx, adminPhone, action, target, detail, domain.AuditResultSuccess)
+}
+
+// RecordFailure appends an action that failed with cause to the admin audit log and returns cause, joined with any
+// error from writing the entry.
+func (s *AdminService) RecordFailure(
+	ctx context.Context,
+	adminPhone, action, target, detail string,
+	cause error,
+) error {
+	if err := s.record(ctx, adminPhone, action, target, detail, "failed: "+cause.Error()); err != nil {
+		return errors.Join(cause, err)
+	}
+	return cause
+}
+
+func (s *AdminService) record(ctx context.Context, adminPhone, action, target, detail, result string) error {
+	id, err := generateID()
+	if err != nil {
+		return err
+	}
+
+	entry := domain.AuditEntry{
+		ID:         id,
+		AdminPhone: adminPhone,
+		Action:     action,
+		Target:     target,
+		Detail:     detail,
+		Result:     result,
+		Timestamp:  time.Now().Format(time.RFC3339),
+	}
+	return s.audit.Save(ctx, &entry)
+}
+
+// AuditLog returns the audit entries for actions taken by admin, against target, or both, newest first. Phones may be
+// written in any format that the phone package accepts; anything else, such as the api actor or a queued prayer ID, is
+// matched as is.
+func (s *AdminService) AuditLog(ctx context.Context, admin, target string) ([]domain.AuditEntry, error) {
+	admin, target = s.auditKey(admin), s.auditKey(target)
+
+	var entries []domain.AuditEntry
+	var err error
+	switch {
+	case admin != "":
+		entries, err = s.audit.GetByAdmin(ctx, admin)
+	case target != "":
+		entries, err = s.audit.GetByTarget(ctx, target)
+	default:
+		return nil, ErrInvalidAuditQuery
+	}
+	if err != nil {
+		return nil, apperr.WrapError(err, "failed to query audit log")
+	}
+
+	if admin != "" && target != "" {
+		entries = slices.DeleteFunc(entries, func(e domain.AuditEntry) bool { return e.Target != target })
+	}
+	slices.Reverse(entries)
+	return entries, nil
+}
+
+func (s *AdminService) auditKey(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if number, err := phone.Normalize(raw, s.cfg.PhoneRegion); err == nil {
+		return number
+	}
+	return raw
+}
+
+var blockDurationRE = regexp.MustCompile(`^(\d+)([hdw])$`)
+
+// parseBlockDetails returns the optional duration and reason that follow the phone number in a #block command.
+func parseBlockDetails(body string, region string) (time.Duration, string) {
+	_, rest, _ := phone.Extract(body, region)
+
+	var words []string
+	for _, word := range strings.Fields(rest) {
+		if strings.ToLower(word) != CmdBlock {
+			words = append(words, word)
+		}
+	}
+	if len(words) == 0 {
+		return 0, ""
+	}
+
+	duration, ok := ParseBlockDuration(words[0])
+	if !ok {
+		return 0, strings.Join(words, " ")
+	}
+	return duration, strings.Join(words[1:], " ")
+}
+
+// parsePromoteRole returns the role named after the phone number in a #promote command, or the moderator role when
+// none is named. ok is false when the word after the phone is not a role.
+func parsePromoteRole(body string, region string) (domain.Role, bool) {
+	_, rest, _ := phone.Extract(body, region)
+
+	for _, word := range strings.Fields(strings.ToLower(rest)) {
+		if word == CmdPromote {
+			continue
+		}
+		role, ok := domain.ParseRole(word)
+		if !ok || !role.IsAdmin() {
+			return domain.RoleNone, false
+		}
+		return role, true
+	}
+	return domain.RoleModerator, true
+}
+
+// commandArgs returns the first word after command in body, lowercased, and the text after that word.
+func commandArgs(body, command string) (string, string) {
+	i := strings.Index(strings.ToLower(body), command)
+	if i < 0 {
+		return "", ""
+	}
+
+	rest := strings.TrimSpace(body[i+len(command):])
+	end := strings.IndexFunc(rest, unicode.IsSpace)
+	if end < 0 {
+		return strings.ToLower(rest), ""
+	}
+	return strings.ToLower(rest[:end]), strings.TrimSpace(rest[end:])
+}
+
+// ParseBlockDuration parses a block duration written as a number of hours, days or weeks, such as 12h, 7d or 2w.
+func ParseBlockDuration(word string) (time.Duration, bool) {
+	matches := blockDurationRE.FindStringSubmatch(strings.ToLower(word))
+	if matches == nil {
+		return 0, false
+	}
+
+	amount, _ := strconv.Atoi(matches[1])
+	unit := time.Hour
+	switch matches[2] {
+	case "d":
+		unit = blockDay
+	case "w":
+		unit = blockWeek
+	}
+	return time.Duration(amount) * unit, true
 }
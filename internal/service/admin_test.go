@@ -2,12 +2,17 @@ package service_test
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/4JesusApps/prayertexter/internal/config"
 	"github.com/4JesusApps/prayertexter/internal/domain"
 	"github.com/4JesusApps/prayertexter/internal/messaging"
 	"github.com/4JesusApps/prayertexter/internal/service"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
@@ -21,8 +26,11 @@ type AdminServiceSuite struct {
 	memberSvc    *service.MemberService
 	members      *repomocks.MockMemberRepository
 	blocked      *repomocks.MockBlockedPhonesRepository
+	audit        *repomocks.MockAuditRepository
 	intercessors *repomocks.MockIntercessorPhonesRepository
 	prayers      *repomocks.MockPrayerRepository
+	consents     *repomocks.MockConsentRecordRepository
+	pending      *repomocks.MockPendingPrayerRepository
 	sender       *msgmocks.MockMessageSender
 	ctx          context.Context
 }
@@ -30,18 +38,35 @@ type AdminServiceSuite struct {
 func (s *AdminServiceSuite) SetupTest() {
 	s.members = repomocks.NewMockMemberRepository(s.T())
 	s.blocked = repomocks.NewMockBlockedPhonesRepository(s.T())
+	s.audit = repomocks.NewMockAuditRepository(s.T())
 	s.intercessors = repomocks.NewMockIntercessorPhonesRepository(s.T())
 	s.prayers = repomocks.NewMockPrayerRepository(s.T())
+	s.consents = repomocks.NewMockConsentRecordRepository(s.T())
+	s.pending = repomocks.NewMockPendingPrayerRepository(s.T())
 	s.sender = msgmocks.NewMockMessageSender(s.T())
 	s.ctx = context.Background()
-	s.memberSvc = service.NewMemberService(s.members, s.intercessors, s.prayers, s.sender, config.Config{})
-	s.svc = service.NewAdminService(s.members, s.blocked, s.sender, s.memberSvc)
+	cfg := config.Config{PhoneRegion: "US"}
+	profanity := messaging.NewProfanity(config.ProfanityConfig{}, domain.ProfanityLists{})
+	s.memberSvc = service.NewMemberService(s.members, s.intercessors, s.prayers, s.consents, s.sender, profanity, cfg)
+	prayerSvc := service.NewPrayerService(
+		s.members, s.intercessors, s.prayers, repomocks.NewMockCompletedPrayerRepository(s.T()), s.pending,
+		repomocks.NewMockRequestHistoryRepository(s.T()), s.sender, profanity, cfg,
+	)
+	s.svc = service.NewAdminService(
+		s.members, s.blocked, s.prayers, s.audit, s.consents, s.sender, s.memberSvc, prayerSvc, cfg,
+	)
+}
+
+func (s *AdminServiceSuite) expectAudit(action, target string) {
+	s.audit.EXPECT().Save(s.ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == action && e.Target == target && e.ID != "" && e.Timestamp != ""
+	})).Return(nil).Once()
 }
 
 func (s *AdminServiceSuite) TestBlockUser_NotAdmin() {
 	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgUnauthorized).Return(nil)
 
-	mem := domain.Member{Phone: "+11234567890", Administrator: false}
+	mem := domain.Member{Phone: "+11234567890"}
 	blocked := &domain.BlockedPhones{}
 	err := s.svc.BlockUser(s.ctx, domain.TextMessage{Body: "#block 777-777-7777"}, mem, blocked)
 	s.NoError(err)
@@ -50,7 +75,7 @@ func (s *AdminServiceSuite) TestBlockUser_NotAdmin() {
 func (s *AdminServiceSuite) TestBlockUser_InvalidPhone() {
 	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgInvalidPhone).Return(nil)
 
-	mem := domain.Member{Phone: "+17777777777", Administrator: true}
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
 	blocked := &domain.BlockedPhones{}
 	err := s.svc.BlockUser(s.ctx, domain.TextMessage{Body: "#block 123"}, mem, blocked)
 	s.NoError(err)
@@ -59,13 +84,14 @@ func (s *AdminServiceSuite) TestBlockUser_InvalidPhone() {
 func (s *AdminServiceSuite) TestBlockUser_AlreadyBlocked() {
 	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgUserAlreadyBlocked).Return(nil)
 
-	mem := domain.Member{Phone: "+17777777777", Administrator: true}
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
 	blocked := &domain.BlockedPhones{Phones: []string{"+11234567890"}}
 	err := s.svc.BlockUser(s.ctx, domain.TextMessage{Body: "#block 123-456-7890"}, mem, blocked)
 	s.NoError(err)
 }
 
 func (s *AdminServiceSuite) TestBlockUser_Success_NonIntercessor() {
+	s.expectAudit(domain.AuditActionBlock, "+11234567890")
 	s.blocked.EXPECT().Save(s.ctx, mock.MatchedBy(func(bp *domain.BlockedPhones) bool {
 		return len(bp.Phones) == 2 && bp.Phones[1] == "+11234567890"
 	})).Return(nil)
@@ -77,12 +103,537 @@ func (s *AdminServiceSuite) TestBlockUser_Success_NonIntercessor() {
 	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgBlockedNotification+messaging.MsgHelp).Return(nil)
 	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgSuccessfullyBlocked).Return(nil)
 
-	mem := domain.Member{Phone: "+17777777777", Administrator: true}
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
 	blocked := &domain.BlockedPhones{Phones: []string{"+12222222222"}}
 	err := s.svc.BlockUser(s.ctx, domain.TextMessage{Body: "#block 123-456-7890"}, mem, blocked)
 	s.NoError(err)
 }
 
+func (s *AdminServiceSuite) TestBlockUser_ReasonAndExpiry() {
+	s.blocked.EXPECT().Save(s.ctx, mock.MatchedBy(func(bp *domain.BlockedPhones) bool {
+		entry, ok := bp.Entry("+11234567890")
+		if !ok || entry.BlockedBy != "+17777777777" || entry.Reason != "spamming requests" {
+			return false
+		}
+		expiresAt, err := time.Parse(time.RFC3339, entry.ExpiresAt)
+		return err == nil && time.Until(expiresAt) > 6*24*time.Hour && time.Until(expiresAt) <= 7*24*time.Hour
+	})).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{Phone: "+11234567890"}, nil)
+	s.members.EXPECT().Delete(s.ctx, "+11234567890").Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgRemoveUser).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgBlockedNotification+messaging.MsgHelp).Return(nil)
+	s.audit.EXPECT().Save(s.ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionBlock && strings.HasPrefix(e.Detail, "spamming requests (expires ")
+	})).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgSuccessfullyBlocked).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	msg := domain.TextMessage{Body: "#block 123-456-7890 7d spamming requests"}
+	s.NoError(s.svc.BlockUser(s.ctx, msg, mem, &domain.BlockedPhones{}))
+}
+
+func (s *AdminServiceSuite) TestBlockUser_InternationalNumber() {
+	s.blocked.EXPECT().Save(s.ctx, mock.MatchedBy(func(bp *domain.BlockedPhones) bool {
+		entry, ok := bp.Entry("+447911123456")
+		return ok && entry.Reason == "abuse"
+	})).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+447911123456").Return(&domain.Member{Phone: "+447911123456"}, nil)
+	s.members.EXPECT().Delete(s.ctx, "+447911123456").Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+447911123456", messaging.MsgRemoveUser).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+447911123456", messaging.MsgBlockedNotification+messaging.MsgHelp).
+		Return(nil)
+	s.expectAudit(domain.AuditActionBlock, "+447911123456")
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgSuccessfullyBlocked).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	msg := domain.TextMessage{Body: "#block +44 7911 123456 abuse"}
+	s.NoError(s.svc.BlockUser(s.ctx, msg, mem, &domain.BlockedPhones{}))
+}
+
+func (s *AdminServiceSuite) TestExpireBlocks() {
+	past := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).Format(time.RFC3339)
+	blocked := &domain.BlockedPhones{}
+	blocked.Block(domain.BlockEntry{Phone: "+11111111111", ExpiresAt: past})
+	blocked.Block(domain.BlockEntry{Phone: "+12222222222", ExpiresAt: future})
+	blocked.Block(domain.BlockEntry{Phone: "+13333333333"})
+
+	s.blocked.EXPECT().Get(s.ctx).Return(blocked, nil)
+	s.blocked.EXPECT().Save(s.ctx, mock.MatchedBy(func(bp *domain.BlockedPhones) bool {
+		return len(bp.Phones) == 2 && !slices.Contains(bp.Phones, "+11111111111")
+	})).Return(nil)
+	s.audit.EXPECT().Save(s.ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionExpireBlock && e.Target == "+11111111111" &&
+			e.AdminPhone == domain.AuditSystemActor
+	})).Return(nil)
+
+	s.NoError(s.svc.ExpireBlocks(s.ctx))
+}
+
+func (s *AdminServiceSuite) TestExpireBlocks_NoneExpired() {
+	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{Phones: []string{"+11111111111"}}, nil)
+
+	s.NoError(s.svc.ExpireBlocks(s.ctx))
+}
+
+func (s *AdminServiceSuite) TestUnblockUser_NotBlocked() {
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgUserNotBlocked).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	blocked := &domain.BlockedPhones{Phones: []string{"+12222222222"}}
+	err := s.svc.UnblockUser(s.ctx, domain.TextMessage{Body: "#unblock 123-456-7890"}, mem, blocked)
+	s.NoError(err)
+}
+
+func (s *AdminServiceSuite) TestUnblockUser_Success() {
+	s.expectAudit(domain.AuditActionUnblock, "+11234567890")
+	s.blocked.EXPECT().Save(s.ctx, &domain.BlockedPhones{Phones: []string{"+12222222222"}}).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgSuccessfullyUnblocked).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	blocked := &domain.BlockedPhones{Phones: []string{"+12222222222", "+11234567890"}}
+	err := s.svc.UnblockUser(s.ctx, domain.TextMessage{Body: "#unblock 123-456-7890"}, mem, blocked)
+	s.NoError(err)
+}
+
+func (s *AdminServiceSuite) TestStats() {
+	s.expectAudit(domain.AuditActionStats, "")
+	s.members.EXPECT().GetAll(s.ctx).Return([]domain.Member{
+		{Phone: "+11111111111", SetupStatus: domain.MemberSetupComplete, Intercessor: true},
+		{Phone: "+12222222222", SetupStatus: domain.MemberSetupComplete},
+		{Phone: "+13333333333", SetupStatus: domain.MemberSetupInProgress},
+	}, nil)
+	s.prayers.EXPECT().GetAll(s.ctx, false).Return([]domain.Prayer{{}}, nil)
+	s.prayers.EXPECT().GetAll(s.ctx, true).Return([]domain.Prayer{{}, {}}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777",
+		"Members: 2\nIntercessors: 1\nActive prayers: 1\nQueued prayers: 2").Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	err := s.svc.HandleCommand(s.ctx, domain.TextMessage{Body: "#stats"}, mem, &domain.BlockedPhones{})
+	s.NoError(err)
+}
+
+func (s *AdminServiceSuite) TestStats_NotAdmin() {
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgUnauthorized).Return(nil)
+
+	mem := domain.Member{Phone: "+11234567890"}
+	err := s.svc.HandleCommand(s.ctx, domain.TextMessage{Body: "#stats"}, mem, &domain.BlockedPhones{})
+	s.NoError(err)
+}
+
+func (s *AdminServiceSuite) TestQueue_Empty() {
+	s.expectAudit(domain.AuditActionQueue, "")
+	s.prayers.EXPECT().GetAll(s.ctx, true).Return(nil, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgQueueEmpty).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	s.NoError(s.svc.Queue(s.ctx, mem))
+}
+
+func (s *AdminServiceSuite) TestQueue_ListsPrayers() {
+	s.expectAudit(domain.AuditActionQueue, "")
+	s.prayers.EXPECT().GetAll(s.ctx, true).Return([]domain.Prayer{
+		{Request: "please pray for my job", Requestor: domain.MemberRef{Name: "John", Phone: "+11111111111"}},
+		{Request: "healing for my mom", Requestor: domain.MemberRef{Name: "Jane", Phone: "+12222222222"}},
+	}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777",
+		"1. John (+11111111111): please pray for my job\n2. Jane (+12222222222): healing for my mom").Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	s.NoError(s.svc.Queue(s.ctx, mem))
+}
+
+func (s *AdminServiceSuite) TestLookup_NotFound() {
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{Phone: "+11234567890"}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgMemberNotFound).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	s.NoError(s.svc.Lookup(s.ctx, domain.TextMessage{Body: "#lookup 123-456-7890"}, mem))
+}
+
+func (s *AdminServiceSuite) TestLookup_Success() {
+	s.expectAudit(domain.AuditActionLookup, "+11234567890")
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", Name: "John", SetupStatus: domain.MemberSetupComplete, Intercessor: true,
+		PrayerCount: 1, WeeklyPrayerLimit: 5,
+	}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", mock.MatchedBy(func(body string) bool {
+		return strings.Contains(body, "Name: John") && strings.Contains(body, "Prayers this week: 1/5")
+	})).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	s.NoError(s.svc.Lookup(s.ctx, domain.TextMessage{Body: "#lookup 123-456-7890"}, mem))
+}
+
+func (s *AdminServiceSuite) TestPromoteAndDemote() {
+	s.expectAudit(domain.AuditActionPromote, "+11234567890")
+	s.expectAudit(domain.AuditActionDemote, "+11234567890")
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete,
+	}, nil).Twice()
+	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool {
+		return m.Role == domain.RoleCoordinator
+	})).Return(nil).Once()
+	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool { return m.Role == domain.RoleNone })).
+		Return(nil).Once()
+	s.sender.EXPECT().
+		SendMessage(s.ctx, "+17777777777", "The member has been successfully given the coordinator role.").
+		Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgSuccessfullyDemoted).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	blocked := &domain.BlockedPhones{}
+	msg := domain.TextMessage{Body: "#promote 123-456-7890 Coordinator"}
+	s.NoError(s.svc.HandleCommand(s.ctx, msg, mem, blocked))
+	s.NoError(s.svc.HandleCommand(s.ctx, domain.TextMessage{Body: "#demote 123-456-7890"}, mem, blocked))
+}
+
+func (s *AdminServiceSuite) TestPromote_DefaultsToModerator() {
+	s.expectAudit(domain.AuditActionPromote, "+11234567890")
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete,
+	}, nil)
+	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool {
+		return m.Role == domain.RoleModerator
+	})).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", "The member has been successfully given the moderator role.").
+		Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	s.NoError(s.svc.Promote(s.ctx, domain.TextMessage{Body: "#promote 123-456-7890"}, mem))
+}
+
+func (s *AdminServiceSuite) TestPromote_InvalidRole() {
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgInvalidRole).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	s.NoError(s.svc.Promote(s.ctx, domain.TextMessage{Body: "#promote 123-456-7890 boss"}, mem))
+}
+
+func (s *AdminServiceSuite) TestPromote_OwnRole() {
+	s.members.EXPECT().Get(s.ctx, "+17777777777").Return(&domain.Member{
+		Phone: "+17777777777", SetupStatus: domain.MemberSetupComplete, Role: domain.RoleOwner,
+	}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgCannotChangeOwnRole).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	s.NoError(s.svc.Demote(s.ctx, domain.TextMessage{Body: "#demote 777-777-7777"}, mem))
+}
+
+func (s *AdminServiceSuite) TestPermissions() {
+	tests := []struct {
+		role domain.Role
+		body string
+	}{
+		{domain.RoleModerator, "#promote 123-456-7890"},
+		{domain.RoleCoordinator, "#demote 123-456-7890"},
+		{domain.RoleNone, "#stats"},
+		{domain.RoleNone, "#queue"},
+	}
+
+	for _, tt := range tests {
+		s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgUnauthorized).Return(nil).Once()
+
+		mem := domain.Member{Phone: "+17777777777", Role: tt.role}
+		s.NoError(s.svc.HandleCommand(s.ctx, domain.TextMessage{Body: tt.body}, mem, &domain.BlockedPhones{}), tt.body)
+	}
+}
+
+func (s *AdminServiceSuite) TestBlockUser_Moderator() {
+	s.expectAudit(domain.AuditActionBlock, "+11234567890")
+	s.blocked.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{Phone: "+11234567890"}, nil)
+	s.members.EXPECT().Delete(s.ctx, "+11234567890").Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", mock.Anything).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgSuccessfullyBlocked).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleModerator}
+	err := s.svc.BlockUser(s.ctx, domain.TextMessage{Body: "#block 123-456-7890"}, mem, &domain.BlockedPhones{})
+	s.NoError(err)
+}
+
+func (s *AdminServiceSuite) TestRemoveMember() {
+	s.expectAudit(domain.AuditActionRemove, "+11234567890")
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete,
+	}, nil)
+	s.members.EXPECT().Delete(s.ctx, "+11234567890").Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgRemoveUser).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgSuccessfullyRemoved).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	s.NoError(s.svc.RemoveMember(s.ctx, domain.TextMessage{Body: "#remove 123-456-7890"}, mem))
+}
+
+func (s *AdminServiceSuite) TestRemoveMember_Self() {
+	s.members.EXPECT().Get(s.ctx, "+17777777777").Return(&domain.Member{
+		Phone: "+17777777777", SetupStatus: domain.MemberSetupComplete, Role: domain.RoleModerator,
+	}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgCannotRemoveSelf).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleModerator}
+	s.NoError(s.svc.RemoveMember(s.ctx, domain.TextMessage{Body: "#remove 777-777-7777"}, mem))
+}
+
+func (s *AdminServiceSuite) TestLastOwner() {
+	owner := &domain.Member{Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete, Role: domain.RoleOwner}
+	s.members.EXPECT().Get(s.ctx, "+11234567890").RunAndReturn(func(context.Context, string) (*domain.Member, error) {
+		m := *owner
+		return &m, nil
+	})
+	s.members.EXPECT().GetAll(s.ctx).Return([]domain.Member{
+		*owner, {Phone: "+17777777777", Role: domain.RoleModerator},
+	}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgLastOwner).Return(nil).Twice()
+	s.audit.EXPECT().Save(s.ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionBlock && e.Result == "failed: "+service.ErrLastOwner.Error()
+	})).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleModerator}
+	blocked := &domain.BlockedPhones{}
+	s.NoError(s.svc.RemoveMember(s.ctx, domain.TextMessage{Body: "#remove 123-456-7890"}, mem))
+	s.NoError(s.svc.BlockUser(s.ctx, domain.TextMessage{Body: "#block 123-456-7890"}, mem, blocked))
+	s.Empty(blocked.Phones)
+
+	role := domain.RoleNone
+	_, err := s.svc.UpdateMember(s.ctx, "+19999999999", "+11234567890", service.MemberUpdate{Role: &role})
+	s.ErrorIs(err, service.ErrLastOwner)
+	s.ErrorIs(s.svc.Remove(s.ctx, "+19999999999", "+11234567890"), service.ErrLastOwner)
+}
+
+func (s *AdminServiceSuite) TestDemote_AnotherOwner() {
+	s.expectAudit(domain.AuditActionDemote, "+11234567890")
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete, Role: domain.RoleOwner,
+	}, nil)
+	s.members.EXPECT().GetAll(s.ctx).Return([]domain.Member{
+		{Phone: "+11234567890", Role: domain.RoleOwner}, {Phone: "+17777777777", Role: domain.RoleOwner},
+	}, nil)
+	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool { return m.Role == domain.RoleNone })).
+		Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgSuccessfullyDemoted).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	s.NoError(s.svc.Demote(s.ctx, domain.TextMessage{Body: "#demote 123-456-7890"}, mem))
+}
+
+func (s *AdminServiceSuite) TestModerate_Reject() {
+	s.pending.EXPECT().Get(s.ctx, "1a2b3c").Return(&domain.PendingPrayer{
+		ID: "1a2b3c", Request: "original", Requestor: domain.MemberRef{Phone: "+11234567890"},
+	}, nil)
+	s.pending.EXPECT().Delete(s.ctx, "1a2b3c").Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", mock.MatchedBy(func(body string) bool {
+		return strings.HasSuffix(body, "Reason: Please call us instead.")
+	})).Return(nil)
+	s.audit.EXPECT().Save(s.ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionReject && e.Target == "1a2b3c" && e.Detail == "Please call us instead."
+	})).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgSuccessfullyRejected).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleModerator}
+	msg := domain.TextMessage{Body: "#reject 1A2B3C Please call us instead."}
+	s.NoError(s.svc.HandleCommand(s.ctx, msg, mem, &domain.BlockedPhones{}))
+}
+
+func (s *AdminServiceSuite) TestModerate_Approve() {
+	s.pending.EXPECT().Get(s.ctx, "1a2b3c").Return(&domain.PendingPrayer{
+		ID: "1a2b3c", Request: "original", Requestor: domain.MemberRef{Phone: "+11234567890"},
+	}, nil)
+	s.pending.EXPECT().Delete(s.ctx, "1a2b3c").Return(nil)
+	s.intercessors.EXPECT().Get(s.ctx).Return(&domain.IntercessorPhones{}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgPrayerAssigned).Return(nil)
+	s.expectAudit(domain.AuditActionApprove, "1a2b3c")
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgSuccessfullyApproved).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleModerator}
+	s.NoError(s.svc.Moderate(s.ctx, domain.TextMessage{Body: "#approve 1a2b3c"}, mem))
+}
+
+func (s *AdminServiceSuite) TestModerate_NotFoundAndMissingEdit() {
+	s.pending.EXPECT().Get(s.ctx, "ffffff").Return(&domain.PendingPrayer{}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgPendingNotFound).Return(nil).Twice()
+	s.sender.EXPECT().SendMessage(s.ctx, "+17777777777", messaging.MsgEditMissingRequest).Return(nil)
+
+	mem := domain.Member{Phone: "+17777777777", Role: domain.RoleOwner}
+	s.NoError(s.svc.Moderate(s.ctx, domain.TextMessage{Body: "#approve ffffff"}, mem))
+	s.NoError(s.svc.Moderate(s.ctx, domain.TextMessage{Body: "#approve"}, mem))
+	s.NoError(s.svc.Moderate(s.ctx, domain.TextMessage{Body: "#edit ffffff"}, mem))
+}
+
+func TestAdminCommand(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{"#block 123-456-7890", service.CmdBlock},
+		{"please #UNBLOCK 123-456-7890", service.CmdUnblock},
+		{"#stats", service.CmdStats},
+		{"pray for my #blessed family", ""},
+		{"no command here", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			assert.Equal(t, tt.want, service.AdminCommand(tt.body))
+		})
+	}
+}
+
+func (s *AdminServiceSuite) TestMembers_Search() {
+	s.members.EXPECT().GetAll(s.ctx).Return([]domain.Member{
+		{Phone: "+12222222222", Name: "John Smith"},
+		{Phone: "+11111111111", Name: "Johnny"},
+		{Phone: "+13333333333", Name: "Jane"},
+	}, nil)
+
+	members, err := s.svc.Members(s.ctx, "JOHN")
+	s.Require().NoError(err)
+	s.Require().Len(members, 2)
+	s.Equal("+11111111111", members[0].Phone)
+	s.Equal("+12222222222", members[1].Phone)
+}
+
+func (s *AdminServiceSuite) TestMember_NotFound() {
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{Phone: "+11234567890"}, nil)
+
+	_, err := s.svc.Member(s.ctx, "123-456-7890")
+	s.ErrorIs(err, service.ErrMemberNotFound)
+}
+
+func (s *AdminServiceSuite) TestUpdateMember() {
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", Name: "John", SetupStatus: domain.MemberSetupComplete, WeeklyPrayerLimit: 5,
+	}, nil)
+	s.members.EXPECT().Save(s.ctx, &domain.Member{
+		Phone: "+11234567890", Name: "Johnny", SetupStatus: domain.MemberSetupComplete, WeeklyPrayerLimit: 3,
+	}).Return(nil)
+	s.audit.EXPECT().Save(s.ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionEdit && e.Detail == "changed name, weekly prayer limit"
+	})).Return(nil)
+
+	name, limit := "Johnny", 3
+	mem, err := s.svc.UpdateMember(s.ctx, "+19999999999", "+11234567890", service.MemberUpdate{
+		Name: &name, WeeklyPrayerLimit: &limit,
+	})
+	s.Require().NoError(err)
+	s.Equal("Johnny", mem.Name)
+}
+
+func (s *AdminServiceSuite) TestUpdateMember_Invalid() {
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete,
+	}, nil)
+
+	limit := 0
+	_, err := s.svc.UpdateMember(s.ctx, "+19999999999", "+11234567890", service.MemberUpdate{
+		WeeklyPrayerLimit: &limit,
+	})
+	s.ErrorIs(err, service.ErrInvalidMemberUpdate)
+}
+
+func (s *AdminServiceSuite) TestBlock_AlreadyBlocked() {
+	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{Phones: []string{"+11234567890"}}, nil)
+
+	err := s.svc.Block(s.ctx, "+19999999999", "123-456-7890", 0, "")
+	s.ErrorIs(err, service.ErrAlreadyBlocked)
+}
+
+func (s *AdminServiceSuite) TestBlocks() {
+	blocked := &domain.BlockedPhones{Phones: []string{"+11111111111"}}
+	blocked.Block(domain.BlockEntry{Phone: "+12222222222", Reason: "spam"})
+	s.blocked.EXPECT().Get(s.ctx).Return(blocked, nil)
+
+	entries, err := s.svc.Blocks(s.ctx)
+	s.Require().NoError(err)
+	s.Equal([]domain.BlockEntry{{Phone: "+11111111111"}, {Phone: "+12222222222", Reason: "spam"}}, entries)
+}
+
 func TestAdminServiceSuite(t *testing.T) {
 	suite.Run(t, new(AdminServiceSuite))
 }
+
+func (s *AdminServiceSuite) TestBlock_RecordsFailure() {
+	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{}, nil)
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{Phone: "+11234567890"}, nil)
+	s.blocked.EXPECT().Save(s.ctx, mock.Anything).Return(errors.New("ddb down"))
+	s.audit.EXPECT().Save(s.ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionBlock && e.Target == "+11234567890" && e.Detail == "spam" &&
+			e.Result == "failed: ddb down"
+	})).Return(nil)
+
+	err := s.svc.Block(s.ctx, "+19999999999", "123-456-7890", 0, "spam")
+	s.EqualError(err, "ddb down")
+}
+
+func (s *AdminServiceSuite) TestRecordFailure_AuditFails() {
+	s.audit.EXPECT().Save(s.ctx, mock.Anything).Return(errors.New("audit down"))
+
+	cause := errors.New("ddb down")
+	err := s.svc.RecordFailure(s.ctx, "+19999999999", domain.AuditActionUnblock, "+11234567890", "", cause)
+	s.ErrorIs(err, cause)
+	s.ErrorContains(err, "audit down")
+}
+
+func (s *AdminServiceSuite) TestAuditLog_ByAdminAndTarget() {
+	s.audit.EXPECT().GetByAdmin(s.ctx, "+19999999999").Return([]domain.AuditEntry{
+		{ID: "1", Target: "+11234567890"},
+		{ID: "2", Target: "+12222222222"},
+		{ID: "3", Target: "+11234567890"},
+	}, nil)
+
+	entries, err := s.svc.AuditLog(s.ctx, "999-999-9999", "+1 123 456 7890")
+	s.Require().NoError(err)
+	s.Equal([]string{"3", "1"}, []string{entries[0].ID, entries[1].ID})
+}
+
+func (s *AdminServiceSuite) TestAuditLog_ByActor() {
+	s.audit.EXPECT().GetByAdmin(s.ctx, domain.AuditSystemActor).Return(nil, nil)
+
+	_, err := s.svc.AuditLog(s.ctx, "system", "")
+	s.Require().NoError(err)
+}
+
+func (s *AdminServiceSuite) TestAuditLog_NoFilter() {
+	_, err := s.svc.AuditLog(s.ctx, "", " ")
+	s.ErrorIs(err, service.ErrInvalidAuditQuery)
+}
+
+func (s *AdminServiceSuite) TestAnnounce_AllMembers() {
+	s.members.EXPECT().GetAll(s.ctx).Return([]domain.Member{
+		{Phone: "+11111111111", SetupStatus: domain.MemberSetupComplete},
+		{Phone: "+12222222222", SetupStatus: domain.MemberSetupInProgress},
+		{Phone: "+13333333333", SetupStatus: domain.MemberSetupComplete, Inactive: true},
+		{Phone: "+447911123456", SetupStatus: domain.MemberSetupComplete},
+	}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11111111111", "we will be down tonight").Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+447911123456", "we will be down tonight").
+		Return(errors.New("failed"))
+	s.audit.EXPECT().Save(s.ctx, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == domain.AuditActionAnnounce && e.Detail == "sent to 1 of 2 phones"
+	})).Return(nil)
+
+	sent, err := s.svc.Announce(s.ctx, domain.AuditSystemActor, "we will be down tonight", nil)
+	s.NoError(err)
+	s.Equal(1, sent)
+}
+
+func (s *AdminServiceSuite) TestAnnounce_NormalizesPhones() {
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", "hello").Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+447911123456", "hello").Return(nil)
+	s.expectAudit(domain.AuditActionAnnounce, "")
+
+	phones := []string{"(123) 456-7890", "+11234567890", "+44 7911 123456"}
+	sent, err := s.svc.Announce(s.ctx, domain.AuditSystemActor, "hello", phones)
+	s.NoError(err)
+	s.Equal(2, sent)
+}
+
+func (s *AdminServiceSuite) TestAnnounce_InvalidPhone() {
+	_, err := s.svc.Announce(s.ctx, domain.AuditSystemActor, "hello", []string{"123"})
+	s.ErrorIs(err, service.ErrInvalidPhone)
+}
+
+func (s *AdminServiceSuite) TestAnnounce_Empty() {
+	_, err := s.svc.Announce(s.ctx, domain.AuditSystemActor, " ", nil)
+	s.ErrorIs(err, service.ErrEmptyAnnouncement)
+}
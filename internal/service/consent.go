@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/apperr"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/4JesusApps/prayertexter/internal/phone"
+)
+
+// optInKeyword is the keyword that starts the sign up flow that members consent through.
+const optInKeyword = "pray"
+
+// ConsentExport is a phone's consent record with the text of the disclosure shown at each opt in, for carrier audits.
+type ConsentExport struct {
+	Phone  string
+	Events []ConsentExportEvent
+}
+
+// ConsentExportEvent is a consent event with the text of its disclosure version. DisclosureText is empty for opt
+// outs.
+type ConsentExportEvent struct {
+	domain.ConsentEvent
+	DisclosureText string
+}
+
+// recordOptIn adds an opt in through the sign up flow to mem's consent record and copies it onto mem, along with
+// the dates of any earlier opt outs. mem is not saved.
+func (s *MemberService) recordOptIn(ctx context.Context, mem *domain.Member) error {
+	event := domain.ConsentEvent{
+		Type:       domain.ConsentOptIn,
+		Date:       time.Now().Format(time.RFC3339),
+		Method:     domain.ConsentMethodKeyword,
+		Keyword:    optInKeyword,
+		Disclosure: messaging.DisclosureVersion,
+	}
+	record, err := s.addConsentEvent(ctx, mem.Phone, event)
+	if err != nil {
+		return err
+	}
+
+	mem.ConsentDate = event.Date
+	mem.ConsentMethod = event.Method
+	mem.ConsentDisclosure = event.Disclosure
+	mem.OptOuts = record.OptOuts()
+	return nil
+}
+
+// RecordOptOut adds an opt out by texting keyword to the consent record for phn. It is recorded even when phn never
+// finished signing up.
+func (s *MemberService) RecordOptOut(ctx context.Context, phn, keyword string) error {
+	_, err := s.addConsentEvent(ctx, phn, domain.ConsentEvent{
+		Type:    domain.ConsentOptOut,
+		Date:    time.Now().Format(time.RFC3339),
+		Method:  domain.ConsentMethodKeyword,
+		Keyword: keyword,
+	})
+	return err
+}
+
+func (s *MemberService) addConsentEvent(
+	ctx context.Context,
+	phn string,
+	event domain.ConsentEvent,
+) (*domain.ConsentRecord, error) {
+	record, err := s.consents.Get(ctx, phn)
+	if err != nil {
+		return nil, err
+	}
+	record.Phone = phn
+	record.Events = append(record.Events, event)
+	if err = s.consents.Save(ctx, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// ConsentExport returns every opt in and opt out by phone, including those from before the member last opted out, or
+// ErrConsentNotFound when there are none.
+func (s *AdminService) ConsentExport(ctx context.Context, phn string) (*ConsentExport, error) {
+	target, err := phone.Normalize(phn, s.cfg.PhoneRegion)
+	if err != nil {
+		return nil, apperr.WrapError(ErrInvalidPhone, phn)
+	}
+
+	record, err := s.consents.Get(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	if len(record.Events) == 0 {
+		return nil, ErrConsentNotFound
+	}
+
+	export := &ConsentExport{Phone: target, Events: make([]ConsentExportEvent, 0, len(record.Events))}
+	for _, event := range record.Events {
+		export.Events = append(export.Events, ConsentExportEvent{
+			ConsentEvent:   event,
+			DisclosureText: messaging.Disclosures[event.Disclosure],
+		})
+	}
+	return export, nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/repository"
+)
+
+type DeliveryService struct {
+	members   repository.MemberRepository
+	receipts  repository.DeliveryReceiptRepository
+	memberSvc *MemberService
+	cfg       config.Config
+}
+
+func NewDeliveryService(
+	members repository.MemberRepository,
+	receipts repository.DeliveryReceiptRepository,
+	memberSvc *MemberService,
+	cfg config.Config,
+) *DeliveryService {
+	return &DeliveryService{
+		members:   members,
+		receipts:  receipts,
+		memberSvc: memberSvc,
+		cfg:       cfg,
+	}
+}
+
+// RecordReceipt stores the latest delivery status of an outbound message and keeps track of consecutive hard failures
+// for the recipient. Once a member reaches the configured failure limit they are marked inactive and, if they are an
+// intercessor, removed from the intercessor list so that their active prayer can go to someone else. Receipts are
+// matched to messages by the provider message ID, and a repeated receipt with the status already recorded is ignored
+// so that it is not counted twice.
+func (s *DeliveryService) RecordReceipt(ctx context.Context, receipt domain.DeliveryReceipt) error {
+	existing, err := s.receipts.Get(ctx, receipt.MessageID)
+	if err != nil {
+		return err
+	}
+	if existing.Status == receipt.Status {
+		slog.InfoContext(ctx, "skipping duplicate delivery receipt", "messageid", receipt.MessageID,
+			"status", receipt.Status)
+		return nil
+	}
+
+	if err = s.receipts.Save(ctx, &receipt); err != nil {
+		return err
+	}
+
+	mem, err := s.members.Get(ctx, receipt.Phone)
+	if err != nil {
+		return err
+	}
+
+	if mem.SetupStatus == "" {
+		return nil
+	}
+
+	switch {
+	case receipt.IsDelivered() && mem.DeliveryFailures > 0:
+		mem.DeliveryFailures = 0
+		return s.members.Save(ctx, mem)
+	case receipt.IsHardFailure():
+		return s.recordFailure(ctx, *mem, receipt)
+	default:
+		return nil
+	}
+}
+
+func (s *DeliveryService) recordFailure(ctx context.Context, mem domain.Member, receipt domain.DeliveryReceipt) error {
+	mem.DeliveryFailures++
+	slog.WarnContext(ctx, "hard delivery failure", "phone", mem.Phone, "status", receipt.Status,
+		"failures", mem.DeliveryFailures)
+
+	if mem.Inactive || mem.DeliveryFailures < s.cfg.DeliveryFailureLimit {
+		return s.members.Save(ctx, &mem)
+	}
+
+	mem.Inactive = true
+	if err := s.members.Save(ctx, &mem); err != nil {
+		return err
+	}
+	slog.WarnContext(ctx, "member marked inactive after repeated delivery failures", "phone", mem.Phone)
+
+	if mem.Intercessor {
+		return s.memberSvc.removeIntercessor(ctx, mem)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/4JesusApps/prayertexter/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	msgmocks "github.com/4JesusApps/prayertexter/internal/mocks/messaging"
+	repomocks "github.com/4JesusApps/prayertexter/internal/mocks/repository"
+)
+
+type DeliveryServiceSuite struct {
+	suite.Suite
+	svc          *service.DeliveryService
+	members      *repomocks.MockMemberRepository
+	receipts     *repomocks.MockDeliveryReceiptRepository
+	intercessors *repomocks.MockIntercessorPhonesRepository
+	prayers      *repomocks.MockPrayerRepository
+	consents     *repomocks.MockConsentRecordRepository
+	sender       *msgmocks.MockMessageSender
+	ctx          context.Context
+}
+
+func (s *DeliveryServiceSuite) SetupTest() {
+	s.members = repomocks.NewMockMemberRepository(s.T())
+	s.receipts = repomocks.NewMockDeliveryReceiptRepository(s.T())
+	s.intercessors = repomocks.NewMockIntercessorPhonesRepository(s.T())
+	s.prayers = repomocks.NewMockPrayerRepository(s.T())
+	s.consents = repomocks.NewMockConsentRecordRepository(s.T())
+	s.sender = msgmocks.NewMockMessageSender(s.T())
+	s.ctx = context.Background()
+
+	cfg := config.Config{DeliveryFailureLimit: 3}
+	profanity := messaging.NewProfanity(config.ProfanityConfig{}, domain.ProfanityLists{})
+	memberSvc := service.NewMemberService(s.members, s.intercessors, s.prayers, s.consents, s.sender, profanity, cfg)
+	s.svc = service.NewDeliveryService(s.members, s.receipts, memberSvc, cfg)
+}
+
+func (s *DeliveryServiceSuite) TestRecordReceipt_NonMember() {
+	receipt := domain.DeliveryReceipt{MessageID: "m1", Phone: "+11234567890", Status: domain.DeliveryStatusInvalid}
+	s.receipts.EXPECT().Get(s.ctx, "m1").Return(&domain.DeliveryReceipt{}, nil)
+	s.receipts.EXPECT().Save(s.ctx, &receipt).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{Phone: "+11234567890"}, nil)
+
+	err := s.svc.RecordReceipt(s.ctx, receipt)
+	s.NoError(err)
+}
+
+func (s *DeliveryServiceSuite) TestRecordReceipt_Duplicate() {
+	receipt := domain.DeliveryReceipt{MessageID: "m1", Phone: "+11234567890", Status: domain.DeliveryStatusInvalid}
+	s.receipts.EXPECT().Get(s.ctx, "m1").Return(&domain.DeliveryReceipt{
+		MessageID: "m1", Phone: "+11234567890", Status: domain.DeliveryStatusInvalid,
+	}, nil)
+
+	// The failure was already counted when the receipt was first recorded.
+	s.NoError(s.svc.RecordReceipt(s.ctx, receipt))
+}
+
+func (s *DeliveryServiceSuite) TestRecordReceipt_DeliveredResetsFailures() {
+	receipt := domain.DeliveryReceipt{MessageID: "m1", Phone: "+11234567890", Status: domain.DeliveryStatusDelivered}
+	s.receipts.EXPECT().Get(s.ctx, "m1").Return(&domain.DeliveryReceipt{}, nil)
+	s.receipts.EXPECT().Save(s.ctx, &receipt).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete, DeliveryFailures: 2,
+	}, nil)
+	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool {
+		return m.DeliveryFailures == 0
+	})).Return(nil)
+
+	err := s.svc.RecordReceipt(s.ctx, receipt)
+	s.NoError(err)
+}
+
+func (s *DeliveryServiceSuite) TestRecordReceipt_HardFailureUnderLimit() {
+	receipt := domain.DeliveryReceipt{MessageID: "m1", Phone: "+11234567890", Status: domain.DeliveryStatusUnreachable}
+	s.receipts.EXPECT().Get(s.ctx, "m1").Return(&domain.DeliveryReceipt{}, nil)
+	s.receipts.EXPECT().Save(s.ctx, &receipt).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete, DeliveryFailures: 1,
+	}, nil)
+	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool {
+		return m.DeliveryFailures == 2 && !m.Inactive
+	})).Return(nil)
+
+	err := s.svc.RecordReceipt(s.ctx, receipt)
+	s.NoError(err)
+}
+
+func (s *DeliveryServiceSuite) TestRecordReceipt_HardFailureDeactivatesIntercessor() {
+	receipt := domain.DeliveryReceipt{MessageID: "m1", Phone: "+11234567890", Status: domain.DeliveryStatusInvalid}
+	s.receipts.EXPECT().Get(s.ctx, "m1").Return(&domain.DeliveryReceipt{}, nil)
+	s.receipts.EXPECT().Save(s.ctx, &receipt).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone:            "+11234567890",
+		SetupStatus:      domain.MemberSetupComplete,
+		Intercessor:      true,
+		DeliveryFailures: 2,
+	}, nil)
+	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool {
+		return m.DeliveryFailures == 3 && m.Inactive
+	})).Return(nil)
+	s.intercessors.EXPECT().Get(s.ctx).Return(&domain.IntercessorPhones{
+		Phones: []string{"+11234567890", "+12222222222"},
+	}, nil)
+	s.intercessors.EXPECT().Save(s.ctx, mock.MatchedBy(func(p *domain.IntercessorPhones) bool {
+		return len(p.Phones) == 1 && p.Phones[0] == "+12222222222"
+	})).Return(nil)
+	s.prayers.EXPECT().Exists(s.ctx, "+11234567890").Return(true, nil)
+	s.prayers.EXPECT().Get(s.ctx, "+11234567890", false).Return(&domain.Prayer{
+		IntercessorPhone: "+11234567890",
+		Request:          "please pray for my family",
+	}, nil)
+	s.prayers.EXPECT().Delete(s.ctx, "+11234567890", false).Return(nil)
+	s.prayers.EXPECT().Save(s.ctx, mock.MatchedBy(func(p *domain.Prayer) bool {
+		return p.IntercessorPhone != "+11234567890" && p.Request == "please pray for my family"
+	}), true).Return(nil)
+
+	err := s.svc.RecordReceipt(s.ctx, receipt)
+	s.NoError(err)
+}
+
+func TestDeliveryServiceSuite(t *testing.T) {
+	suite.Run(t, new(DeliveryServiceSuite))
+}
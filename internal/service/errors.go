@@ -10,4 +10,15 @@ const (
 	ErrNoAvailableIntercessors = constError("no available intercessors")
 	ErrIntercessorUnavailable  = constError("intercessor unavailable")
 	ErrInvalidPhone            = constError("no valid phone numbers found")
+	ErrEmptyAnnouncement       = constError("announcement is empty")
+	ErrAlreadyBlocked          = constError("phone is already blocked")
+	ErrNotBlocked              = constError("phone is not blocked")
+	ErrMemberNotFound          = constError("member not found")
+	ErrPrayerNotFound          = constError("prayer not found")
+	ErrInvalidMemberUpdate     = constError("invalid member update")
+	ErrInvalidAuditQuery       = constError("an admin or target is required")
+	ErrForbidden               = constError("role does not have permission")
+	ErrUnknownSignUpStage      = constError("unknown sign up stage")
+	ErrConsentNotFound         = constError("consent record not found")
+	ErrLastOwner               = constError("cannot remove the last owner")
 )
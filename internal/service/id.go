@@ -16,3 +16,15 @@ func generateID() (string, error) {
 
 	return hex.EncodeToString(bytes), nil
 }
+
+// generateShortID returns an ID short enough for an admin to type back in a text message. Short IDs collide far more
+// often than those from generateID, so they are only used where a collision is detected and retried.
+func generateShortID() (string, error) {
+	size := 3
+	bytes := make([]byte, size)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", apperr.WrapError(err, "failed generate ID")
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
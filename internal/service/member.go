@@ -2,10 +2,7 @@ package service
 
 import (
 	"context"
-	"log/slog"
-	"strconv"
 	"strings"
-	"time"
 	"unicode"
 
 	"github.com/4JesusApps/prayertexter/internal/config"
@@ -18,7 +15,9 @@ type MemberService struct {
 	members      repository.MemberRepository
 	intercessors repository.IntercessorPhonesRepository
 	prayers      repository.PrayerRepository
+	consents     repository.ConsentRecordRepository
 	sender       messaging.MessageSender
+	profanity    *messaging.Profanity
 	cfg          config.Config
 }
 
@@ -26,14 +25,18 @@ func NewMemberService(
 	members repository.MemberRepository,
 	intercessors repository.IntercessorPhonesRepository,
 	prayers repository.PrayerRepository,
+	consents repository.ConsentRecordRepository,
 	sender messaging.MessageSender,
+	profanity *messaging.Profanity,
 	cfg config.Config,
 ) *MemberService {
 	return &MemberService{
 		members:      members,
 		intercessors: intercessors,
 		prayers:      prayers,
+		consents:     consents,
 		sender:       sender,
+		profanity:    profanity,
 		cfg:          cfg,
 	}
 }
@@ -54,6 +57,27 @@ func (s *MemberService) Delete(ctx context.Context, mem domain.Member) error {
 	return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgRemoveUser)
 }
 
+// Reactivate clears the inactive flag set after repeated delivery failures. A member texting in proves their phone can
+// receive texts again, so intercessors are also put back on the intercessor list.
+func (s *MemberService) Reactivate(ctx context.Context, mem domain.Member) error {
+	mem.Inactive = false
+	mem.DeliveryFailures = 0
+	if err := s.members.Save(ctx, &mem); err != nil {
+		return err
+	}
+
+	if !mem.Intercessor || mem.SetupStatus != domain.MemberSetupComplete {
+		return nil
+	}
+
+	phones, err := s.intercessors.Get(ctx)
+	if err != nil {
+		return err
+	}
+	phones.AddPhone(mem.Phone)
+	return s.intercessors.Save(ctx, phones)
+}
+
 func (s *MemberService) removeIntercessor(ctx context.Context, mem domain.Member) error {
 	phones, err := s.intercessors.Get(ctx)
 	if err != nil {
@@ -89,121 +113,11 @@ func (s *MemberService) moveActivePrayer(ctx context.Context, mem domain.Member)
 		return err
 	}
 	pryr.IntercessorPhone = id
-	pryr.Intercessor = domain.Member{}
+	pryr.Intercessor = domain.MemberRef{}
 
 	return s.prayers.Save(ctx, pryr, true)
 }
 
-func (s *MemberService) SignUp(ctx context.Context, msg domain.TextMessage, mem domain.Member) error {
-	cleanMsg := cleanStr(msg.Body)
-
-	switch {
-	case cleanMsg == "pray":
-		return s.signUpStageOne(ctx, mem)
-	case mem.SetupStage == domain.MemberSignUpStepOne:
-		return s.signUpStageTwo(ctx, msg, mem)
-	case cleanMsg == "1" && mem.SetupStage == domain.MemberSignUpStepTwo:
-		return s.signUpFinalPrayer(ctx, mem)
-	case cleanMsg == "2" && mem.SetupStage == domain.MemberSignUpStepTwo:
-		return s.signUpStageThree(ctx, mem)
-	case mem.SetupStage == domain.MemberSignUpStepThree:
-		return s.signUpFinalIntercessor(ctx, msg, mem)
-	default:
-		return s.signUpWrongInput(ctx, mem, msg)
-	}
-}
-
-func (s *MemberService) signUpStageOne(ctx context.Context, mem domain.Member) error {
-	mem.SetupStatus = domain.MemberSetupInProgress
-	mem.SetupStage = domain.MemberSignUpStepOne
-	if err := s.members.Save(ctx, &mem); err != nil {
-		return err
-	}
-	return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgNameRequest)
-}
-
-func (s *MemberService) signUpStageTwo(ctx context.Context, msg domain.TextMessage, mem domain.Member) error {
-	profanity := messaging.CheckProfanity(msg.Body)
-	if profanity != "" {
-		rendered, err := messaging.Render(messaging.ProfanityDetectedTmpl, struct{ Word string }{profanity})
-		if err != nil {
-			return err
-		}
-		return s.sender.SendMessage(ctx, mem.Phone, rendered)
-	}
-
-	if cleanStr(msg.Body) == "2" {
-		mem.Name = "Anonymous"
-	} else {
-		mem.Name = msg.Body
-	}
-
-	if !isNameValid(mem.Name) {
-		return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgInvalidName)
-	}
-
-	mem.SetupStage = domain.MemberSignUpStepTwo
-	if err := s.members.Save(ctx, &mem); err != nil {
-		return err
-	}
-	return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgMemberTypeRequest)
-}
-
-func (s *MemberService) signUpFinalPrayer(ctx context.Context, mem domain.Member) error {
-	mem.SetupStatus = domain.MemberSetupComplete
-	mem.SetupStage = domain.MemberSignUpStepFinal
-	mem.Intercessor = false
-	if err := s.members.Save(ctx, &mem); err != nil {
-		return err
-	}
-
-	body := messaging.MsgPrayerInstructions + "\n\n" + messaging.MsgSignUpConfirmation
-	return s.sender.SendMessage(ctx, mem.Phone, body)
-}
-
-func (s *MemberService) signUpStageThree(ctx context.Context, mem domain.Member) error {
-	mem.SetupStage = domain.MemberSignUpStepThree
-	mem.Intercessor = true
-	if err := s.members.Save(ctx, &mem); err != nil {
-		return err
-	}
-	return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgPrayerNumRequest)
-}
-
-func (s *MemberService) signUpFinalIntercessor(ctx context.Context, msg domain.TextMessage, mem domain.Member) error {
-	num, err := strconv.Atoi(cleanStr(msg.Body))
-	if err != nil {
-		return s.signUpWrongInput(ctx, mem, msg)
-	}
-
-	phones, err := s.intercessors.Get(ctx)
-	if err != nil {
-		return err
-	}
-
-	phones.AddPhone(mem.Phone)
-	if err = s.intercessors.Save(ctx, phones); err != nil {
-		return err
-	}
-
-	mem.SetupStatus = domain.MemberSetupComplete
-	mem.SetupStage = domain.MemberSignUpStepFinal
-	mem.WeeklyPrayerLimit = num
-	mem.WeeklyPrayerDate = time.Now().Format(time.RFC3339)
-	if err = s.members.Save(ctx, &mem); err != nil {
-		return err
-	}
-
-	body := messaging.MsgPrayerInstructions + "\n\n" + messaging.MsgIntercessorInstructions + "\n\n" +
-		messaging.MsgSignUpConfirmation
-	return s.sender.SendMessage(ctx, mem.Phone, body)
-}
-
-func (s *MemberService) signUpWrongInput(ctx context.Context, mem domain.Member, msg domain.TextMessage) error {
-	slog.WarnContext(ctx, "wrong input received during sign up", "member", mem.Phone, "msg", msg)
-	return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgWrongInput)
-}
-
 func cleanStr(str string) string {
 	var sb strings.Builder
 	sb.Grow(len(str))
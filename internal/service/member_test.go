@@ -2,7 +2,10 @@ package service_test
 
 import (
 	"context"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/4JesusApps/prayertexter/internal/config"
 	"github.com/4JesusApps/prayertexter/internal/domain"
@@ -21,6 +24,7 @@ type MemberServiceSuite struct {
 	members      *repomocks.MockMemberRepository
 	intercessors *repomocks.MockIntercessorPhonesRepository
 	prayers      *repomocks.MockPrayerRepository
+	consents     *repomocks.MockConsentRecordRepository
 	sender       *msgmocks.MockMessageSender
 	ctx          context.Context
 }
@@ -29,11 +33,14 @@ func (s *MemberServiceSuite) SetupTest() {
 	s.members = repomocks.NewMockMemberRepository(s.T())
 	s.intercessors = repomocks.NewMockIntercessorPhonesRepository(s.T())
 	s.prayers = repomocks.NewMockPrayerRepository(s.T())
+	s.consents = repomocks.NewMockConsentRecordRepository(s.T())
 	s.sender = msgmocks.NewMockMessageSender(s.T())
 	s.ctx = context.Background()
-	s.svc = service.NewMemberService(s.members, s.intercessors, s.prayers, s.sender, config.Config{
-		IntercessorsPerPrayer: 2,
-	})
+	s.svc = service.NewMemberService(s.members, s.intercessors, s.prayers, s.consents, s.sender,
+		messaging.NewProfanity(config.ProfanityConfig{}, domain.ProfanityLists{}), config.Config{
+			IntercessorsPerPrayer: 2,
+			SignUp:                config.SignUpConfig{NudgeHours: 24, ExpireHours: 168},
+		})
 }
 
 func (s *MemberServiceSuite) TestHelp() {
@@ -57,6 +64,64 @@ func (s *MemberServiceSuite) TestSignUpStageOne() {
 	s.NoError(err)
 }
 
+func (s *MemberServiceSuite) TestSignUp_Restart() {
+	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool {
+		return m.SetupStage == domain.MemberSignUpStepOne && m.SetupDate != "" && !m.SetupNudged && m.Name == ""
+	})).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgNameRequest).Return(nil)
+
+	mem := domain.Member{
+		Phone:       "+11234567890",
+		SetupStage:  domain.MemberSignUpStepOne,
+		SetupStatus: domain.MemberSetupInProgress,
+		SetupNudged: true,
+	}
+	err := s.svc.SignUp(s.ctx, domain.TextMessage{Body: "Restart", Phone: "+11234567890"}, mem)
+	s.NoError(err)
+}
+
+func (s *MemberServiceSuite) TestHandleStaleSignUps() {
+	now := time.Now()
+	s.members.EXPECT().GetAll(s.ctx).Return([]domain.Member{
+		{Phone: "+11111111111", SetupStatus: domain.MemberSetupComplete},
+		{
+			Phone:       "+12222222222",
+			SetupStatus: domain.MemberSetupInProgress,
+			SetupDate:   now.Add(-time.Hour).Format(time.RFC3339),
+		},
+		{
+			Phone:       "+13333333333",
+			SetupStatus: domain.MemberSetupInProgress,
+			SetupDate:   now.Add(-25 * time.Hour).Format(time.RFC3339),
+		},
+		{
+			Phone:       "+14444444444",
+			SetupStatus: domain.MemberSetupInProgress,
+			SetupDate:   now.Add(-25 * time.Hour).Format(time.RFC3339),
+			SetupNudged: true,
+		},
+		{
+			Phone:       "+15555555555",
+			SetupStatus: domain.MemberSetupInProgress,
+			SetupDate:   now.Add(-8 * 24 * time.Hour).Format(time.RFC3339),
+			SetupNudged: true,
+		},
+		{Phone: "+16666666666", SetupStatus: domain.MemberSetupInProgress},
+	}, nil)
+
+	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool {
+		return m.Phone == "+13333333333" && m.SetupNudged
+	})).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+13333333333", messaging.MsgSignUpNudge).Return(nil)
+	s.members.EXPECT().Delete(s.ctx, "+15555555555").Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+15555555555", messaging.MsgSignUpExpired).Return(nil)
+	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool {
+		return m.Phone == "+16666666666" && m.SetupDate != "" && !m.SetupNudged
+	})).Return(nil)
+
+	s.NoError(s.svc.HandleStaleSignUps(s.ctx))
+}
+
 func (s *MemberServiceSuite) TestSignUpStageTwo_ValidName() {
 	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool {
 		return m.Name == "John Doe" && m.SetupStage == domain.MemberSignUpStepTwo
@@ -72,6 +137,41 @@ func (s *MemberServiceSuite) TestSignUpStageTwo_ValidName() {
 	s.NoError(err)
 }
 
+func (s *MemberServiceSuite) TestSignUpStageTwo_Profanity() {
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", mock.MatchedBy(func(body string) bool {
+		return strings.Contains(body, "shit")
+	})).Return(nil)
+
+	mem := domain.Member{
+		Phone:       "+11234567890",
+		SetupStage:  domain.MemberSignUpStepOne,
+		SetupStatus: domain.MemberSetupInProgress,
+	}
+	err := s.svc.SignUp(s.ctx, domain.TextMessage{Body: "shit head", Phone: "+11234567890"}, mem)
+	s.NoError(err)
+}
+
+func (s *MemberServiceSuite) TestSignUpStageTwo_MaskedName() {
+	profanity := messaging.NewProfanity(config.ProfanityConfig{
+		Name: config.ProfanityPolicyConfig{Mode: "mask"},
+	}, domain.ProfanityLists{})
+	svc := service.NewMemberService(
+		s.members, s.intercessors, s.prayers, s.consents, s.sender, profanity, config.Config{},
+	)
+	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool {
+		return m.Name == "**** head"
+	})).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgMemberTypeRequest).Return(nil)
+
+	mem := domain.Member{
+		Phone:       "+11234567890",
+		SetupStage:  domain.MemberSignUpStepOne,
+		SetupStatus: domain.MemberSetupInProgress,
+	}
+	err := svc.SignUp(s.ctx, domain.TextMessage{Body: "shit head", Phone: "+11234567890"}, mem)
+	s.NoError(err)
+}
+
 func (s *MemberServiceSuite) TestSignUpStageTwo_InvalidName() {
 	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgInvalidName).Return(nil)
 
@@ -85,8 +185,21 @@ func (s *MemberServiceSuite) TestSignUpStageTwo_InvalidName() {
 }
 
 func (s *MemberServiceSuite) TestSignUpFinalPrayer() {
+	optOut := domain.ConsentEvent{Type: domain.ConsentOptOut, Date: "2024-01-01T00:00:00Z", Keyword: "stop"}
+	s.consents.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.ConsentRecord{
+		Phone:  "+11234567890",
+		Events: []domain.ConsentEvent{optOut},
+	}, nil)
+	s.consents.EXPECT().Save(s.ctx, mock.MatchedBy(func(r *domain.ConsentRecord) bool {
+		return len(r.Events) == 2 && r.Events[1].Type == domain.ConsentOptIn &&
+			r.Events[1].Method == domain.ConsentMethodKeyword &&
+			r.Events[1].Disclosure == messaging.DisclosureVersion
+	})).Return(nil)
 	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool {
-		return m.SetupStatus == domain.MemberSetupComplete && !m.Intercessor
+		return m.SetupStatus == domain.MemberSetupComplete && !m.Intercessor && m.SignUpDate != "" &&
+			m.ConsentDate != "" && m.ConsentMethod == domain.ConsentMethodKeyword &&
+			m.ConsentDisclosure == messaging.DisclosureVersion &&
+			reflect.DeepEqual(m.OptOuts, []string{optOut.Date})
 	})).Return(nil)
 	expectedBody := messaging.MsgPrayerInstructions + "\n\n" + messaging.MsgSignUpConfirmation
 	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", expectedBody).Return(nil)
@@ -132,6 +245,8 @@ func (s *MemberServiceSuite) TestSignUpStageThree() {
 }
 
 func (s *MemberServiceSuite) TestSignUpFinalIntercessor() {
+	s.consents.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.ConsentRecord{}, nil)
+	s.consents.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
 	s.intercessors.EXPECT().Get(s.ctx).Return(&domain.IntercessorPhones{
 		Phones: []string{"+19999999999"},
 	}, nil)
@@ -141,7 +256,7 @@ func (s *MemberServiceSuite) TestSignUpFinalIntercessor() {
 	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool {
 		return m.SetupStatus == domain.MemberSetupComplete &&
 			m.SetupStage == domain.MemberSignUpStepFinal &&
-			m.WeeklyPrayerLimit == 5
+			m.WeeklyPrayerLimit == 5 && m.SignUpDate != ""
 	})).Return(nil)
 	expectedBody := messaging.MsgPrayerInstructions + "\n\n" + messaging.MsgIntercessorInstructions + "\n\n" +
 		messaging.MsgSignUpConfirmation
@@ -173,13 +288,13 @@ func (s *MemberServiceSuite) TestDelete_Intercessor_WithActivePrayer() {
 	s.prayers.EXPECT().Get(s.ctx, "+11234567890", false).Return(&domain.Prayer{
 		Request:          "original prayer",
 		IntercessorPhone: "+11234567890",
-		Requestor:        domain.Member{Phone: "+19999999999"},
+		Requestor:        domain.MemberRef{Phone: "+19999999999"},
 	}, nil)
 	s.prayers.EXPECT().Delete(s.ctx, "+11234567890", false).Return(nil)
 	s.prayers.EXPECT().Save(s.ctx, mock.MatchedBy(func(p *domain.Prayer) bool {
 		return p.Request == "original prayer" &&
 			p.IntercessorPhone != "+11234567890" &&
-			p.Intercessor == domain.Member{}
+			p.Intercessor == domain.MemberRef{}
 	}), true).Return(nil)
 	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgRemoveUser).Return(nil)
 
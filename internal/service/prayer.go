@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log/slog"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/4JesusApps/prayertexter/internal/config"
 	"github.com/4JesusApps/prayertexter/internal/domain"
 	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/4JesusApps/prayertexter/internal/phone"
 	"github.com/4JesusApps/prayertexter/internal/repository"
 )
 
@@ -19,7 +21,11 @@ type PrayerService struct {
 	members      repository.MemberRepository
 	intercessors repository.IntercessorPhonesRepository
 	prayers      repository.PrayerRepository
+	completed    repository.CompletedPrayerRepository
+	pending      repository.PendingPrayerRepository
+	history      repository.RequestHistoryRepository
 	sender       messaging.MessageSender
+	profanity    *messaging.Profanity
 	cfg          config.Config
 }
 
@@ -27,21 +33,41 @@ func NewPrayerService(
 	members repository.MemberRepository,
 	intercessors repository.IntercessorPhonesRepository,
 	prayers repository.PrayerRepository,
+	completed repository.CompletedPrayerRepository,
+	pending repository.PendingPrayerRepository,
+	history repository.RequestHistoryRepository,
 	sender messaging.MessageSender,
+	profanity *messaging.Profanity,
 	cfg config.Config,
 ) *PrayerService {
 	return &PrayerService{
 		members:      members,
 		intercessors: intercessors,
 		prayers:      prayers,
+		completed:    completed,
+		pending:      pending,
+		history:      history,
 		sender:       sender,
+		profanity:    profanity,
 		cfg:          cfg,
 	}
 }
 
+// Request handles a prayer request from mem. Requests that suggest mem may be in danger are answered with crisis
+// resources before anything else is checked, are never refused and are sent to intercessors ahead of other requests.
+// Moderation still applies to them: a crisis request that matches a moderation keyword is held for review like any
+// other, but is marked urgent so that it is reviewed first.
 func (s *PrayerService) Request(ctx context.Context, msg domain.TextMessage, mem domain.Member) error {
-	profanity := messaging.CheckProfanity(msg.Body)
-	if profanity != "" {
+	crisis := messaging.MatchKeyword(msg.Body, s.cfg.Crisis.Keywords)
+	rule := messaging.MatchKeyword(msg.Body, s.cfg.Moderation.Keywords)
+	if crisis != "" {
+		if err := s.respondToCrisis(ctx, msg, mem, crisis, rule != ""); err != nil {
+			return err
+		}
+	}
+
+	// Profanity in a crisis request is masked for intercessors rather than turning the request away.
+	if profanity := s.profanity.Requests.Reject(msg.Body); profanity != "" && crisis == "" {
 		rendered, err := messaging.Render(messaging.ProfanityDetectedTmpl, struct{ Word string }{profanity})
 		if err != nil {
 			return err
@@ -49,31 +75,102 @@ func (s *PrayerService) Request(ctx context.Context, msg domain.TextMessage, mem
 		return s.sender.SendMessage(ctx, mem.Phone, rendered)
 	}
 
-	if !isRequestValid(msg) {
+	if crisis == "" && !isRequestValid(msg) {
 		return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgInvalidRequest)
 	}
 
-	handleTriggerWords(&msg, &mem)
+	throttled, err := s.throttle(ctx, msg, mem, crisis != "")
+	if err != nil || throttled {
+		return err
+	}
+
+	requestor := mem.Ref()
+	handleTriggerWords(&msg, &requestor)
+
+	requestDate := time.Now().Format(time.RFC3339)
+	if rule != "" {
+		return s.holdForReview(ctx, domain.PendingPrayer{
+			Request:     msg.Body,
+			RequestDate: requestDate,
+			Requestor:   requestor,
+			Rule:        rule,
+			Priority:    crisis != "",
+		})
+	}
+
+	return s.submit(ctx, domain.Prayer{
+		Request:     msg.Body,
+		RequestDate: requestDate,
+		Requestor:   requestor,
+		Priority:    crisis != "",
+	})
+}
+
+// respondToCrisis sends mem the crisis resources and alerts the on call admins that mem's request mentioned keyword.
+// held tells the alert whether the request is waiting for review. Alerts are best effort so that a failed alert never
+// delays the request.
+func (s *PrayerService) respondToCrisis(
+	ctx context.Context,
+	msg domain.TextMessage,
+	mem domain.Member,
+	keyword string,
+	held bool,
+) error {
+	slog.WarnContext(ctx, "crisis keyword found in prayer request", "requestor", mem.Phone, "keyword", keyword)
+	if err := s.sender.SendMessage(ctx, mem.Phone, s.cfg.Crisis.Message); err != nil {
+		return err
+	}
+
+	alert, err := messaging.Render(messaging.CrisisAlertTmpl, struct {
+		Name, Phone, Keyword, Request string
+		Held                          bool
+	}{mem.Name, mem.Phone, keyword, msg.Body, held})
+	if err != nil {
+		return err
+	}
+
+	if len(s.cfg.Crisis.OnCall) == 0 {
+		if err = s.notifyModerators(ctx, alert); err != nil {
+			apperr.LogError(ctx, err, "failed to send crisis alert", "requestor", mem.Phone)
+		}
+		return nil
+	}
+	for _, raw := range s.cfg.Crisis.OnCall {
+		onCall, normErr := phone.Normalize(raw, s.cfg.PhoneRegion)
+		if normErr != nil {
+			apperr.LogError(ctx, normErr, "invalid on call phone", "phone", raw)
+			continue
+		}
+		if err = s.sender.SendMessage(ctx, onCall, alert); err != nil {
+			apperr.LogError(ctx, err, "failed to send crisis alert", "phone", onCall)
+		}
+	}
+	return nil
+}
 
-	intercessors, err := s.FindIntercessors(ctx, mem.Phone)
+// submit sends pryr to intercessors, or queues it when none are available, and tells the requestor which happened.
+func (s *PrayerService) submit(ctx context.Context, pryr domain.Prayer) error {
+	intercessors, err := s.FindIntercessors(ctx, pryr.Requestor.Phone)
 	if err != nil && errors.Is(err, ErrNoAvailableIntercessors) {
-		slog.WarnContext(ctx, "no intercessors available", "request", msg.Body, "requestor", msg.Phone)
-		return s.queuePrayer(ctx, msg, mem)
+		slog.WarnContext(ctx, "no intercessors available", "request", pryr.Request, "requestor", pryr.Requestor.Phone)
+		return s.queuePrayer(ctx, pryr)
 	} else if err != nil {
 		return apperr.WrapError(err, "failed to find intercessors")
 	}
 
 	for _, intr := range intercessors {
-		pryr := domain.Prayer{
-			Request:   msg.Body,
-			Requestor: mem,
-		}
 		if err = s.AssignPrayer(ctx, pryr, intr); err != nil {
 			return err
 		}
 	}
 
-	return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgPrayerAssigned)
+	return s.sender.SendMessage(ctx, pryr.Requestor.Phone, messaging.MsgPrayerAssigned)
+}
+
+// forIntercessor returns request as it is shown to intercessors, with contact details redacted and profanity masked.
+// The saved request is never changed so that admins and the requestor always see what was sent.
+func (s *PrayerService) forIntercessor(request string) string {
+	return s.profanity.Requests.Mask(messaging.Redact(request, s.cfg.Redaction.Surnames))
 }
 
 func isRequestValid(msg domain.TextMessage) bool {
@@ -81,26 +178,29 @@ func isRequestValid(msg domain.TextMessage) bool {
 	return len(strings.Fields(msg.Body)) >= minWords
 }
 
-func handleTriggerWords(msg *domain.TextMessage, mem *domain.Member) {
+func handleTriggerWords(msg *domain.TextMessage, requestor *domain.MemberRef) {
 	if strings.Contains(strings.ToLower(msg.Body), "#anon") {
-		mem.Name = "Anonymous"
+		requestor.Name = domain.AnonymousName
 		re := regexp.MustCompile(`(?i)#anon`)
 		msg.Body = strings.TrimSpace(re.ReplaceAllString(msg.Body, ""))
 	}
 }
 
 func (s *PrayerService) AssignPrayer(ctx context.Context, pryr domain.Prayer, intr domain.Member) error {
-	pryr.Intercessor = intr
+	pryr.Intercessor = intr.Ref()
 	pryr.IntercessorPhone = intr.Phone
 	if err := s.prayers.Save(ctx, &pryr, false); err != nil {
 		return err
 	}
 
-	introMsg, err := messaging.Render(messaging.PrayerIntroTmpl, struct{ Name string }{pryr.Requestor.Name})
+	introMsg, err := messaging.Render(messaging.PrayerIntroTmpl, struct {
+		Name   string
+		Urgent bool
+	}{pryr.Requestor.Name, pryr.Priority})
 	if err != nil {
 		return err
 	}
-	msg := introMsg + pryr.Request + "\n\n" + messaging.MsgPrayed
+	msg := introMsg + s.forIntercessor(pryr.Request) + "\n\n" + messaging.MsgPrayed
 	if err = s.sender.SendMessage(ctx, pryr.Intercessor.Phone, msg); err != nil {
 		return err
 	}
@@ -110,6 +210,10 @@ func (s *PrayerService) AssignPrayer(ctx context.Context, pryr domain.Prayer, in
 }
 
 func (s *PrayerService) FindIntercessors(ctx context.Context, skipPhone string) ([]domain.Member, error) {
+	return s.findIntercessors(ctx, skipPhone, s.cfg.IntercessorsPerPrayer)
+}
+
+func (s *PrayerService) findIntercessors(ctx context.Context, skipPhone string, count int) ([]domain.Member, error) {
 	allPhones, err := s.intercessors.Get(ctx)
 	if err != nil {
 		return nil, err
@@ -119,8 +223,8 @@ func (s *PrayerService) FindIntercessors(ctx context.Context, skipPhone string)
 
 	var intercessors []domain.Member
 
-	for len(intercessors) < s.cfg.IntercessorsPerPrayer {
-		randPhones := allPhones.GenRandPhones(s.cfg.IntercessorsPerPrayer)
+	for len(intercessors) < count {
+		randPhones := allPhones.GenRandPhones(count)
 		if randPhones == nil {
 			slog.InfoContext(ctx, "there are no more intercessors left to check")
 			if len(intercessors) > 0 {
@@ -132,7 +236,7 @@ func (s *PrayerService) FindIntercessors(ctx context.Context, skipPhone string)
 		}
 
 		for _, phn := range randPhones {
-			if len(intercessors) >= s.cfg.IntercessorsPerPrayer {
+			if len(intercessors) >= count {
 				return intercessors, nil
 			}
 
@@ -154,8 +258,8 @@ func (s *PrayerService) FindIntercessors(ctx context.Context, skipPhone string)
 	return intercessors, nil
 }
 
-func (s *PrayerService) processIntercessor(ctx context.Context, phone string) (*domain.Member, error) {
-	intr, err := s.members.Get(ctx, phone)
+func (s *PrayerService) processIntercessor(ctx context.Context, phn string) (*domain.Member, error) {
+	intr, err := s.members.Get(ctx, phn)
 	if err != nil {
 		return nil, err
 	}
@@ -198,23 +302,139 @@ func canResetPrayerCount(intr domain.Member) (bool, error) {
 	return time.Since(previousTime) > 7*24*time.Hour, nil
 }
 
-func (s *PrayerService) queuePrayer(ctx context.Context, msg domain.TextMessage, mem domain.Member) error {
+func (s *PrayerService) queuePrayer(ctx context.Context, pryr domain.Prayer) error {
 	id, err := generateID()
 	if err != nil {
 		return err
 	}
 
-	pryr := domain.Prayer{
-		IntercessorPhone: id,
-		Request:          msg.Body,
-		Requestor:        mem,
+	pryr.IntercessorPhone = id
+	if err = s.prayers.Save(ctx, &pryr, true); err != nil {
+		return err
+	}
+
+	return s.sender.SendMessage(ctx, pryr.Requestor.Phone, messaging.MsgPrayerQueued)
+}
+
+// holdForReview saves pending for an admin to review, tells the requestor that their request is being reviewed and
+// asks every admin who can moderate to approve, edit or reject it.
+func (s *PrayerService) holdForReview(ctx context.Context, pending domain.PendingPrayer) error {
+	if err := s.createPending(ctx, &pending); err != nil {
+		return err
 	}
+	slog.InfoContext(ctx, "prayer request held for review", "id", pending.ID, "requestor", pending.Requestor.Phone,
+		"rule", pending.Rule)
 
-	if err = s.prayers.Save(ctx, &pryr, true); err != nil {
+	if err := s.sender.SendMessage(ctx, pending.Requestor.Phone, messaging.MsgPrayerPendingReview); err != nil {
+		return err
+	}
+
+	notice, err := messaging.Render(messaging.ModerationNoticeTmpl, pending)
+	if err != nil {
+		return err
+	}
+	return s.notifyModerators(ctx, notice)
+}
+
+// notifyModerators texts body to every signed up admin who can moderate.
+func (s *PrayerService) notifyModerators(ctx context.Context, body string) error {
+	members, err := s.members.GetAll(ctx)
+	if err != nil {
+		return apperr.WrapError(err, "failed to get members")
+	}
+
+	notified := 0
+	for _, mem := range members {
+		if !mem.Role.Can(domain.PermModerate) || mem.SetupStatus != domain.MemberSetupComplete {
+			continue
+		}
+		if err = s.sender.SendMessage(ctx, mem.Phone, body); err != nil {
+			return err
+		}
+		notified++
+	}
+	if notified == 0 {
+		slog.WarnContext(ctx, "there are no admins who can moderate to notify")
+	}
+
+	return nil
+}
+
+// createPending saves pending under a new short ID, retrying when the ID is already in use.
+func (s *PrayerService) createPending(ctx context.Context, pending *domain.PendingPrayer) error {
+	const attempts = 5
+
+	var err error
+	for range attempts {
+		pending.ID, err = generateShortID()
+		if err != nil {
+			return err
+		}
+		err = s.pending.Create(ctx, pending)
+		if !errors.Is(err, repository.ErrItemExists) {
+			return err
+		}
+	}
+	return apperr.WrapError(err, "failed to create pending prayer")
+}
+
+// Approve sends the pending prayer with id to intercessors. When request is not empty it replaces the original request,
+// so that an admin can remove sensitive details first. It returns ErrPrayerNotFound when there is no such prayer.
+//
+// The pending prayer is only deleted once it has been sent, so that a failed send leaves it in place to approve again.
+// A failed delete after that is logged rather than returned, since the prayer has already gone out.
+func (s *PrayerService) Approve(ctx context.Context, id, request string) error {
+	pending, err := s.getPending(ctx, id)
+	if err != nil {
+		return err
+	}
+	if request != "" {
+		pending.Request = request
+	}
+
+	if err = s.submit(ctx, domain.Prayer{
+		Request:     pending.Request,
+		RequestDate: pending.RequestDate,
+		Requestor:   pending.Requestor,
+		Priority:    pending.Priority,
+	}); err != nil {
+		return err
+	}
+
+	if err = s.pending.Delete(ctx, pending.ID); err != nil {
+		apperr.LogError(ctx, err, "failed to delete approved pending prayer", "id", pending.ID)
+	}
+	return nil
+}
+
+// Reject deletes the pending prayer with id and tells the requestor, giving reason when it is not empty. It returns
+// ErrPrayerNotFound when there is no such prayer.
+func (s *PrayerService) Reject(ctx context.Context, id, reason string) error {
+	pending, err := s.getPending(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.pending.Delete(ctx, pending.ID); err != nil {
+		return err
+	}
+
+	body, err := messaging.Render(messaging.PrayerRejectedTmpl, struct{ Reason string }{reason})
+	if err != nil {
 		return err
 	}
+	return s.sender.SendMessage(ctx, pending.Requestor.Phone, body)
+}
 
-	return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgPrayerQueued)
+func (s *PrayerService) getPending(ctx context.Context, id string) (*domain.PendingPrayer, error) {
+	pending, err := s.pending.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pending.Request == "" {
+		return nil, ErrPrayerNotFound
+	}
+	return pending, nil
 }
 
 func (s *PrayerService) Complete(ctx context.Context, mem domain.Member) error {
@@ -250,9 +470,111 @@ func (s *PrayerService) Complete(ctx context.Context, mem domain.Member) error {
 			"body", confirmMsg)
 	}
 
+	if err = s.recordCompleted(ctx, *pryr); err != nil {
+		return err
+	}
+
 	return s.prayers.Delete(ctx, mem.Phone, false)
 }
 
+// recordCompleted adds pryr to the prayer history that reporting is built on.
+func (s *PrayerService) recordCompleted(ctx context.Context, pryr domain.Prayer) error {
+	id, err := generateID()
+	if err != nil {
+		return err
+	}
+
+	return s.completed.Save(ctx, &domain.CompletedPrayer{
+		ID:               id,
+		IntercessorPhone: pryr.IntercessorPhone,
+		IntercessorName:  pryr.Intercessor.Name,
+		RequestorPhone:   pryr.Requestor.Phone,
+		RequestDate:      pryr.RequestDate,
+		CompletedDate:    time.Now().Format(time.RFC3339),
+		ReminderCount:    pryr.ReminderCount,
+	})
+}
+
+// Prayers returns every active and queued prayer.
+func (s *PrayerService) Prayers(ctx context.Context) ([]domain.Prayer, []domain.Prayer, error) {
+	active, err := s.prayers.GetAll(ctx, false)
+	if err != nil {
+		return nil, nil, apperr.WrapError(err, "failed to get active prayers")
+	}
+
+	queued, err := s.prayers.GetAll(ctx, true)
+	if err != nil {
+		return nil, nil, apperr.WrapError(err, "failed to get queued prayers")
+	}
+
+	return active, queued, nil
+}
+
+// Reassign takes the active prayer away from intercessorPhone and gives it to another available intercessor. When no
+// other intercessor is available the prayer is queued instead. It returns the phone of the new intercessor, or an
+// empty string when the prayer was queued.
+func (s *PrayerService) Reassign(ctx context.Context, intercessorPhone string) (string, error) {
+	pryr, err := s.prayers.Get(ctx, intercessorPhone, false)
+	if err != nil {
+		return "", err
+	}
+	if pryr.Request == "" {
+		return "", ErrPrayerNotFound
+	}
+
+	// The current intercessor still has this prayer active, so they are never picked again.
+	intercessors, err := s.findIntercessors(ctx, pryr.Requestor.Phone, 1)
+	if err != nil && !errors.Is(err, ErrNoAvailableIntercessors) {
+		return "", apperr.WrapError(err, "failed to find intercessors")
+	}
+
+	if err = s.prayers.Delete(ctx, intercessorPhone, false); err != nil {
+		return "", err
+	}
+	if err = s.sender.SendMessage(ctx, intercessorPhone, messaging.MsgPrayerReassigned); err != nil {
+		return "", err
+	}
+
+	next := domain.Prayer{
+		Priority:    pryr.Priority,
+		Request:     pryr.Request,
+		RequestDate: pryr.RequestDate,
+		Requestor:   pryr.Requestor,
+	}
+	if len(intercessors) == 0 {
+		slog.WarnContext(ctx, "no intercessors available, queueing reassigned prayer",
+			"requestor", pryr.Requestor.Phone)
+		next.IntercessorPhone, err = generateID()
+		if err != nil {
+			return "", err
+		}
+		return "", s.prayers.Save(ctx, &next, true)
+	}
+
+	return intercessors[0].Phone, s.AssignPrayer(ctx, next, intercessors[0])
+}
+
+// Cancel deletes a prayer. key is the intercessor phone of an active prayer or the ID of a queued prayer. The
+// intercessor of an active prayer is told that they no longer need to pray for it.
+func (s *PrayerService) Cancel(ctx context.Context, key string, queued bool) error {
+	pryr, err := s.prayers.Get(ctx, key, queued)
+	if err != nil {
+		return err
+	}
+	if pryr.Request == "" {
+		return ErrPrayerNotFound
+	}
+
+	if err = s.prayers.Delete(ctx, key, queued); err != nil {
+		return err
+	}
+
+	if queued {
+		return nil
+	}
+	return s.sender.SendMessage(ctx, key, messaging.MsgPrayerCancelled)
+}
+
 func (s *PrayerService) RunScheduledJobs(ctx context.Context) {
 	if err := s.AssignQueuedPrayers(ctx); err != nil {
 		apperr.LogError(ctx, err, "failed job", "job", "Assign Queued Prayers")
@@ -265,13 +587,84 @@ func (s *PrayerService) RunScheduledJobs(ctx context.Context) {
 	} else {
 		slog.InfoContext(ctx, "finished job", "job", "Remind Intercessors with Active Prayers")
 	}
+
+	now := time.Now()
+	if !s.weeklyReportDue(now) {
+		return
+	}
+	if err := s.SendWeeklyReport(ctx, now); err != nil {
+		apperr.LogError(ctx, err, "failed job", "job", "Send Weekly Report")
+	} else {
+		slog.InfoContext(ctx, "finished job", "job", "Send Weekly Report")
+	}
+}
+
+// weeklyReportDue reports whether now falls in the hour that the weekly report is configured to go out.
+func (s *PrayerService) weeklyReportDue(now time.Time) bool {
+	now = now.UTC()
+	return s.cfg.WeeklyReport.Day != "" && strings.EqualFold(now.Weekday().String(), s.cfg.WeeklyReport.Day) &&
+		now.Hour() == s.cfg.WeeklyReport.Hour
 }
 
+// SendWeeklyReport texts every administrator a summary of the week that ended at now.
+func (s *PrayerService) SendWeeklyReport(ctx context.Context, now time.Time) error {
+	members, err := s.members.GetAll(ctx)
+	if err != nil {
+		return apperr.WrapError(err, "failed to get members")
+	}
+	active, err := s.prayers.GetAll(ctx, false)
+	if err != nil {
+		return apperr.WrapError(err, "failed to get active prayers")
+	}
+	queued, err := s.prayers.GetAll(ctx, true)
+	if err != nil {
+		return apperr.WrapError(err, "failed to get queued prayers")
+	}
+	completed, err := s.completed.GetAll(ctx)
+	if err != nil {
+		return apperr.WrapError(err, "failed to get completed prayers")
+	}
+
+	summary := weeklySummary(members, active, queued, completed, now)
+	medianTime := "n/a"
+	if summary.Completed > 0 {
+		medianTime = messaging.FormatAge(summary.MedianTimeToPrayed)
+	}
+	body, err := messaging.Render(messaging.WeeklyReportTmpl, struct {
+		NewMembers, Requests, Completed, Queued int
+		MedianTimeToPrayed                      string
+	}{summary.NewMembers, summary.Requests, summary.Completed, summary.Queued, medianTime})
+	if err != nil {
+		return err
+	}
+
+	for _, mem := range members {
+		if !mem.Role.Can(domain.PermView) || mem.SetupStatus != domain.MemberSetupComplete {
+			continue
+		}
+		if err = s.sender.SendMessage(ctx, mem.Phone, body); err != nil {
+			return apperr.WrapError(err, "failed to send weekly report")
+		}
+	}
+
+	return nil
+}
+
+// AssignQueuedPrayers gives queued prayers to available intercessors, priority prayers first and then oldest first.
 func (s *PrayerService) AssignQueuedPrayers(ctx context.Context) error {
 	prayers, err := s.prayers.GetAll(ctx, true)
 	if err != nil {
 		return apperr.WrapError(err, "failed to get queued prayers")
 	}
+	slices.SortStableFunc(prayers, func(a, b domain.Prayer) int {
+		if a.Priority != b.Priority {
+			if a.Priority {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.RequestDate, b.RequestDate)
+	})
 
 	for _, pryr := range prayers {
 		var intercessors []domain.Member
@@ -338,7 +731,7 @@ func (s *PrayerService) RemindActiveIntercessors(ctx context.Context) error {
 			if err != nil {
 				return err
 			}
-			msg := reminderMsg + pryr.Request + "\n\n" + messaging.MsgPrayed
+			msg := reminderMsg + s.forIntercessor(pryr.Request) + "\n\n" + messaging.MsgPrayed
 			if err = s.sender.SendMessage(ctx, pryr.Intercessor.Phone, msg); err != nil {
 				return err
 			}
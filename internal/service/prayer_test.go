@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/4JesusApps/prayertexter/internal/config"
 	"github.com/4JesusApps/prayertexter/internal/domain"
 	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/4JesusApps/prayertexter/internal/repository"
 	"github.com/4JesusApps/prayertexter/internal/service"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -23,6 +25,9 @@ type PrayerServiceSuite struct {
 	members      *repomocks.MockMemberRepository
 	intercessors *repomocks.MockIntercessorPhonesRepository
 	prayers      *repomocks.MockPrayerRepository
+	completed    *repomocks.MockCompletedPrayerRepository
+	pending      *repomocks.MockPendingPrayerRepository
+	history      *repomocks.MockRequestHistoryRepository
 	sender       *msgmocks.MockMessageSender
 	ctx          context.Context
 }
@@ -31,12 +36,38 @@ func (s *PrayerServiceSuite) SetupTest() {
 	s.members = repomocks.NewMockMemberRepository(s.T())
 	s.intercessors = repomocks.NewMockIntercessorPhonesRepository(s.T())
 	s.prayers = repomocks.NewMockPrayerRepository(s.T())
+	s.completed = repomocks.NewMockCompletedPrayerRepository(s.T())
+	s.pending = repomocks.NewMockPendingPrayerRepository(s.T())
+	s.history = repomocks.NewMockRequestHistoryRepository(s.T())
 	s.sender = msgmocks.NewMockMessageSender(s.T())
 	s.ctx = context.Background()
-	s.svc = service.NewPrayerService(s.members, s.intercessors, s.prayers, s.sender, config.Config{
-		IntercessorsPerPrayer: 2,
-		PrayerReminderHours:   3,
-	})
+	s.svc = service.NewPrayerService(
+		s.members, s.intercessors, s.prayers, s.completed, s.pending, s.history, s.sender,
+		messaging.NewProfanity(config.ProfanityConfig{}, domain.ProfanityLists{}), config.Config{
+			IntercessorsPerPrayer: 2,
+			Crisis:                config.CrisisConfig{Keywords: []string{"want to die"}, Message: "call 988"},
+			Moderation:            config.ModerationConfig{Keywords: []string{"self harm"}},
+			PrayerReminderHours:   3,
+		},
+	)
+}
+
+// expectRecorded expects phn's request history to be read and the request to be recorded in it.
+func (s *PrayerServiceSuite) expectRecorded(phn string) {
+	s.history.EXPECT().Get(s.ctx, phn).Return(&domain.RequestHistory{}, nil)
+	s.history.EXPECT().Save(s.ctx, mock.MatchedBy(func(h *domain.RequestHistory) bool {
+		return h.Phone == phn && len(h.Requests) == 1
+	})).Return(nil)
+}
+
+// throttledService returns a service that allows 2 requests a day, refuses duplicates and escalates after 2 refusals.
+func (s *PrayerServiceSuite) throttledService() *service.PrayerService {
+	return service.NewPrayerService(s.members, s.intercessors, s.prayers, s.completed, s.pending, s.history, s.sender,
+		messaging.NewProfanity(config.ProfanityConfig{}, domain.ProfanityLists{}), config.Config{
+			Throttle: config.ThrottleConfig{
+				DailyRequests: 2, WeeklyRequests: 10, DuplicateSimilarity: 0.8, EscalateAfter: 2,
+			},
+		})
 }
 
 func (s *PrayerServiceSuite) TestComplete_NoActivePrayer() {
@@ -48,8 +79,8 @@ func (s *PrayerServiceSuite) TestComplete_NoActivePrayer() {
 }
 
 func (s *PrayerServiceSuite) TestComplete_WithActivePrayer() {
-	requestor := domain.Member{Phone: "+19999999999", Name: "Requestor"}
-	intercessor := domain.Member{Phone: "+11234567890", Name: "Intercessor"}
+	requestor := domain.MemberRef{Phone: "+19999999999", Name: "Requestor"}
+	intercessor := domain.MemberRef{Phone: "+11234567890", Name: "Intercessor"}
 
 	s.prayers.EXPECT().Get(s.ctx, "+11234567890", false).Return(&domain.Prayer{
 		Request:          "Please pray for me",
@@ -61,9 +92,13 @@ func (s *PrayerServiceSuite) TestComplete_WithActivePrayer() {
 	s.members.EXPECT().Exists(s.ctx, "+19999999999").Return(true, nil)
 	confirmMsg, _ := messaging.Render(messaging.PrayerConfirmationTmpl, struct{ Name string }{"Intercessor"})
 	s.sender.EXPECT().SendMessage(s.ctx, "+19999999999", confirmMsg).Return(nil)
+	s.completed.EXPECT().Save(s.ctx, mock.MatchedBy(func(c *domain.CompletedPrayer) bool {
+		return c.ID != "" && c.IntercessorPhone == "+11234567890" && c.IntercessorName == "Intercessor" &&
+			c.RequestorPhone == "+19999999999" && c.CompletedDate != ""
+	})).Return(nil)
 	s.prayers.EXPECT().Delete(s.ctx, "+11234567890", false).Return(nil)
 
-	err := s.svc.Complete(s.ctx, intercessor)
+	err := s.svc.Complete(s.ctx, domain.Member{Phone: intercessor.Phone, Name: intercessor.Name})
 	s.NoError(err)
 }
 
@@ -76,6 +111,7 @@ func (s *PrayerServiceSuite) TestRequest_InvalidRequest() {
 }
 
 func (s *PrayerServiceSuite) TestRequest_Queued() {
+	s.expectRecorded("+11234567890")
 	s.intercessors.EXPECT().Get(s.ctx).Return(&domain.IntercessorPhones{Phones: []string{}}, nil)
 	s.prayers.EXPECT().Save(s.ctx, mock.MatchedBy(func(p *domain.Prayer) bool {
 		return p.Request == "please pray for my health and well being today" &&
@@ -93,6 +129,201 @@ func (s *PrayerServiceSuite) TestRequest_Queued() {
 	s.NoError(err)
 }
 
+func (s *PrayerServiceSuite) TestRequest_HeldForReview() {
+	s.expectRecorded("+11234567890")
+	request := "please pray for me, I keep thinking about self-harm"
+	s.pending.EXPECT().Create(s.ctx, mock.MatchedBy(func(p *domain.PendingPrayer) bool {
+		return len(p.ID) == 6 && p.Request == request && p.Rule == "self harm" && p.Requestor.Phone == "+11234567890"
+	})).Return(repository.ErrItemExists).Once()
+	s.pending.EXPECT().Create(s.ctx, mock.Anything).Return(nil).Once()
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgPrayerPendingReview).Return(nil)
+	s.members.EXPECT().GetAll(s.ctx).Return([]domain.Member{
+		{Phone: "+12222222222", SetupStatus: domain.MemberSetupComplete, Role: domain.RoleModerator},
+		{Phone: "+13333333333", SetupStatus: domain.MemberSetupComplete},
+	}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+12222222222", mock.MatchedBy(func(body string) bool {
+		return strings.Contains(body, `matched "self harm"`) && strings.Contains(body, request) &&
+			strings.Contains(body, "#approve ")
+	})).Return(nil)
+
+	mem := domain.Member{Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete}
+	s.NoError(s.svc.Request(s.ctx, domain.TextMessage{Body: request, Phone: "+11234567890"}, mem))
+}
+
+func (s *PrayerServiceSuite) TestRequest_Crisis() {
+	s.expectRecorded("+11234567890")
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", "call 988").Return(nil)
+	s.members.EXPECT().GetAll(s.ctx).Return([]domain.Member{
+		{Phone: "+12222222222", SetupStatus: domain.MemberSetupComplete, Role: domain.RoleOwner},
+	}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+12222222222", mock.MatchedBy(func(body string) bool {
+		return strings.HasPrefix(body, "Crisis alert") && strings.Contains(body, "I want to die") &&
+			strings.Contains(body, "It is being sent to intercessors first.")
+	})).Return(nil)
+	// Crisis requests are not refused for being short.
+	s.intercessors.EXPECT().Get(s.ctx).Return(&domain.IntercessorPhones{Phones: []string{}}, nil)
+	s.prayers.EXPECT().Save(s.ctx, mock.MatchedBy(func(p *domain.Prayer) bool {
+		return p.Priority && p.Request == "I want to die"
+	}), true).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgPrayerQueued).Return(nil)
+
+	mem := domain.Member{Phone: "+11234567890", Name: "Ann", SetupStatus: domain.MemberSetupComplete}
+	s.NoError(s.svc.Request(s.ctx, domain.TextMessage{Body: "I want to die", Phone: "+11234567890"}, mem))
+}
+
+func (s *PrayerServiceSuite) TestRequest_CrisisHeldForReview() {
+	s.expectRecorded("+11234567890")
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", "call 988").Return(nil)
+	s.members.EXPECT().GetAll(s.ctx).Return([]domain.Member{
+		{Phone: "+12222222222", SetupStatus: domain.MemberSetupComplete, Role: domain.RoleOwner},
+	}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+12222222222", mock.MatchedBy(func(body string) bool {
+		return strings.HasPrefix(body, "Crisis alert") && strings.Contains(body, "It is waiting for review")
+	})).Return(nil).Once()
+	// Moderation still applies to crisis requests, but they are marked urgent.
+	s.pending.EXPECT().Create(s.ctx, mock.MatchedBy(func(p *domain.PendingPrayer) bool {
+		return p.Priority && p.Rule == "self harm" && p.Request == "I want to die, self harm"
+	})).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgPrayerPendingReview).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+12222222222", mock.MatchedBy(func(body string) bool {
+		return strings.HasPrefix(body, "URGENT: A prayer request from Ann")
+	})).Return(nil).Once()
+
+	mem := domain.Member{Phone: "+11234567890", Name: "Ann", SetupStatus: domain.MemberSetupComplete}
+	s.NoError(s.svc.Request(s.ctx, domain.TextMessage{Body: "I want to die, self harm", Phone: "+11234567890"}, mem))
+}
+
+func (s *PrayerServiceSuite) TestRequest_CrisisOnCall() {
+	svc := service.NewPrayerService(s.members, s.intercessors, s.prayers, s.completed, s.pending, s.history, s.sender,
+		messaging.NewProfanity(config.ProfanityConfig{}, domain.ProfanityLists{}), config.Config{
+			Crisis: config.CrisisConfig{
+				Keywords: []string{"suicide"}, Message: "call 988", OnCall: []string{"213-373-4253"},
+			},
+			PhoneRegion: "US",
+		})
+	s.expectRecorded("+11234567890")
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", "call 988").Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+12133734253", mock.Anything).Return(errors.New("failed"))
+	// Profanity does not turn a crisis request away.
+	s.intercessors.EXPECT().Get(s.ctx).Return(&domain.IntercessorPhones{Phones: []string{}}, nil).Maybe()
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgPrayerAssigned).Return(nil)
+
+	mem := domain.Member{Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete}
+	s.NoError(svc.Request(s.ctx, domain.TextMessage{Body: "thinking about suicide, shit", Phone: "+11234567890"}, mem))
+}
+
+func (s *PrayerServiceSuite) TestRequest_DailyLimit() {
+	svc := s.throttledService()
+	recent := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	s.history.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.RequestHistory{
+		Requests: []domain.RequestRecord{
+			{Date: recent, Text: "pray for my job interview today please"},
+			{Date: recent, Text: "pray for my sister and her new baby"},
+		},
+	}, nil)
+	s.history.EXPECT().Save(s.ctx, mock.MatchedBy(func(h *domain.RequestHistory) bool {
+		return len(h.Requests) == 2 && len(h.Strikes) == 1
+	})).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890",
+		"Sorry, you can only send 2 prayer requests per day. Please try again later.").Return(nil)
+
+	mem := domain.Member{Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete}
+	msg := domain.TextMessage{Body: "please pray for my health and well being today", Phone: "+11234567890"}
+	s.NoError(svc.Request(s.ctx, msg, mem))
+}
+
+func (s *PrayerServiceSuite) TestRequest_Duplicate() {
+	svc := s.throttledService()
+	s.history.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.RequestHistory{
+		Requests: []domain.RequestRecord{{
+			Date: time.Now().Add(-48 * time.Hour).Format(time.RFC3339),
+			Text: "Please pray for my health and well being today!",
+		}},
+		Strikes: []string{"not a time"},
+	}, nil)
+	s.history.EXPECT().Save(s.ctx, mock.MatchedBy(func(h *domain.RequestHistory) bool {
+		return len(h.Requests) == 1 && len(h.Strikes) == 1
+	})).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgDuplicateRequest).Return(nil)
+
+	mem := domain.Member{Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete}
+	msg := domain.TextMessage{Body: "please pray for my health and well being today", Phone: "+11234567890"}
+	s.NoError(svc.Request(s.ctx, msg, mem))
+}
+
+func (s *PrayerServiceSuite) TestRequest_ThrottleEscalates() {
+	svc := s.throttledService()
+	now := time.Now()
+	s.history.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.RequestHistory{
+		Requests: []domain.RequestRecord{{
+			Date: now.Add(-time.Hour).Format(time.RFC3339),
+			Text: "please pray for my health and well being today",
+		}},
+		Strikes: []string{
+			now.Add(-8 * 24 * time.Hour).Format(time.RFC3339),
+			now.Add(-time.Hour).Format(time.RFC3339),
+		},
+	}, nil)
+	s.history.EXPECT().Save(s.ctx, mock.MatchedBy(func(h *domain.RequestHistory) bool {
+		return len(h.Strikes) == 0
+	})).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgDuplicateRequest).Return(nil)
+	s.members.EXPECT().GetAll(s.ctx).Return([]domain.Member{
+		{Phone: "+12222222222", SetupStatus: domain.MemberSetupComplete, Role: domain.RoleModerator},
+	}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+12222222222", mock.MatchedBy(func(body string) bool {
+		return strings.HasPrefix(body, "Throttle alert: Ann (+11234567890) has had 2 prayer requests refused")
+	})).Return(nil)
+
+	mem := domain.Member{Phone: "+11234567890", Name: "Ann", SetupStatus: domain.MemberSetupComplete}
+	msg := domain.TextMessage{Body: "please pray for my health and well being today", Phone: "+11234567890"}
+	s.NoError(svc.Request(s.ctx, msg, mem))
+}
+
+func (s *PrayerServiceSuite) TestApprove_Edited() {
+	requestDate := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	s.pending.EXPECT().Get(s.ctx, "1a2b3c").Return(&domain.PendingPrayer{
+		ID: "1a2b3c", Request: "original", RequestDate: requestDate, Requestor: domain.MemberRef{Phone: "+11234567890"},
+		Priority: true,
+	}, nil)
+	s.pending.EXPECT().Delete(s.ctx, "1a2b3c").Return(nil)
+	s.intercessors.EXPECT().Get(s.ctx).Return(&domain.IntercessorPhones{Phones: []string{}}, nil)
+	s.prayers.EXPECT().Save(s.ctx, mock.MatchedBy(func(p *domain.Prayer) bool {
+		return p.Request == "please pray for my friend" && p.RequestDate == requestDate && p.Priority
+	}), true).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgPrayerQueued).Return(nil)
+
+	s.NoError(s.svc.Approve(s.ctx, "1a2b3c", "please pray for my friend"))
+}
+
+func (s *PrayerServiceSuite) TestApprove_SendFails() {
+	s.pending.EXPECT().Get(s.ctx, "1a2b3c").Return(&domain.PendingPrayer{
+		ID: "1a2b3c", Request: "original", Requestor: domain.MemberRef{Phone: "+11234567890"},
+	}, nil)
+	s.intercessors.EXPECT().Get(s.ctx).Return(nil, errors.New("table missing"))
+
+	// The pending prayer is kept so that it can be approved again.
+	s.Error(s.svc.Approve(s.ctx, "1a2b3c", ""))
+	s.pending.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (s *PrayerServiceSuite) TestReject() {
+	s.pending.EXPECT().Get(s.ctx, "1a2b3c").Return(&domain.PendingPrayer{
+		ID: "1a2b3c", Request: "original", Requestor: domain.MemberRef{Phone: "+11234567890"},
+	}, nil)
+	s.pending.EXPECT().Delete(s.ctx, "1a2b3c").Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890",
+		"Your prayer request was reviewed and could not be sent to intercessors. Reason: please call us").Return(nil)
+
+	s.NoError(s.svc.Reject(s.ctx, "1a2b3c", "please call us"))
+}
+
+func (s *PrayerServiceSuite) TestApprove_NotFound() {
+	s.pending.EXPECT().Get(s.ctx, "1a2b3c").Return(&domain.PendingPrayer{}, nil)
+
+	s.ErrorIs(s.svc.Approve(s.ctx, "1a2b3c", ""), service.ErrPrayerNotFound)
+}
+
 func (s *PrayerServiceSuite) TestFindIntercessors_UnderLimit() {
 	s.intercessors.EXPECT().Get(s.ctx).Return(&domain.IntercessorPhones{
 		Phones: []string{"+18888888888", "+19999999999"},
@@ -180,6 +411,7 @@ func (s *PrayerServiceSuite) TestFindIntercessors_AtLimit_NotResetEligible() {
 }
 
 func (s *PrayerServiceSuite) TestRequest_WithAnon() {
+	s.expectRecorded("+11234567890")
 	s.intercessors.EXPECT().Get(s.ctx).Return(&domain.IntercessorPhones{
 		Phones: []string{"+18888888888", "+19999999999"},
 	}, nil)
@@ -201,7 +433,7 @@ func (s *PrayerServiceSuite) TestRequest_WithAnon() {
 	s.prayers.EXPECT().Save(s.ctx, mock.MatchedBy(func(p *domain.Prayer) bool {
 		return p.Requestor.Name == "Anonymous" && !strings.Contains(p.Request, "#anon")
 	}), false).Return(nil).Times(2)
-	introMsg, _ := messaging.Render(messaging.PrayerIntroTmpl, struct{ Name string }{"Anonymous"})
+	introMsg := "Hello! Please pray for Anonymous:\n\n"
 	expectedPrayerMsg := introMsg + "please pray for my family and friends" + "\n\n" + messaging.MsgPrayed
 	s.sender.EXPECT().SendMessage(s.ctx, "+18888888888", expectedPrayerMsg).Return(nil)
 	s.sender.EXPECT().SendMessage(s.ctx, "+19999999999", expectedPrayerMsg).Return(nil)
@@ -220,7 +452,7 @@ func (s *PrayerServiceSuite) TestAssignQueuedPrayers_Success() {
 	queuedPrayer := domain.Prayer{
 		IntercessorPhone: "queue-id-123",
 		Request:          "please pray for me and my family today",
-		Requestor:        domain.Member{Phone: "+11234567890", Name: "Requestor"},
+		Requestor:        domain.MemberRef{Phone: "+11234567890", Name: "Requestor"},
 	}
 
 	s.prayers.EXPECT().GetAll(s.ctx, true).Return([]domain.Prayer{queuedPrayer}, nil)
@@ -247,7 +479,7 @@ func (s *PrayerServiceSuite) TestAssignQueuedPrayers_Success() {
 		return p.Request == "please pray for me and my family today" &&
 			p.Requestor.Phone == "+11234567890"
 	}), false).Return(nil).Times(2)
-	introMsg, _ := messaging.Render(messaging.PrayerIntroTmpl, struct{ Name string }{"Requestor"})
+	introMsg := "Hello! Please pray for Requestor:\n\n"
 	expectedPrayerMsg := introMsg + "please pray for me and my family today" + "\n\n" + messaging.MsgPrayed
 	s.sender.EXPECT().SendMessage(s.ctx, "+18888888888", expectedPrayerMsg).Return(nil)
 	s.sender.EXPECT().SendMessage(s.ctx, "+19999999999", expectedPrayerMsg).Return(nil)
@@ -259,6 +491,28 @@ func (s *PrayerServiceSuite) TestAssignQueuedPrayers_Success() {
 	s.NoError(err)
 }
 
+func (s *PrayerServiceSuite) TestAssignQueuedPrayers_PriorityFirst() {
+	s.prayers.EXPECT().GetAll(s.ctx, true).Return([]domain.Prayer{
+		{IntercessorPhone: "old", Request: "old", RequestDate: "2026-10-01T00:00:00Z"},
+		{IntercessorPhone: "urgent", Request: "urgent", RequestDate: "2026-10-02T00:00:00Z", Priority: true},
+	}, nil)
+	s.intercessors.EXPECT().Get(s.ctx).Return(&domain.IntercessorPhones{Phones: []string{"+18888888888"}}, nil)
+	s.members.EXPECT().Get(s.ctx, "+18888888888").Return(&domain.Member{
+		Phone: "+18888888888", WeeklyPrayerLimit: 5,
+	}, nil)
+	s.prayers.EXPECT().Exists(s.ctx, "+18888888888").Return(false, nil)
+	s.members.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
+	s.prayers.EXPECT().Save(s.ctx, mock.MatchedBy(func(p *domain.Prayer) bool { return p.Request == "urgent" }), false).
+		Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+18888888888", mock.MatchedBy(func(body string) bool {
+		return strings.HasPrefix(body, "URGENT: ")
+	})).Return(nil)
+	s.prayers.EXPECT().Delete(s.ctx, "urgent", true).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "", messaging.MsgPrayerAssigned).Return(nil)
+
+	s.NoError(s.svc.AssignQueuedPrayers(s.ctx))
+}
+
 func (s *PrayerServiceSuite) TestRemindActiveIntercessors() {
 	oldDate := time.Now().Add(-4 * time.Hour).Format(time.RFC3339)
 	recentDate := time.Now().Add(-1 * time.Hour).Format(time.RFC3339)
@@ -267,21 +521,21 @@ func (s *PrayerServiceSuite) TestRemindActiveIntercessors() {
 		{
 			IntercessorPhone: "+18888888888",
 			Request:          "prayer 1",
-			Requestor:        domain.Member{Name: "R1"},
-			Intercessor:      domain.Member{Phone: "+18888888888"},
+			Requestor:        domain.MemberRef{Name: "R1"},
+			Intercessor:      domain.MemberRef{Phone: "+18888888888"},
 		},
 		{
 			IntercessorPhone: "+19999999999",
 			Request:          "prayer 2",
-			Requestor:        domain.Member{Name: "R2"},
-			Intercessor:      domain.Member{Phone: "+19999999999"},
+			Requestor:        domain.MemberRef{Name: "R2"},
+			Intercessor:      domain.MemberRef{Phone: "+19999999999"},
 			ReminderDate:     oldDate,
 		},
 		{
 			IntercessorPhone: "+17777777777",
 			Request:          "prayer 3",
-			Requestor:        domain.Member{Name: "R3"},
-			Intercessor:      domain.Member{Phone: "+17777777777"},
+			Requestor:        domain.MemberRef{Name: "R3"},
+			Intercessor:      domain.MemberRef{Phone: "+17777777777"},
 			ReminderDate:     recentDate,
 		},
 	}
@@ -303,6 +557,162 @@ func (s *PrayerServiceSuite) TestRemindActiveIntercessors() {
 	s.NoError(err)
 }
 
+func (s *PrayerServiceSuite) TestAssignPrayer_MasksProfanity() {
+	profanity := messaging.NewProfanity(config.ProfanityConfig{
+		Request: config.ProfanityPolicyConfig{Mode: "mask"},
+	}, domain.ProfanityLists{})
+	svc := service.NewPrayerService(s.members, s.intercessors, s.prayers, s.completed, s.pending, s.history, s.sender,
+		profanity, config.Config{})
+	s.prayers.EXPECT().Save(s.ctx, mock.MatchedBy(func(p *domain.Prayer) bool {
+		return p.Request == "this shit is hard"
+	}), false).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+18888888888",
+		"Hello! Please pray for Ann:\n\nthis **** is hard\n\n"+messaging.MsgPrayed).Return(nil)
+
+	pryr := domain.Prayer{Request: "this shit is hard", Requestor: domain.MemberRef{Name: "Ann"}}
+	s.NoError(svc.AssignPrayer(s.ctx, pryr, domain.Member{Phone: "+18888888888"}))
+}
+
+func (s *PrayerServiceSuite) TestAssignPrayer_RedactsContactDetails() {
+	svc := service.NewPrayerService(s.members, s.intercessors, s.prayers, s.completed, s.pending, s.history, s.sender,
+		messaging.NewProfanity(config.ProfanityConfig{}, domain.ProfanityLists{}),
+		config.Config{Redaction: config.RedactionConfig{Surnames: true}})
+	request := "please pray for John Smith, his number is 555-123-4567"
+	s.prayers.EXPECT().Save(s.ctx, mock.MatchedBy(func(p *domain.Prayer) bool {
+		return p.Request == request
+	}), false).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+18888888888",
+		"Hello! Please pray for Ann:\n\nplease pray for John S., his number is [phone]\n\n"+messaging.MsgPrayed).
+		Return(nil)
+
+	pryr := domain.Prayer{Request: request, Requestor: domain.MemberRef{Name: "Ann"}}
+	s.NoError(svc.AssignPrayer(s.ctx, pryr, domain.Member{Phone: "+18888888888"}))
+}
+
+func (s *PrayerServiceSuite) TestReassign_ToAnotherIntercessor() {
+	requestor := domain.MemberRef{Phone: "+19999999999", Name: "Requestor"}
+	s.prayers.EXPECT().Get(s.ctx, "+11111111111", false).Return(&domain.Prayer{
+		IntercessorPhone: "+11111111111", Request: "please pray for my job", Requestor: requestor,
+	}, nil)
+	s.intercessors.EXPECT().Get(s.ctx).Return(&domain.IntercessorPhones{
+		Phones: []string{"+11111111111", "+18888888888", "+19999999999"},
+	}, nil)
+	s.members.EXPECT().Get(s.ctx, "+11111111111").Return(&domain.Member{Phone: "+11111111111"}, nil).Maybe()
+	s.prayers.EXPECT().Exists(s.ctx, "+11111111111").Return(true, nil).Maybe()
+	s.members.EXPECT().Get(s.ctx, "+18888888888").Return(&domain.Member{
+		Phone: "+18888888888", WeeklyPrayerLimit: 5,
+	}, nil)
+	s.prayers.EXPECT().Exists(s.ctx, "+18888888888").Return(false, nil)
+	s.members.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
+	s.prayers.EXPECT().Delete(s.ctx, "+11111111111", false).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11111111111", messaging.MsgPrayerReassigned).Return(nil)
+	s.prayers.EXPECT().Save(s.ctx, mock.MatchedBy(func(p *domain.Prayer) bool {
+		return p.IntercessorPhone == "+18888888888" && p.Request == "please pray for my job"
+	}), false).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+18888888888", mock.Anything).Return(nil)
+
+	intercessor, err := s.svc.Reassign(s.ctx, "+11111111111")
+	s.Require().NoError(err)
+	s.Equal("+18888888888", intercessor)
+}
+
+func (s *PrayerServiceSuite) TestReassign_QueuesWhenNoneAvailable() {
+	s.prayers.EXPECT().Get(s.ctx, "+11111111111", false).Return(&domain.Prayer{
+		IntercessorPhone: "+11111111111", Request: "please pray for my job",
+		Requestor: domain.MemberRef{Phone: "+19999999999"},
+	}, nil)
+	s.intercessors.EXPECT().Get(s.ctx).Return(&domain.IntercessorPhones{Phones: []string{"+11111111111"}}, nil)
+	s.members.EXPECT().Get(s.ctx, "+11111111111").Return(&domain.Member{Phone: "+11111111111"}, nil)
+	s.prayers.EXPECT().Exists(s.ctx, "+11111111111").Return(true, nil)
+	s.prayers.EXPECT().Delete(s.ctx, "+11111111111", false).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11111111111", messaging.MsgPrayerReassigned).Return(nil)
+	s.prayers.EXPECT().Save(s.ctx, mock.MatchedBy(func(p *domain.Prayer) bool {
+		return p.IntercessorPhone != "" && p.IntercessorPhone != "+11111111111"
+	}), true).Return(nil)
+
+	intercessor, err := s.svc.Reassign(s.ctx, "+11111111111")
+	s.Require().NoError(err)
+	s.Empty(intercessor)
+}
+
+func (s *PrayerServiceSuite) TestReassign_NotFound() {
+	s.prayers.EXPECT().Get(s.ctx, "+11111111111", false).Return(&domain.Prayer{}, nil)
+
+	_, err := s.svc.Reassign(s.ctx, "+11111111111")
+	s.ErrorIs(err, service.ErrPrayerNotFound)
+}
+
+func (s *PrayerServiceSuite) TestCancel_Active() {
+	s.prayers.EXPECT().Get(s.ctx, "+11111111111", false).Return(&domain.Prayer{Request: "pray"}, nil)
+	s.prayers.EXPECT().Delete(s.ctx, "+11111111111", false).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11111111111", messaging.MsgPrayerCancelled).Return(nil)
+
+	s.NoError(s.svc.Cancel(s.ctx, "+11111111111", false))
+}
+
+func (s *PrayerServiceSuite) TestCancel_Queued() {
+	s.prayers.EXPECT().Get(s.ctx, "queue-id", true).Return(&domain.Prayer{Request: "pray"}, nil)
+	s.prayers.EXPECT().Delete(s.ctx, "queue-id", true).Return(nil)
+
+	s.NoError(s.svc.Cancel(s.ctx, "queue-id", true))
+}
+
+func (s *PrayerServiceSuite) TestSendWeeklyReport() {
+	now := time.Date(2026, time.October, 19, 16, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) string { return now.Add(-d).Format(time.RFC3339) }
+
+	s.members.EXPECT().GetAll(s.ctx).Return([]domain.Member{
+		{
+			Phone: "+11111111111", SetupStatus: domain.MemberSetupComplete, Role: domain.RoleOwner,
+			SignUpDate: ago(time.Hour),
+		},
+		{Phone: "+12222222222", SetupStatus: domain.MemberSetupComplete, SignUpDate: ago(10 * 24 * time.Hour)},
+		{Phone: "+13333333333", SetupStatus: domain.MemberSetupInProgress, Role: domain.RoleOwner},
+		{Phone: "+14444444444", SetupStatus: domain.MemberSetupComplete, Role: domain.RoleOwner},
+	}, nil)
+	// One request sent to two intercessors, one of whom has already prayed.
+	s.prayers.EXPECT().GetAll(s.ctx, false).Return([]domain.Prayer{
+		{
+			IntercessorPhone: "+12222222222", Requestor: domain.MemberRef{Phone: "+15555555555"},
+			RequestDate: ago(5 * time.Hour),
+		},
+	}, nil)
+	s.prayers.EXPECT().GetAll(s.ctx, true).Return([]domain.Prayer{
+		{IntercessorPhone: "queue-id", Requestor: domain.MemberRef{Phone: "+16666666666"}, RequestDate: ago(time.Hour)},
+	}, nil)
+	s.completed.EXPECT().GetAll(s.ctx).Return([]domain.CompletedPrayer{
+		{ID: "1", RequestorPhone: "+15555555555", RequestDate: ago(5 * time.Hour), CompletedDate: ago(4 * time.Hour)},
+		{ID: "2", RequestorPhone: "+17777777777", RequestDate: ago(50 * time.Hour), CompletedDate: ago(47 * time.Hour)},
+		{ID: "3", RequestorPhone: "+17777777777", RequestDate: ago(20 * 24 * time.Hour),
+			CompletedDate: ago(20*24*time.Hour - 2*time.Hour)},
+		{ID: "4", RequestorPhone: "+18888888888", RequestDate: ago(30 * 24 * time.Hour),
+			CompletedDate: ago(24 * time.Hour)},
+	}, nil)
+
+	expected, _ := messaging.Render(messaging.WeeklyReportTmpl, struct {
+		NewMembers, Requests, Completed, Queued int
+		MedianTimeToPrayed                      string
+	}{1, 3, 3, 1, "3h"})
+	s.sender.EXPECT().SendMessage(s.ctx, "+11111111111", expected).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+14444444444", expected).Return(nil)
+
+	s.NoError(s.svc.SendWeeklyReport(s.ctx, now))
+}
+
+func (s *PrayerServiceSuite) TestSendWeeklyReport_NothingCompleted() {
+	s.members.EXPECT().GetAll(s.ctx).Return([]domain.Member{
+		{Phone: "+11111111111", SetupStatus: domain.MemberSetupComplete, Role: domain.RoleOwner},
+	}, nil)
+	s.prayers.EXPECT().GetAll(s.ctx, false).Return(nil, nil)
+	s.prayers.EXPECT().GetAll(s.ctx, true).Return(nil, nil)
+	s.completed.EXPECT().GetAll(s.ctx).Return(nil, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11111111111", mock.MatchedBy(func(body string) bool {
+		return strings.Contains(body, "Median time to prayed: n/a")
+	})).Return(nil)
+
+	s.NoError(s.svc.SendWeeklyReport(s.ctx, time.Now()))
+}
+
 func TestPrayerServiceSuite(t *testing.T) {
 	suite.Run(t, new(PrayerServiceSuite))
 }
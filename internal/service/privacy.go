@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/apperr"
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/4JesusApps/prayertexter/internal/phone"
+	"github.com/4JesusApps/prayertexter/internal/repository"
+)
+
+// forgetConfirmWindow is how long after texting deletemydata a YES reply confirms it. MsgConfirmDeleteData tells people
+// this window.
+const forgetConfirmWindow = 10 * time.Minute
+
+// PrivacyService tells people what PrayerTexter stores about their phone and deletes it when asked.
+type PrivacyService struct {
+	members     repository.MemberRepository
+	prayers     repository.PrayerRepository
+	pending     repository.PendingPrayerRepository
+	completed   repository.CompletedPrayerRepository
+	history     repository.RequestHistoryRepository
+	transcripts repository.TranscriptRepository
+	deferred    repository.DeferredMessageRepository
+	receipts    repository.DeliveryReceiptRepository
+	sender      messaging.MessageSender
+	memberSvc   *MemberService
+	prayerSvc   *PrayerService
+	cfg         config.Config
+}
+
+func NewPrivacyService(
+	members repository.MemberRepository,
+	prayers repository.PrayerRepository,
+	pending repository.PendingPrayerRepository,
+	completed repository.CompletedPrayerRepository,
+	history repository.RequestHistoryRepository,
+	transcripts repository.TranscriptRepository,
+	deferred repository.DeferredMessageRepository,
+	receipts repository.DeliveryReceiptRepository,
+	sender messaging.MessageSender,
+	memberSvc *MemberService,
+	prayerSvc *PrayerService,
+	cfg config.Config,
+) *PrivacyService {
+	return &PrivacyService{
+		members:     members,
+		prayers:     prayers,
+		pending:     pending,
+		completed:   completed,
+		history:     history,
+		transcripts: transcripts,
+		deferred:    deferred,
+		receipts:    receipts,
+		sender:      sender,
+		memberSvc:   memberSvc,
+		prayerSvc:   prayerSvc,
+		cfg:         cfg,
+	}
+}
+
+// dataSummary is what MyData reports about a phone.
+type dataSummary struct {
+	Phone             string
+	Member            bool
+	Name              string
+	SignUpDate        string
+	Intercessor       bool
+	WeeklyPrayerLimit int
+	ConsentDate       string
+	Open              int
+	Recent            int
+	Completed         int
+	Messages          int
+	RetentionDays     int
+}
+
+// MyData texts phn a summary of the data stored about it: the member record, prayer requests that have not been prayed
+// for yet, requests sent in the last week, completed prayers and the conversation transcript.
+func (s *PrivacyService) MyData(ctx context.Context, phn string) error {
+	mem, err := s.members.Get(ctx, phn)
+	if err != nil {
+		return err
+	}
+	summary := dataSummary{
+		Phone:             phn,
+		Member:            mem.SetupStatus != "",
+		Name:              mem.Name,
+		SignUpDate:        mem.SignUpDate,
+		Intercessor:       mem.Intercessor,
+		WeeklyPrayerLimit: mem.WeeklyPrayerLimit,
+		ConsentDate:       mem.ConsentDate,
+		RetentionDays:     s.cfg.TranscriptRetentionDays,
+	}
+
+	if summary.Open, err = s.countOpenRequests(ctx, phn); err != nil {
+		return err
+	}
+
+	history, err := s.history.Get(ctx, phn)
+	if err != nil {
+		return err
+	}
+	summary.Recent = history.CountSince(time.Now().Add(-throttleWeek))
+
+	completed, err := s.completed.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, pryr := range completed {
+		if pryr.RequestorPhone == phn || pryr.IntercessorPhone == phn {
+			summary.Completed++
+		}
+	}
+
+	thread, err := s.transcripts.GetThread(ctx, phn)
+	if err != nil {
+		return err
+	}
+	summary.Messages = len(thread)
+
+	body, err := messaging.Render(messaging.MyDataTmpl, summary)
+	if err != nil {
+		return err
+	}
+	return s.sender.SendMessage(ctx, phn, body)
+}
+
+func (s *PrivacyService) countOpenRequests(ctx context.Context, phn string) (int, error) {
+	count := 0
+	for _, queued := range []bool{false, true} {
+		prayers, err := s.prayers.GetAll(ctx, queued)
+		if err != nil {
+			return 0, err
+		}
+		for _, pryr := range prayers {
+			if pryr.Requestor.Phone == phn {
+				count++
+			}
+		}
+	}
+
+	pending, err := s.pending.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, pryr := range pending {
+		if pryr.Requestor.Phone == phn {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// RequestForget asks phn to confirm that everything stored about it should be deleted. A YES reply within
+// forgetConfirmWindow confirms it; see ForgetRequested.
+func (s *PrivacyService) RequestForget(ctx context.Context, phn string) error {
+	return s.sender.SendMessage(ctx, phn, messaging.MsgConfirmDeleteData)
+}
+
+// ForgetRequested reports whether phn texted deletemydata within forgetConfirmWindow and has not texted anything but
+// YES since, so that a YES reply confirms it. It reads the conversation transcript, where every inbound message is
+// recorded before it is handled.
+func (s *PrivacyService) ForgetRequested(ctx context.Context, phn string) (bool, error) {
+	thread, err := s.transcripts.GetThread(ctx, phn)
+	if err != nil {
+		return false, err
+	}
+
+	for _, msg := range slices.Backward(thread) {
+		if msg.Direction != domain.DirectionInbound || cleanStr(msg.Body) == "yes" {
+			continue
+		}
+		var sent time.Time
+		if sent, err = time.Parse(time.RFC3339Nano, msg.Timestamp); err != nil {
+			return false, err
+		}
+		return cleanStr(msg.Body) == "deletemydata" && time.Since(sent) <= forgetConfirmWindow, nil
+	}
+	return false, nil
+}
+
+// Forget deletes everything stored about phn and then tells phn that it has been deleted. The member is removed as
+// MemberService.Delete would, their prayer requests that have not been prayed for are cancelled, their request
+// history, messages waiting to be sent to them, delivery receipts and conversation transcript are deleted, and
+// completed prayers keep only an anonymous ID in place of the phone so that reports still add up. The phone's consent
+// record and any audit log entries about it are kept, as carriers and admins rely on them, and so is the delivery
+// receipt of the final confirmation, which arrives after everything else is deleted.
+func (s *PrivacyService) Forget(ctx context.Context, phn string) error {
+	target, err := phone.Normalize(phn, s.cfg.PhoneRegion)
+	if err != nil {
+		return apperr.WrapError(ErrInvalidPhone, phn)
+	}
+
+	mem, err := s.members.Get(ctx, target)
+	if err != nil {
+		return err
+	}
+	if mem.Intercessor {
+		mem.Phone = target
+		if err = s.memberSvc.removeIntercessor(ctx, *mem); err != nil {
+			return err
+		}
+	}
+	if err = s.members.Delete(ctx, target); err != nil {
+		return err
+	}
+
+	if err = s.forgetRequests(ctx, target); err != nil {
+		return err
+	}
+	if err = s.anonymizeCompleted(ctx, target); err != nil {
+		return err
+	}
+	if err = s.history.Delete(ctx, target); err != nil {
+		return err
+	}
+	if err = s.forgetOutbound(ctx, target); err != nil {
+		return err
+	}
+
+	// The confirmation is sent before the transcript is deleted so that it is not left behind in the transcript.
+	if err = s.sender.SendMessage(ctx, target, messaging.MsgDataDeleted); err != nil {
+		return err
+	}
+	if err = s.transcripts.DeleteThread(ctx, target); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "deleted all data for phone", "phone", target)
+	return nil
+}
+
+// forgetRequests cancels every prayer request from phn that has not been prayed for yet, including those waiting for
+// review. Intercessors who were praying for one are told that it was cancelled.
+func (s *PrivacyService) forgetRequests(ctx context.Context, phn string) error {
+	for _, queued := range []bool{false, true} {
+		prayers, err := s.prayers.GetAll(ctx, queued)
+		if err != nil {
+			return err
+		}
+		for _, pryr := range prayers {
+			if pryr.Requestor.Phone != phn {
+				continue
+			}
+			if err = s.prayerSvc.Cancel(ctx, pryr.IntercessorPhone, queued); err != nil {
+				return err
+			}
+		}
+	}
+
+	pending, err := s.pending.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, pryr := range pending {
+		if pryr.Requestor.Phone != phn {
+			continue
+		}
+		if err = s.pending.Delete(ctx, pryr.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forgetOutbound deletes messages to phn that are waiting to be sent, which may quote its prayer requests, and the
+// delivery receipts of messages sent to it.
+func (s *PrivacyService) forgetOutbound(ctx context.Context, phn string) error {
+	deferred, err := s.deferred.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, msg := range deferred {
+		if msg.Phone != phn {
+			continue
+		}
+		if err = s.deferred.Delete(ctx, msg.ID); err != nil {
+			return err
+		}
+	}
+
+	receipts, err := s.receipts.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, receipt := range receipts {
+		if receipt.Phone != phn {
+			continue
+		}
+		if err = s.receipts.Delete(ctx, receipt.MessageID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// anonymizeCompleted replaces phn in completed prayers with a new random ID, which is the same across all of them, and
+// clears the intercessor's name.
+func (s *PrivacyService) anonymizeCompleted(ctx context.Context, phn string) error {
+	completed, err := s.completed.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return err
+	}
+	for _, pryr := range completed {
+		if pryr.RequestorPhone != phn && pryr.IntercessorPhone != phn {
+			continue
+		}
+		if pryr.RequestorPhone == phn {
+			pryr.RequestorPhone = id
+		}
+		if pryr.IntercessorPhone == phn {
+			pryr.IntercessorPhone = id
+			pryr.IntercessorName = ""
+		}
+		if err = s.completed.Save(ctx, &pryr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/4JesusApps/prayertexter/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	msgmocks "github.com/4JesusApps/prayertexter/internal/mocks/messaging"
+	repomocks "github.com/4JesusApps/prayertexter/internal/mocks/repository"
+)
+
+type PrivacyServiceSuite struct {
+	suite.Suite
+	svc          *service.PrivacyService
+	members      *repomocks.MockMemberRepository
+	intercessors *repomocks.MockIntercessorPhonesRepository
+	prayers      *repomocks.MockPrayerRepository
+	pending      *repomocks.MockPendingPrayerRepository
+	completed    *repomocks.MockCompletedPrayerRepository
+	history      *repomocks.MockRequestHistoryRepository
+	transcripts  *repomocks.MockTranscriptRepository
+	deferred     *repomocks.MockDeferredMessageRepository
+	receipts     *repomocks.MockDeliveryReceiptRepository
+	sender       *msgmocks.MockMessageSender
+	ctx          context.Context
+}
+
+func (s *PrivacyServiceSuite) SetupTest() {
+	s.members = repomocks.NewMockMemberRepository(s.T())
+	s.intercessors = repomocks.NewMockIntercessorPhonesRepository(s.T())
+	s.prayers = repomocks.NewMockPrayerRepository(s.T())
+	s.pending = repomocks.NewMockPendingPrayerRepository(s.T())
+	s.completed = repomocks.NewMockCompletedPrayerRepository(s.T())
+	s.history = repomocks.NewMockRequestHistoryRepository(s.T())
+	s.transcripts = repomocks.NewMockTranscriptRepository(s.T())
+	s.deferred = repomocks.NewMockDeferredMessageRepository(s.T())
+	s.receipts = repomocks.NewMockDeliveryReceiptRepository(s.T())
+	s.sender = msgmocks.NewMockMessageSender(s.T())
+	s.ctx = context.Background()
+
+	cfg := config.Config{PhoneRegion: "US", TranscriptRetentionDays: 90}
+	profanity := messaging.NewProfanity(config.ProfanityConfig{}, domain.ProfanityLists{})
+	memberSvc := service.NewMemberService(s.members, s.intercessors, s.prayers,
+		repomocks.NewMockConsentRecordRepository(s.T()), s.sender, profanity, cfg)
+	prayerSvc := service.NewPrayerService(
+		s.members, s.intercessors, s.prayers, s.completed, s.pending, s.history, s.sender, profanity, cfg,
+	)
+	s.svc = service.NewPrivacyService(
+		s.members, s.prayers, s.pending, s.completed, s.history, s.transcripts, s.deferred, s.receipts, s.sender,
+		memberSvc, prayerSvc, cfg,
+	)
+}
+
+func (s *PrivacyServiceSuite) TestMyData() {
+	phn := "+11234567890"
+	requestor := domain.MemberRef{Phone: phn}
+	s.members.EXPECT().Get(s.ctx, phn).Return(&domain.Member{
+		Phone: phn, Name: "Jane", SetupStatus: domain.MemberSetupComplete, SignUpDate: "2024-01-01T00:00:00Z",
+		Intercessor: true, WeeklyPrayerLimit: 5, ConsentDate: "2024-01-01T00:00:00Z",
+	}, nil)
+	s.prayers.EXPECT().GetAll(s.ctx, false).Return([]domain.Prayer{{Requestor: requestor}, {}}, nil)
+	s.prayers.EXPECT().GetAll(s.ctx, true).Return([]domain.Prayer{{Requestor: requestor}}, nil)
+	s.pending.EXPECT().GetAll(s.ctx).Return([]domain.PendingPrayer{{Requestor: requestor}}, nil)
+	s.history.EXPECT().Get(s.ctx, phn).Return(&domain.RequestHistory{
+		Requests: []domain.RequestRecord{{Date: time.Now().Format(time.RFC3339)}},
+	}, nil)
+	s.completed.EXPECT().GetAll(s.ctx).Return([]domain.CompletedPrayer{
+		{RequestorPhone: phn}, {IntercessorPhone: phn}, {RequestorPhone: "+19999999999"},
+	}, nil)
+	s.transcripts.EXPECT().GetThread(s.ctx, phn).Return(make([]domain.TranscriptMessage, 4), nil)
+	s.sender.EXPECT().SendMessage(s.ctx, phn, mock.MatchedBy(func(body string) bool {
+		return body == "PrayerTexter stores this about +11234567890:\nName: Jane\nSigned up: 2024-01-01T00:00:00Z\n"+
+			"Intercessor: yes, up to 5 prayers a week\nOpted in: 2024-01-01T00:00:00Z\nOpen prayer requests: 3\n"+
+			"Requests in the last week: 1\nPrayers completed: 2\nText messages: 4, each kept for 90 days\n\n"+
+			"Reply deletemydata to delete all of it."
+	})).Return(nil)
+
+	s.NoError(s.svc.MyData(s.ctx, phn))
+}
+
+func (s *PrivacyServiceSuite) TestMyData_NotSignedUp() {
+	phn := "+11234567890"
+	s.members.EXPECT().Get(s.ctx, phn).Return(&domain.Member{}, nil)
+	s.prayers.EXPECT().GetAll(s.ctx, mock.Anything).Return(nil, nil)
+	s.pending.EXPECT().GetAll(s.ctx).Return(nil, nil)
+	s.history.EXPECT().Get(s.ctx, phn).Return(&domain.RequestHistory{}, nil)
+	s.completed.EXPECT().GetAll(s.ctx).Return(nil, nil)
+	s.transcripts.EXPECT().GetThread(s.ctx, phn).Return(nil, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, phn, mock.MatchedBy(func(body string) bool {
+		return body == "PrayerTexter stores this about +11234567890:\nYou are not signed up.\n"+
+			"Open prayer requests: 0\nRequests in the last week: 0\nPrayers completed: 0\n"+
+			"Text messages: 0, each kept for 90 days\n\nReply deletemydata to delete all of it."
+	})).Return(nil)
+
+	s.NoError(s.svc.MyData(s.ctx, phn))
+}
+
+func (s *PrivacyServiceSuite) TestForget() {
+	phn := "+11234567890"
+	requestor := domain.MemberRef{Phone: phn, Name: "Jane"}
+
+	// The member is an intercessor, so they come off the intercessor list and their active prayer is queued.
+	s.members.EXPECT().Get(s.ctx, phn).Return(&domain.Member{
+		Phone: phn, Name: "Jane", SetupStatus: domain.MemberSetupComplete, Intercessor: true,
+	}, nil)
+	s.intercessors.EXPECT().Get(s.ctx).Return(&domain.IntercessorPhones{Phones: []string{phn}}, nil)
+	s.intercessors.EXPECT().Save(s.ctx, &domain.IntercessorPhones{Phones: []string{}}).Return(nil)
+	s.prayers.EXPECT().Exists(s.ctx, phn).Return(false, nil)
+	s.members.EXPECT().Delete(s.ctx, phn).Return(nil)
+
+	// Their own requests are cancelled wherever they are waiting.
+	s.prayers.EXPECT().GetAll(s.ctx, false).Return([]domain.Prayer{
+		{IntercessorPhone: "+12222222222", Request: "pray for me", Requestor: requestor},
+		{IntercessorPhone: "+13333333333", Request: "someone else", Requestor: domain.MemberRef{Phone: "+14444444444"}},
+	}, nil)
+	s.prayers.EXPECT().Get(s.ctx, "+12222222222", false).Return(&domain.Prayer{
+		IntercessorPhone: "+12222222222", Request: "pray for me", Requestor: requestor,
+	}, nil)
+	s.prayers.EXPECT().Delete(s.ctx, "+12222222222", false).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+12222222222", messaging.MsgPrayerCancelled).Return(nil)
+	s.prayers.EXPECT().GetAll(s.ctx, true).Return([]domain.Prayer{
+		{IntercessorPhone: "queued1", Request: "queued request", Requestor: requestor},
+	}, nil)
+	s.prayers.EXPECT().Get(s.ctx, "queued1", true).Return(&domain.Prayer{
+		IntercessorPhone: "queued1", Request: "queued request", Requestor: requestor,
+	}, nil)
+	s.prayers.EXPECT().Delete(s.ctx, "queued1", true).Return(nil)
+	s.pending.EXPECT().GetAll(s.ctx).Return([]domain.PendingPrayer{
+		{ID: "pending1", Requestor: requestor}, {ID: "pending2", Requestor: domain.MemberRef{Phone: "+14444444444"}},
+	}, nil)
+	s.pending.EXPECT().Delete(s.ctx, "pending1").Return(nil)
+
+	// Completed prayers keep an anonymous ID, the same one wherever the phone appeared.
+	var anonymous string
+	s.completed.EXPECT().GetAll(s.ctx).Return([]domain.CompletedPrayer{
+		{ID: "c1", RequestorPhone: phn, IntercessorPhone: "+12222222222", IntercessorName: "Bob"},
+		{ID: "c2", RequestorPhone: "+14444444444", IntercessorPhone: phn, IntercessorName: "Jane"},
+		{ID: "c3", RequestorPhone: "+14444444444", IntercessorPhone: "+12222222222"},
+	}, nil)
+	s.completed.EXPECT().Save(s.ctx, mock.MatchedBy(func(p *domain.CompletedPrayer) bool {
+		return p.ID == "c1" && p.RequestorPhone != phn && p.RequestorPhone != "" && p.IntercessorName == "Bob"
+	})).RunAndReturn(func(_ context.Context, p *domain.CompletedPrayer) error {
+		anonymous = p.RequestorPhone
+		return nil
+	})
+	s.completed.EXPECT().Save(s.ctx, mock.MatchedBy(func(p *domain.CompletedPrayer) bool {
+		return p.ID == "c2" && p.IntercessorPhone == anonymous && p.IntercessorName == ""
+	})).Return(nil)
+
+	s.history.EXPECT().Delete(s.ctx, phn).Return(nil)
+
+	// Messages waiting to be sent to them and delivery receipts of messages sent to them are deleted.
+	s.deferred.EXPECT().GetAll(s.ctx).Return([]domain.DeferredMessage{
+		{ID: "d1", Phone: phn, Body: "pray for me"}, {ID: "d2", Phone: "+14444444444"},
+	}, nil)
+	s.deferred.EXPECT().Delete(s.ctx, "d1").Return(nil)
+	s.receipts.EXPECT().GetAll(s.ctx).Return([]domain.DeliveryReceipt{
+		{MessageID: "m1", Phone: phn}, {MessageID: "m2", Phone: "+14444444444"},
+	}, nil)
+	s.receipts.EXPECT().Delete(s.ctx, "m1").Return(nil)
+
+	s.sender.EXPECT().SendMessage(s.ctx, phn, messaging.MsgDataDeleted).Return(nil)
+	s.transcripts.EXPECT().DeleteThread(s.ctx, phn).Return(nil)
+
+	s.NoError(s.svc.Forget(s.ctx, "123-456-7890"))
+}
+
+func (s *PrivacyServiceSuite) TestForgetRequested() {
+	phn := "+11234567890"
+	inbound := func(body string, age time.Duration) domain.TranscriptMessage {
+		return domain.TranscriptMessage{
+			Phone: phn, Direction: domain.DirectionInbound, Body: body,
+			Timestamp: time.Now().Add(-age).Format(time.RFC3339Nano),
+		}
+	}
+	asked, yes := inbound("Delete my data", time.Minute), inbound("Yes", 0)
+	prompt := domain.TranscriptMessage{Phone: phn, Direction: domain.DirectionOutbound, Body: "Reply YES"}
+
+	tests := []struct {
+		name   string
+		thread []domain.TranscriptMessage
+		want   bool
+	}{
+		{"just asked", []domain.TranscriptMessage{asked, prompt, yes}, true},
+		{"replied yes twice", []domain.TranscriptMessage{asked, prompt, yes, yes}, true},
+		{"asked too long ago", []domain.TranscriptMessage{inbound("deletemydata", time.Hour), prompt, yes}, false},
+		{"changed the subject", []domain.TranscriptMessage{asked, prompt, inbound("help", 0), yes}, false},
+		{"never asked", []domain.TranscriptMessage{yes}, false},
+	}
+
+	for _, tt := range tests {
+		s.transcripts.EXPECT().GetThread(s.ctx, phn).Return(tt.thread, nil).Once()
+
+		got, err := s.svc.ForgetRequested(s.ctx, phn)
+		s.Require().NoError(err, tt.name)
+		s.Equal(tt.want, got, tt.name)
+	}
+}
+
+func (s *PrivacyServiceSuite) TestForget_InvalidPhone() {
+	err := s.svc.Forget(s.ctx, "123")
+	s.ErrorIs(err, service.ErrInvalidPhone)
+}
+
+func TestPrivacyServiceSuite(t *testing.T) {
+	suite.Run(t, new(PrivacyServiceSuite))
+}
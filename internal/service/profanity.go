@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/4JesusApps/prayertexter/internal/repository"
+)
+
+// LoadProfanity builds the profanity filters from cfg and the lists admins have saved in DynamoDB. It is called once
+// when a handler starts, and the result is shared by every service that checks names or prayer requests.
+func LoadProfanity(
+	ctx context.Context,
+	lists repository.ProfanityListsRepository,
+	cfg config.ProfanityConfig,
+) (*messaging.Profanity, error) {
+	saved, err := lists.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return messaging.NewProfanity(cfg, *saved), nil
+}
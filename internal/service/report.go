@@ -0,0 +1,244 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/apperr"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/repository"
+)
+
+const (
+	reportWeeks        = 8
+	unresponsiveListed = 5
+	daysPerWeek        = 7
+	reportDay          = 24 * time.Hour
+	reportWeek         = daysPerWeek * reportDay
+)
+
+// Report is a snapshot of ministry health.
+type Report struct {
+	GeneratedAt   time.Time
+	Members       int
+	Intercessors  int
+	ActivePrayers int
+	QueuedPrayers int
+	// OldestQueued is how long the oldest queued prayer has been waiting. It is zero when the queue is empty.
+	OldestQueued time.Duration
+	// Weekly holds the number of prayers prayed in each of the last reportWeeks weeks, oldest first.
+	Weekly []WeeklyCount
+	// Unresponsive lists the intercessors who have been reminded the most about their active prayer.
+	Unresponsive []UnresponsiveIntercessor
+}
+
+// WeeklyCount is the number of prayers prayed in the week starting on Start, a Monday at midnight UTC.
+type WeeklyCount struct {
+	Start  time.Time
+	Prayed int
+}
+
+type UnresponsiveIntercessor struct {
+	Name      string
+	Phone     string
+	Reminders int
+	Waiting   time.Duration
+}
+
+type ReportService struct {
+	members   repository.MemberRepository
+	prayers   repository.PrayerRepository
+	completed repository.CompletedPrayerRepository
+}
+
+func NewReportService(
+	members repository.MemberRepository,
+	prayers repository.PrayerRepository,
+	completed repository.CompletedPrayerRepository,
+) *ReportService {
+	return &ReportService{
+		members:   members,
+		prayers:   prayers,
+		completed: completed,
+	}
+}
+
+// Report builds a Report as of now.
+func (s *ReportService) Report(ctx context.Context, now time.Time) (*Report, error) {
+	members, err := s.members.GetAll(ctx)
+	if err != nil {
+		return nil, apperr.WrapError(err, "failed to get members")
+	}
+	active, err := s.prayers.GetAll(ctx, false)
+	if err != nil {
+		return nil, apperr.WrapError(err, "failed to get active prayers")
+	}
+	queued, err := s.prayers.GetAll(ctx, true)
+	if err != nil {
+		return nil, apperr.WrapError(err, "failed to get queued prayers")
+	}
+	completed, err := s.completed.GetAll(ctx)
+	if err != nil {
+		return nil, apperr.WrapError(err, "failed to get completed prayers")
+	}
+
+	report := &Report{
+		GeneratedAt:   now,
+		ActivePrayers: len(active),
+		QueuedPrayers: len(queued),
+		Weekly:        weeklyCounts(completed, now),
+		Unresponsive:  unresponsive(active, now),
+	}
+
+	for _, mem := range members {
+		if mem.SetupStatus != domain.MemberSetupComplete {
+			continue
+		}
+		report.Members++
+		if mem.Intercessor {
+			report.Intercessors++
+		}
+	}
+
+	for _, pryr := range queued {
+		report.OldestQueued = max(report.OldestQueued, waiting(pryr.RequestDate, now))
+	}
+
+	return report, nil
+}
+
+func weeklyCounts(completed []domain.CompletedPrayer, now time.Time) []WeeklyCount {
+	first := weekStart(now).Add(-(reportWeeks - 1) * reportWeek)
+
+	weeks := make([]WeeklyCount, reportWeeks)
+	for i := range weeks {
+		weeks[i].Start = first.Add(time.Duration(i) * reportWeek)
+	}
+
+	for _, pryr := range completed {
+		date, err := time.Parse(time.RFC3339, pryr.CompletedDate)
+		if err != nil || date.Before(first) || date.After(now) {
+			continue
+		}
+		weeks[int(date.Sub(first)/reportWeek)].Prayed++
+	}
+
+	return weeks
+}
+
+func unresponsive(active []domain.Prayer, now time.Time) []UnresponsiveIntercessor {
+	var intercessors []UnresponsiveIntercessor
+	for _, pryr := range active {
+		if pryr.ReminderCount == 0 {
+			continue
+		}
+		intercessors = append(intercessors, UnresponsiveIntercessor{
+			Name:      pryr.Intercessor.Name,
+			Phone:     pryr.IntercessorPhone,
+			Reminders: pryr.ReminderCount,
+			Waiting:   waiting(pryr.RequestDate, now),
+		})
+	}
+
+	slices.SortFunc(intercessors, func(a, b UnresponsiveIntercessor) int {
+		return cmp.Or(cmp.Compare(b.Reminders, a.Reminders), cmp.Compare(b.Waiting, a.Waiting))
+	})
+
+	return intercessors[:min(len(intercessors), unresponsiveListed)]
+}
+
+// weekStart returns midnight UTC on the Monday of the week that t falls in.
+func weekStart(t time.Time) time.Time {
+	day := t.UTC().Truncate(reportDay)
+	daysSinceMonday := (int(day.Weekday()) + daysPerWeek - 1) % daysPerWeek
+	return day.Add(-time.Duration(daysSinceMonday) * reportDay)
+}
+
+// waiting returns how long ago the RFC3339 date was, or zero when the date is missing.
+func waiting(date string, now time.Time) time.Duration {
+	requested, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return 0
+	}
+	return max(now.Sub(requested), 0)
+}
+
+// WeeklySummary describes the week that ended at Until.
+type WeeklySummary struct {
+	Until      time.Time
+	NewMembers int
+	// Requests counts distinct prayer requests received during the week. A request sent to several intercessors
+	// is counted once.
+	Requests  int
+	Completed int
+	// MedianTimeToPrayed is the median time from request to prayed for the prayers completed during the week.
+	MedianTimeToPrayed time.Duration
+	Queued             int
+}
+
+func weeklySummary(
+	members []domain.Member,
+	active, queued []domain.Prayer,
+	completed []domain.CompletedPrayer,
+	until time.Time,
+) WeeklySummary {
+	since := until.Add(-reportWeek)
+	inWeek := func(date string) bool {
+		t, err := time.Parse(time.RFC3339, date)
+		return err == nil && t.After(since) && !t.After(until)
+	}
+
+	summary := WeeklySummary{Until: until, Queued: len(queued)}
+
+	for _, mem := range members {
+		if mem.SetupStatus == domain.MemberSetupComplete && inWeek(mem.SignUpDate) {
+			summary.NewMembers++
+		}
+	}
+
+	requests := map[string]bool{}
+	for _, pryr := range slices.Concat(active, queued) {
+		if inWeek(pryr.RequestDate) {
+			requests[pryr.Requestor.Phone+pryr.RequestDate] = true
+		}
+	}
+
+	var timesToPrayed []time.Duration
+	for _, pryr := range completed {
+		if inWeek(pryr.RequestDate) {
+			requests[pryr.RequestorPhone+pryr.RequestDate] = true
+		}
+		if !inWeek(pryr.CompletedDate) {
+			continue
+		}
+		summary.Completed++
+
+		requested, err := time.Parse(time.RFC3339, pryr.RequestDate)
+		if err != nil {
+			continue
+		}
+		completedAt, _ := time.Parse(time.RFC3339, pryr.CompletedDate)
+		timesToPrayed = append(timesToPrayed, completedAt.Sub(requested))
+	}
+	summary.Requests = len(requests)
+	summary.MedianTimeToPrayed = median(timesToPrayed)
+
+	return summary
+}
+
+func median(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	const half = 2
+
+	slices.Sort(durations)
+	mid := len(durations) / half
+	if len(durations)%half == 0 {
+		return (durations[mid-1] + durations[mid]) / half
+	}
+	return durations[mid]
+}
@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/service"
+	"github.com/stretchr/testify/suite"
+
+	repomocks "github.com/4JesusApps/prayertexter/internal/mocks/repository"
+)
+
+type ReportServiceSuite struct {
+	suite.Suite
+	svc       *service.ReportService
+	members   *repomocks.MockMemberRepository
+	prayers   *repomocks.MockPrayerRepository
+	completed *repomocks.MockCompletedPrayerRepository
+	ctx       context.Context
+	now       time.Time
+}
+
+func (s *ReportServiceSuite) SetupTest() {
+	s.members = repomocks.NewMockMemberRepository(s.T())
+	s.prayers = repomocks.NewMockPrayerRepository(s.T())
+	s.completed = repomocks.NewMockCompletedPrayerRepository(s.T())
+	s.ctx = context.Background()
+	s.svc = service.NewReportService(s.members, s.prayers, s.completed)
+	// Wednesday, so the current week started two days earlier on Monday October 12.
+	s.now = time.Date(2026, time.October, 14, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *ReportServiceSuite) ago(d time.Duration) string {
+	return s.now.Add(-d).Format(time.RFC3339)
+}
+
+func (s *ReportServiceSuite) TestReport() {
+	s.members.EXPECT().GetAll(s.ctx).Return([]domain.Member{
+		{Phone: "+11111111111", SetupStatus: domain.MemberSetupComplete},
+		{Phone: "+12222222222", SetupStatus: domain.MemberSetupComplete, Intercessor: true},
+		{Phone: "+13333333333", SetupStatus: domain.MemberSetupInProgress, Intercessor: true},
+	}, nil)
+	s.prayers.EXPECT().GetAll(s.ctx, false).Return([]domain.Prayer{
+		{IntercessorPhone: "+12222222222", Intercessor: domain.MemberRef{Name: "Slow"}, ReminderCount: 2,
+			RequestDate: s.ago(30 * time.Hour)},
+		{IntercessorPhone: "+14444444444", Intercessor: domain.MemberRef{Name: "Slower"}, ReminderCount: 2,
+			RequestDate: s.ago(50 * time.Hour)},
+		{IntercessorPhone: "+15555555555", ReminderCount: 0},
+	}, nil)
+	s.prayers.EXPECT().GetAll(s.ctx, true).Return([]domain.Prayer{
+		{IntercessorPhone: "queue-1", RequestDate: s.ago(time.Hour)},
+		{IntercessorPhone: "queue-2", RequestDate: s.ago(5 * time.Hour)},
+		{IntercessorPhone: "queue-3"},
+	}, nil)
+	s.completed.EXPECT().GetAll(s.ctx).Return([]domain.CompletedPrayer{
+		{ID: "1", CompletedDate: s.ago(time.Hour)},
+		{ID: "2", CompletedDate: s.ago(24 * time.Hour)},
+		{ID: "3", CompletedDate: s.ago(3 * 24 * time.Hour)},
+		{ID: "4", CompletedDate: s.ago(365 * 24 * time.Hour)},
+		{ID: "5"},
+	}, nil)
+
+	report, err := s.svc.Report(s.ctx, s.now)
+	s.Require().NoError(err)
+
+	s.Equal(2, report.Members)
+	s.Equal(1, report.Intercessors)
+	s.Equal(3, report.ActivePrayers)
+	s.Equal(3, report.QueuedPrayers)
+	s.Equal(5*time.Hour, report.OldestQueued)
+
+	s.Require().Len(report.Weekly, 8)
+	s.Equal(time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC), report.Weekly[7].Start)
+	s.Equal(time.Date(2026, time.August, 24, 0, 0, 0, 0, time.UTC), report.Weekly[0].Start)
+	s.Equal(2, report.Weekly[7].Prayed)
+	s.Equal(1, report.Weekly[6].Prayed)
+	s.Equal(0, report.Weekly[0].Prayed)
+
+	s.Equal([]service.UnresponsiveIntercessor{
+		{Name: "Slower", Phone: "+14444444444", Reminders: 2, Waiting: 50 * time.Hour},
+		{Name: "Slow", Phone: "+12222222222", Reminders: 2, Waiting: 30 * time.Hour},
+	}, report.Unresponsive)
+}
+
+func (s *ReportServiceSuite) TestReport_Error() {
+	s.members.EXPECT().GetAll(s.ctx).Return(nil, errors.New("boom"))
+
+	_, err := s.svc.Report(s.ctx, s.now)
+	s.ErrorContains(err, "failed to get members")
+}
+
+func TestReportServiceSuite(t *testing.T) {
+	suite.Run(t, new(ReportServiceSuite))
+}
@@ -6,38 +6,59 @@ import (
 	"fmt"
 	"log/slog"
 	"slices"
-	"strings"
 
 	"github.com/4JesusApps/prayertexter/internal/apperr"
+	"github.com/4JesusApps/prayertexter/internal/config"
 	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/phone"
 	"github.com/4JesusApps/prayertexter/internal/repository"
 )
 
 type Router struct {
-	members   repository.MemberRepository
-	blocked   repository.BlockedPhonesRepository
-	memberSvc *MemberService
-	prayerSvc *PrayerService
-	adminSvc  *AdminService
+	members     repository.MemberRepository
+	blocked     repository.BlockedPhonesRepository
+	optedOut    repository.OptedOutPhonesRepository
+	transcripts repository.TranscriptRepository
+	memberSvc   *MemberService
+	prayerSvc   *PrayerService
+	adminSvc    *AdminService
+	privacySvc  *PrivacyService
+	cfg         config.Config
 }
 
 func NewRouter(
 	members repository.MemberRepository,
 	blocked repository.BlockedPhonesRepository,
+	optedOut repository.OptedOutPhonesRepository,
+	transcripts repository.TranscriptRepository,
 	memberSvc *MemberService,
 	prayerSvc *PrayerService,
 	adminSvc *AdminService,
+	privacySvc *PrivacyService,
+	cfg config.Config,
 ) *Router {
 	return &Router{
-		members:   members,
-		blocked:   blocked,
-		memberSvc: memberSvc,
-		prayerSvc: prayerSvc,
-		adminSvc:  adminSvc,
+		members:     members,
+		blocked:     blocked,
+		optedOut:    optedOut,
+		transcripts: transcripts,
+		memberSvc:   memberSvc,
+		prayerSvc:   prayerSvc,
+		adminSvc:    adminSvc,
+		privacySvc:  privacySvc,
+		cfg:         cfg,
 	}
 }
 
 func (r *Router) Handle(ctx context.Context, msg domain.TextMessage) error {
+	number, err := phone.Normalize(msg.Phone, r.cfg.PhoneRegion)
+	if err != nil {
+		return apperr.LogAndWrapError(ctx, err, "failure during stage PRE", "phone", msg.Phone, "msg", msg.Body)
+	}
+	msg.Phone = number
+
+	r.recordInbound(ctx, msg)
+
 	mem, err := r.members.Get(ctx, msg.Phone)
 	if err != nil {
 		return apperr.LogAndWrapError(ctx, err, "failure during stage PRE", "phone", msg.Phone, "msg", msg.Body)
@@ -51,6 +72,21 @@ func (r *Router) Handle(ctx context.Context, msg domain.TextMessage) error {
 	isBlocked := slices.Contains(blockedPhones.Phones, mem.Phone)
 	cleanMsg := cleanStr(msg.Body)
 
+	forgetConfirmed := false
+	if cleanMsg == "yes" && !isBlocked {
+		if forgetConfirmed, err = r.privacySvc.ForgetRequested(ctx, msg.Phone); err != nil {
+			return apperr.LogAndWrapError(ctx, err, "failure during stage PRE", "phone", msg.Phone, "msg", msg.Body)
+		}
+	}
+
+	if mem.Inactive && !isBlocked {
+		if err = r.memberSvc.Reactivate(ctx, *mem); err != nil {
+			return apperr.LogAndWrapError(ctx, err, "failure during stage PRE", "phone", msg.Phone, "msg", msg.Body)
+		}
+		mem.Inactive = false
+		mem.DeliveryFailures = 0
+	}
+
 	var stageName string
 	var stageErr error
 
@@ -59,21 +95,33 @@ func (r *Router) Handle(ctx context.Context, msg domain.TextMessage) error {
 		stageName = "BLOCKED USER"
 		slog.WarnContext(ctx, "blocked user dropping message", "phone", mem.Phone, "msg", msg.Body)
 
-	case strings.Contains(strings.ToLower(msg.Body), "#block"):
-		stageName = "ADD BLOCKED USER"
-		stageErr = r.adminSvc.BlockUser(ctx, msg, *mem, blockedPhones)
+	case AdminCommand(msg.Body) != "":
+		stageName = "ADMIN COMMAND"
+		stageErr = r.adminSvc.HandleCommand(ctx, msg, *mem, blockedPhones)
 
 	case cleanMsg == "help":
 		stageName = "HELP"
 		stageErr = r.memberSvc.Help(ctx, *mem)
 
+	case cleanMsg == "mydata":
+		stageName = "MY DATA"
+		stageErr = r.privacySvc.MyData(ctx, msg.Phone)
+
+	case cleanMsg == "deletemydata":
+		stageName = "DELETE MY DATA"
+		stageErr = r.privacySvc.RequestForget(ctx, msg.Phone)
+
+	case forgetConfirmed:
+		stageName = "DELETE MY DATA CONFIRMED"
+		stageErr = r.forget(ctx, msg.Phone, "deletemydata")
+
 	case cleanMsg == "cancel" || cleanMsg == "stop":
 		stageName = "MEMBER DELETE"
-		stageErr = r.memberSvc.Delete(ctx, *mem)
+		stageErr = r.optOut(ctx, *mem, cleanMsg)
 
 	case cleanMsg == "pray" || mem.SetupStatus == domain.MemberSetupInProgress:
 		stageName = "SIGN UP"
-		stageErr = r.memberSvc.SignUp(ctx, msg, *mem)
+		stageErr = r.signUp(ctx, msg, *mem)
 
 	case mem.SetupStatus == "":
 		stageName = "DROP MESSAGE"
@@ -105,3 +153,63 @@ func (r *Router) Handle(ctx context.Context, msg domain.TextMessage) error {
 
 	return nil
 }
+
+// optOut removes the member, adds the opt out to their consent record and records their phone as opted out so that no
+// further messages are sent to it. The removal confirmation is sent before the phone is added to the opted out list.
+func (r *Router) optOut(ctx context.Context, mem domain.Member, keyword string) error {
+	if err := r.memberSvc.Delete(ctx, mem); err != nil {
+		return err
+	}
+	if err := r.memberSvc.RecordOptOut(ctx, mem.Phone, keyword); err != nil {
+		return err
+	}
+	return r.addOptedOut(ctx, mem.Phone)
+}
+
+// forget deletes everything stored about phn once it has confirmed deletemydata, and then opts it out as optOut does,
+// since someone who wants their data gone does not want to be texted either.
+func (r *Router) forget(ctx context.Context, phn, keyword string) error {
+	if err := r.privacySvc.Forget(ctx, phn); err != nil {
+		return err
+	}
+	if err := r.memberSvc.RecordOptOut(ctx, phn, keyword); err != nil {
+		return err
+	}
+	return r.addOptedOut(ctx, phn)
+}
+
+func (r *Router) addOptedOut(ctx context.Context, phn string) error {
+	phones, err := r.optedOut.Get(ctx)
+	if err != nil {
+		return err
+	}
+	phones.AddPhone(phn)
+	return r.optedOut.Save(ctx, phones)
+}
+
+// signUp opts a phone back in when it texts "pray" after previously opting out, then continues the sign up flow.
+func (r *Router) signUp(ctx context.Context, msg domain.TextMessage, mem domain.Member) error {
+	if cleanStr(msg.Body) == "pray" {
+		phones, err := r.optedOut.Get(ctx)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(phones.Phones, mem.Phone) {
+			phones.RemovePhone(mem.Phone)
+			if err = r.optedOut.Save(ctx, phones); err != nil {
+				return err
+			}
+		}
+	}
+
+	return r.memberSvc.SignUp(ctx, msg, mem)
+}
+
+// recordInbound saves msg to the conversation transcript. Recording is best effort so that a transcript failure never
+// stops the message from being handled.
+func (r *Router) recordInbound(ctx context.Context, msg domain.TextMessage) {
+	entry := domain.NewTranscriptMessage(msg.Phone, domain.DirectionInbound, msg.Body, r.cfg.TranscriptRetentionDays)
+	if err := r.transcripts.Save(ctx, &entry); err != nil {
+		apperr.LogError(ctx, err, "failed to record inbound text message", "phone", msg.Phone)
+	}
+}
@@ -2,11 +2,14 @@ package service_test
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/4JesusApps/prayertexter/internal/config"
 	"github.com/4JesusApps/prayertexter/internal/domain"
 	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/4JesusApps/prayertexter/internal/phone"
 	"github.com/4JesusApps/prayertexter/internal/service"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -20,8 +23,16 @@ type RouterSuite struct {
 	router       *service.Router
 	members      *repomocks.MockMemberRepository
 	blocked      *repomocks.MockBlockedPhonesRepository
+	optedOut     *repomocks.MockOptedOutPhonesRepository
+	transcripts  *repomocks.MockTranscriptRepository
 	intercessors *repomocks.MockIntercessorPhonesRepository
 	prayers      *repomocks.MockPrayerRepository
+	consents     *repomocks.MockConsentRecordRepository
+	history      *repomocks.MockRequestHistoryRepository
+	completed    *repomocks.MockCompletedPrayerRepository
+	pending      *repomocks.MockPendingPrayerRepository
+	deferred     *repomocks.MockDeferredMessageRepository
+	receipts     *repomocks.MockDeliveryReceiptRepository
 	sender       *msgmocks.MockMessageSender
 	ctx          context.Context
 }
@@ -29,20 +40,44 @@ type RouterSuite struct {
 func (s *RouterSuite) SetupTest() {
 	s.members = repomocks.NewMockMemberRepository(s.T())
 	s.blocked = repomocks.NewMockBlockedPhonesRepository(s.T())
+	s.optedOut = repomocks.NewMockOptedOutPhonesRepository(s.T())
+	s.transcripts = repomocks.NewMockTranscriptRepository(s.T())
 	s.intercessors = repomocks.NewMockIntercessorPhonesRepository(s.T())
 	s.prayers = repomocks.NewMockPrayerRepository(s.T())
+	s.consents = repomocks.NewMockConsentRecordRepository(s.T())
+	s.history = repomocks.NewMockRequestHistoryRepository(s.T())
+	s.completed = repomocks.NewMockCompletedPrayerRepository(s.T())
+	s.pending = repomocks.NewMockPendingPrayerRepository(s.T())
+	s.deferred = repomocks.NewMockDeferredMessageRepository(s.T())
+	s.receipts = repomocks.NewMockDeliveryReceiptRepository(s.T())
 	s.sender = msgmocks.NewMockMessageSender(s.T())
 	s.ctx = context.Background()
 
-	cfg := config.Config{IntercessorsPerPrayer: 2, PrayerReminderHours: 3}
-	memberSvc := service.NewMemberService(s.members, s.intercessors, s.prayers, s.sender, cfg)
-	prayerSvc := service.NewPrayerService(s.members, s.intercessors, s.prayers, s.sender, cfg)
-	adminSvc := service.NewAdminService(s.members, s.blocked, s.sender, memberSvc)
+	cfg := config.Config{
+		IntercessorsPerPrayer: 2, PhoneRegion: "US", PrayerReminderHours: 3, TranscriptRetentionDays: 90,
+	}
+	profanity := messaging.NewProfanity(config.ProfanityConfig{}, domain.ProfanityLists{})
+	memberSvc := service.NewMemberService(s.members, s.intercessors, s.prayers, s.consents, s.sender, profanity, cfg)
+	prayerSvc := service.NewPrayerService(
+		s.members, s.intercessors, s.prayers, s.completed, s.pending, s.history, s.sender, profanity, cfg,
+	)
+	adminSvc := service.NewAdminService(
+		s.members, s.blocked, s.prayers, repomocks.NewMockAuditRepository(s.T()), s.consents, s.sender, memberSvc,
+		prayerSvc, cfg,
+	)
 
-	s.router = service.NewRouter(s.members, s.blocked, memberSvc, prayerSvc, adminSvc)
+	privacySvc := service.NewPrivacyService(
+		s.members, s.prayers, s.pending, s.completed, s.history, s.transcripts, s.deferred, s.receipts, s.sender,
+		memberSvc, prayerSvc, cfg,
+	)
+
+	s.router = service.NewRouter(
+		s.members, s.blocked, s.optedOut, s.transcripts, memberSvc, prayerSvc, adminSvc, privacySvc, cfg,
+	)
 }
 
 func (s *RouterSuite) TestRouteHelp() {
+	s.transcripts.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
 	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{Phone: "+11234567890"}, nil)
 	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{}, nil)
 	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgHelp).Return(nil)
@@ -51,7 +86,25 @@ func (s *RouterSuite) TestRouteHelp() {
 	s.NoError(err)
 }
 
+func (s *RouterSuite) TestRouteNormalizesPhone() {
+	s.transcripts.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.TranscriptMessage) bool {
+		return m.Phone == "+11234567890"
+	})).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{Phone: "+11234567890"}, nil)
+	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgHelp).Return(nil)
+
+	err := s.router.Handle(s.ctx, domain.TextMessage{Body: "HELP", Phone: "(123) 456-7890"})
+	s.NoError(err)
+}
+
+func (s *RouterSuite) TestRouteInvalidPhone() {
+	err := s.router.Handle(s.ctx, domain.TextMessage{Body: "HELP", Phone: "12345"})
+	s.ErrorIs(err, phone.ErrInvalid)
+}
+
 func (s *RouterSuite) TestRouteBlockedUser() {
+	s.transcripts.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
 	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{Phone: "+11234567890"}, nil)
 	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{Phones: []string{"+11234567890"}}, nil)
 
@@ -60,8 +113,10 @@ func (s *RouterSuite) TestRouteBlockedUser() {
 }
 
 func (s *RouterSuite) TestRouteSignUp() {
+	s.transcripts.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
 	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{Phone: "+11234567890"}, nil)
 	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{}, nil)
+	s.optedOut.EXPECT().Get(s.ctx).Return(&domain.OptedOutPhones{}, nil)
 	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool {
 		return m.SetupStatus == domain.MemberSetupInProgress
 	})).Return(nil)
@@ -71,7 +126,112 @@ func (s *RouterSuite) TestRouteSignUp() {
 	s.NoError(err)
 }
 
+func (s *RouterSuite) TestRouteSignUpOptsBackIn() {
+	s.transcripts.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{Phone: "+11234567890"}, nil)
+	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{}, nil)
+	s.optedOut.EXPECT().Get(s.ctx).Return(&domain.OptedOutPhones{Phones: []string{"+11234567890"}}, nil)
+	s.optedOut.EXPECT().Save(s.ctx, &domain.OptedOutPhones{Phones: []string{}}).Return(nil)
+	s.members.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgNameRequest).Return(nil)
+
+	err := s.router.Handle(s.ctx, domain.TextMessage{Body: "pray", Phone: "+11234567890"})
+	s.NoError(err)
+}
+
+func (s *RouterSuite) TestRouteStopOptsOut() {
+	s.transcripts.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete,
+	}, nil)
+	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{}, nil)
+	s.members.EXPECT().Delete(s.ctx, "+11234567890").Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgRemoveUser).Return(nil)
+	s.consents.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.ConsentRecord{}, nil)
+	s.consents.EXPECT().Save(s.ctx, mock.MatchedBy(func(r *domain.ConsentRecord) bool {
+		return r.Phone == "+11234567890" && len(r.Events) == 1 && r.Events[0].Type == domain.ConsentOptOut &&
+			r.Events[0].Keyword == "stop" && r.Events[0].Date != ""
+	})).Return(nil)
+	s.optedOut.EXPECT().Get(s.ctx).Return(&domain.OptedOutPhones{}, nil)
+	s.optedOut.EXPECT().Save(s.ctx, &domain.OptedOutPhones{Phones: []string{"+11234567890"}}).Return(nil)
+
+	err := s.router.Handle(s.ctx, domain.TextMessage{Body: "STOP", Phone: "+11234567890"})
+	s.NoError(err)
+}
+
+func (s *RouterSuite) TestRouteMyData() {
+	s.transcripts.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{}, nil)
+	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{}, nil)
+	s.prayers.EXPECT().GetAll(s.ctx, mock.Anything).Return(nil, nil)
+	s.pending.EXPECT().GetAll(s.ctx).Return(nil, nil)
+	s.history.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.RequestHistory{}, nil)
+	s.completed.EXPECT().GetAll(s.ctx).Return(nil, nil)
+	s.transcripts.EXPECT().GetThread(s.ctx, "+11234567890").Return(nil, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", mock.MatchedBy(func(body string) bool {
+		return strings.Contains(body, "You are not signed up.")
+	})).Return(nil)
+
+	err := s.router.Handle(s.ctx, domain.TextMessage{Body: "My data", Phone: "+11234567890"})
+	s.NoError(err)
+}
+
+func (s *RouterSuite) TestRouteDeleteMyDataAsksToConfirm() {
+	s.transcripts.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete,
+	}, nil)
+	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{}, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgConfirmDeleteData).Return(nil)
+
+	err := s.router.Handle(s.ctx, domain.TextMessage{Body: "Delete my data", Phone: "+11234567890"})
+	s.NoError(err)
+}
+
+func (s *RouterSuite) TestRouteDeleteMyDataConfirmedOptsOut() {
+	s.transcripts.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone: "+11234567890", SetupStatus: domain.MemberSetupComplete,
+	}, nil)
+	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{}, nil)
+	s.transcripts.EXPECT().GetThread(s.ctx, "+11234567890").Return([]domain.TranscriptMessage{
+		{Direction: domain.DirectionInbound, Body: "Delete my data", Timestamp: time.Now().Format(time.RFC3339Nano)},
+		{Direction: domain.DirectionInbound, Body: "YES", Timestamp: time.Now().Format(time.RFC3339Nano)},
+	}, nil)
+	s.members.EXPECT().Delete(s.ctx, "+11234567890").Return(nil)
+	s.prayers.EXPECT().GetAll(s.ctx, mock.Anything).Return(nil, nil)
+	s.pending.EXPECT().GetAll(s.ctx).Return(nil, nil)
+	s.completed.EXPECT().GetAll(s.ctx).Return(nil, nil)
+	s.history.EXPECT().Delete(s.ctx, "+11234567890").Return(nil)
+	s.deferred.EXPECT().GetAll(s.ctx).Return(nil, nil)
+	s.receipts.EXPECT().GetAll(s.ctx).Return(nil, nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgDataDeleted).Return(nil)
+	s.transcripts.EXPECT().DeleteThread(s.ctx, "+11234567890").Return(nil)
+	s.consents.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.ConsentRecord{}, nil)
+	s.consents.EXPECT().Save(s.ctx, mock.MatchedBy(func(r *domain.ConsentRecord) bool {
+		return len(r.Events) == 1 && r.Events[0].Type == domain.ConsentOptOut && r.Events[0].Keyword == "deletemydata"
+	})).Return(nil)
+	s.optedOut.EXPECT().Get(s.ctx).Return(&domain.OptedOutPhones{}, nil)
+	s.optedOut.EXPECT().Save(s.ctx, &domain.OptedOutPhones{Phones: []string{"+11234567890"}}).Return(nil)
+
+	err := s.router.Handle(s.ctx, domain.TextMessage{Body: "YES", Phone: "+11234567890"})
+	s.NoError(err)
+}
+
+func (s *RouterSuite) TestRouteRecordsInboundMessage() {
+	s.transcripts.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.TranscriptMessage) bool {
+		return m.Phone == "+11234567890" && m.Body == "random text" && m.Direction == domain.DirectionInbound &&
+			m.Timestamp != "" && m.ExpiresAt > 0
+	})).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{Phone: "+11234567890"}, nil)
+	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{}, nil)
+
+	err := s.router.Handle(s.ctx, domain.TextMessage{Body: "random text", Phone: "+11234567890"})
+	s.NoError(err)
+}
+
 func (s *RouterSuite) TestRouteDropMessage() {
+	s.transcripts.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
 	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{Phone: "+11234567890"}, nil)
 	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{}, nil)
 
@@ -79,6 +239,29 @@ func (s *RouterSuite) TestRouteDropMessage() {
 	s.NoError(err)
 }
 
+func (s *RouterSuite) TestRouteReactivatesInactiveMember() {
+	s.transcripts.EXPECT().Save(s.ctx, mock.Anything).Return(nil)
+	s.members.EXPECT().Get(s.ctx, "+11234567890").Return(&domain.Member{
+		Phone:            "+11234567890",
+		SetupStatus:      domain.MemberSetupComplete,
+		Intercessor:      true,
+		Inactive:         true,
+		DeliveryFailures: 3,
+	}, nil)
+	s.blocked.EXPECT().Get(s.ctx).Return(&domain.BlockedPhones{}, nil)
+	s.members.EXPECT().Save(s.ctx, mock.MatchedBy(func(m *domain.Member) bool {
+		return !m.Inactive && m.DeliveryFailures == 0
+	})).Return(nil)
+	s.intercessors.EXPECT().Get(s.ctx).Return(&domain.IntercessorPhones{}, nil)
+	s.intercessors.EXPECT().Save(s.ctx, mock.MatchedBy(func(p *domain.IntercessorPhones) bool {
+		return len(p.Phones) == 1 && p.Phones[0] == "+11234567890"
+	})).Return(nil)
+	s.sender.EXPECT().SendMessage(s.ctx, "+11234567890", messaging.MsgHelp).Return(nil)
+
+	err := s.router.Handle(s.ctx, domain.TextMessage{Body: "help", Phone: "+11234567890"})
+	s.NoError(err)
+}
+
 func TestRouterSuite(t *testing.T) {
 	suite.Run(t, new(RouterSuite))
 }
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/apperr"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+)
+
+// signUpStep is one step of the sign up flow. The prompt is sent when a member reaches the step. accept validates the
+// member's reply, applies it to mem and returns the stage to move to. When the reply is not accepted it instead returns
+// the message to send back, and the member stays on the step.
+type signUpStep struct {
+	stage  int
+	prompt string
+	accept func(s *MemberService, reply string, mem *domain.Member) (next int, refusal string, err error)
+}
+
+// signUpFlow is the sign up flow in the order members usually go through it. Texting "pray" or "restart" moves a member
+// to the first step, and moving to domain.MemberSignUpStepFinal completes the sign up. To add a step, give it a new
+// stage, add it here and return its stage from the step before it.
+var signUpFlow = []signUpStep{
+	{
+		stage:  domain.MemberSignUpStepOne,
+		prompt: messaging.MsgNameRequest,
+		accept: (*MemberService).acceptName,
+	},
+	{
+		stage:  domain.MemberSignUpStepTwo,
+		prompt: messaging.MsgMemberTypeRequest,
+		accept: (*MemberService).acceptMemberType,
+	},
+	{
+		stage:  domain.MemberSignUpStepThree,
+		prompt: messaging.MsgPrayerNumRequest,
+		accept: (*MemberService).acceptPrayerLimit,
+	},
+}
+
+// SignUp moves mem through the sign up flow using the reply in msg.
+func (s *MemberService) SignUp(ctx context.Context, msg domain.TextMessage, mem domain.Member) error {
+	if cleanMsg := cleanStr(msg.Body); cleanMsg == "pray" || cleanMsg == "restart" {
+		return s.moveToStage(ctx, mem, signUpFlow[0].stage)
+	}
+
+	step, ok := signUpStepFor(mem.SetupStage)
+	if !ok {
+		return s.signUpWrongInput(ctx, mem, msg)
+	}
+
+	next, refusal, err := step.accept(s, msg.Body, &mem)
+	if err != nil {
+		return err
+	}
+	if refusal == messaging.MsgWrongInput {
+		return s.signUpWrongInput(ctx, mem, msg)
+	}
+	if refusal != "" {
+		return s.sender.SendMessage(ctx, mem.Phone, refusal)
+	}
+
+	return s.moveToStage(ctx, mem, next)
+}
+
+// moveToStage saves mem at stage and sends the step's prompt, or completes the sign up when stage is the final stage.
+func (s *MemberService) moveToStage(ctx context.Context, mem domain.Member, stage int) error {
+	if stage == domain.MemberSignUpStepFinal {
+		return s.completeSignUp(ctx, mem)
+	}
+
+	step, ok := signUpStepFor(stage)
+	if !ok {
+		return apperr.WrapError(ErrUnknownSignUpStage, "failed to move to stage "+strconv.Itoa(stage))
+	}
+
+	mem.SetupStatus = domain.MemberSetupInProgress
+	setSignUpStage(&mem, stage)
+	if err := s.members.Save(ctx, &mem); err != nil {
+		return err
+	}
+	return s.sender.SendMessage(ctx, mem.Phone, step.prompt)
+}
+
+// completeSignUp records mem's consent, marks them as signed up, adds intercessors to the intercessor list and sends
+// the instructions along with the opt in disclosure.
+func (s *MemberService) completeSignUp(ctx context.Context, mem domain.Member) error {
+	body := messaging.MsgPrayerInstructions + "\n\n"
+	if mem.Intercessor {
+		phones, err := s.intercessors.Get(ctx)
+		if err != nil {
+			return err
+		}
+		phones.AddPhone(mem.Phone)
+		if err = s.intercessors.Save(ctx, phones); err != nil {
+			return err
+		}
+		body += messaging.MsgIntercessorInstructions + "\n\n"
+	}
+
+	if err := s.recordOptIn(ctx, &mem); err != nil {
+		return err
+	}
+	mem.SetupStatus = domain.MemberSetupComplete
+	mem.SetupStage = domain.MemberSignUpStepFinal
+	mem.SignUpDate = time.Now().Format(time.RFC3339)
+	if err := s.members.Save(ctx, &mem); err != nil {
+		return err
+	}
+
+	return s.sender.SendMessage(ctx, mem.Phone, body+messaging.MsgSignUpConfirmation)
+}
+
+// acceptName accepts the member's name, or "2" to stay anonymous.
+func (s *MemberService) acceptName(reply string, mem *domain.Member) (int, string, error) {
+	if profanity := s.profanity.Names.Reject(reply); profanity != "" {
+		rendered, err := messaging.Render(messaging.ProfanityDetectedTmpl, struct{ Word string }{profanity})
+		return 0, rendered, err
+	}
+
+	name := reply
+	if cleanStr(reply) == "2" {
+		name = domain.AnonymousName
+	}
+	if !isNameValid(name) {
+		return 0, messaging.MsgInvalidName, nil
+	}
+
+	mem.Name = s.profanity.Names.Mask(name)
+	return domain.MemberSignUpStepTwo, "", nil
+}
+
+// acceptMemberType accepts "1" to only send prayer requests or "2" to also become an intercessor.
+func (s *MemberService) acceptMemberType(reply string, mem *domain.Member) (int, string, error) {
+	switch cleanStr(reply) {
+	case "1":
+		mem.Intercessor = false
+		return domain.MemberSignUpStepFinal, "", nil
+	case "2":
+		mem.Intercessor = true
+		return domain.MemberSignUpStepThree, "", nil
+	default:
+		return 0, messaging.MsgWrongInput, nil
+	}
+}
+
+// acceptPrayerLimit accepts the number of prayers an intercessor is willing to pray for each week.
+func (s *MemberService) acceptPrayerLimit(reply string, mem *domain.Member) (int, string, error) {
+	num, err := strconv.Atoi(cleanStr(reply))
+	if err != nil {
+		return 0, messaging.MsgWrongInput, nil
+	}
+
+	mem.Intercessor = true
+	mem.WeeklyPrayerLimit = num
+	mem.WeeklyPrayerDate = time.Now().Format(time.RFC3339)
+	return domain.MemberSignUpStepFinal, "", nil
+}
+
+func signUpStepFor(stage int) (signUpStep, bool) {
+	for _, step := range signUpFlow {
+		if step.stage == stage {
+			return step, true
+		}
+	}
+	return signUpStep{}, false
+}
+
+// setSignUpStage moves mem to stage and restarts the clock used to nudge and expire unfinished sign ups.
+func setSignUpStage(mem *domain.Member, stage int) {
+	mem.SetupStage = stage
+	mem.SetupDate = time.Now().Format(time.RFC3339)
+	mem.SetupNudged = false
+}
+
+func (s *MemberService) signUpWrongInput(ctx context.Context, mem domain.Member, msg domain.TextMessage) error {
+	slog.WarnContext(ctx, "wrong input received during sign up", "member", mem.Phone, "msg", msg)
+	return s.sender.SendMessage(ctx, mem.Phone, messaging.MsgWrongInput)
+}
+
+// HandleStaleSignUps reminds members who stopped partway through signing up to finish, once, and removes sign ups that
+// have made no progress for too long so that the phone can start again. It is run by the statecontroller. Sign ups
+// saved before SetupDate existed are given the current time.
+func (s *MemberService) HandleStaleSignUps(ctx context.Context) error {
+	members, err := s.members.GetAll(ctx)
+	if err != nil {
+		return apperr.WrapError(err, "failed to get members")
+	}
+
+	now := time.Now()
+	nudgeAfter := time.Duration(s.cfg.SignUp.NudgeHours) * time.Hour
+	expireAfter := time.Duration(s.cfg.SignUp.ExpireHours) * time.Hour
+	for _, mem := range members {
+		if mem.SetupStatus != domain.MemberSetupInProgress {
+			continue
+		}
+
+		updated, parseErr := time.Parse(time.RFC3339, mem.SetupDate)
+		if parseErr != nil {
+			mem.SetupDate = now.Format(time.RFC3339)
+			if err = s.members.Save(ctx, &mem); err != nil {
+				return err
+			}
+			continue
+		}
+
+		age := now.Sub(updated)
+		switch {
+		case expireAfter > 0 && age >= expireAfter:
+			slog.InfoContext(ctx, "expiring unfinished sign up", "phone", mem.Phone, "stage", mem.SetupStage)
+			if err = s.members.Delete(ctx, mem.Phone); err != nil {
+				return err
+			}
+			if err = s.sender.SendMessage(ctx, mem.Phone, messaging.MsgSignUpExpired); err != nil {
+				return err
+			}
+		case nudgeAfter > 0 && age >= nudgeAfter && !mem.SetupNudged:
+			mem.SetupNudged = true
+			if err = s.members.Save(ctx, &mem); err != nil {
+				return err
+			}
+			if err = s.sender.SendMessage(ctx, mem.Phone, messaging.MsgSignUpNudge); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
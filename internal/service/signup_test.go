@@ -0,0 +1,143 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4JesusApps/prayertexter/internal/config"
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+	"github.com/4JesusApps/prayertexter/internal/service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	msgmocks "github.com/4JesusApps/prayertexter/internal/mocks/messaging"
+	repomocks "github.com/4JesusApps/prayertexter/internal/mocks/repository"
+)
+
+const signUpPhone = "+11234567890"
+
+// SignUpFlowSuite drives a phone through the whole sign up flow against in-memory fakes.
+type SignUpFlowSuite struct {
+	suite.Suite
+	svc          *service.MemberService
+	ctx          context.Context
+	member       domain.Member
+	intercessors domain.IntercessorPhones
+	consent      domain.ConsentRecord
+	replies      []string
+}
+
+func (s *SignUpFlowSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.member = domain.Member{Phone: signUpPhone}
+	s.intercessors = domain.IntercessorPhones{}
+	s.consent = domain.ConsentRecord{}
+	s.replies = nil
+
+	members := repomocks.NewMockMemberRepository(s.T())
+	members.EXPECT().Save(s.ctx, mock.Anything).RunAndReturn(func(_ context.Context, m *domain.Member) error {
+		s.member = *m
+		return nil
+	}).Maybe()
+
+	intercessors := repomocks.NewMockIntercessorPhonesRepository(s.T())
+	intercessors.EXPECT().Get(s.ctx).RunAndReturn(func(context.Context) (*domain.IntercessorPhones, error) {
+		phones := s.intercessors
+		return &phones, nil
+	}).Maybe()
+	intercessors.EXPECT().Save(s.ctx, mock.Anything).RunAndReturn(
+		func(_ context.Context, p *domain.IntercessorPhones) error {
+			s.intercessors = *p
+			return nil
+		}).Maybe()
+
+	sender := msgmocks.NewMockMessageSender(s.T())
+	sender.EXPECT().SendMessage(s.ctx, signUpPhone, mock.Anything).RunAndReturn(
+		func(_ context.Context, _ string, body string) error {
+			s.replies = append(s.replies, body)
+			return nil
+		}).Maybe()
+
+	consents := repomocks.NewMockConsentRecordRepository(s.T())
+	consents.EXPECT().Get(s.ctx, signUpPhone).RunAndReturn(
+		func(context.Context, string) (*domain.ConsentRecord, error) {
+			record := s.consent
+			return &record, nil
+		}).Maybe()
+	consents.EXPECT().Save(s.ctx, mock.Anything).RunAndReturn(func(_ context.Context, r *domain.ConsentRecord) error {
+		s.consent = *r
+		return nil
+	}).Maybe()
+
+	s.svc = service.NewMemberService(members, intercessors, repomocks.NewMockPrayerRepository(s.T()), consents,
+		sender, messaging.NewProfanity(config.ProfanityConfig{}, domain.ProfanityLists{}), config.Config{})
+}
+
+// text sends each body to SignUp in turn as the phone, as the member saved by the previous text, and returns the
+// replies sent back.
+func (s *SignUpFlowSuite) text(bodies ...string) []string {
+	s.replies = nil
+	for _, body := range bodies {
+		s.Require().NoError(s.svc.SignUp(s.ctx, domain.TextMessage{Body: body, Phone: signUpPhone}, s.member))
+	}
+	return s.replies
+}
+
+func (s *SignUpFlowSuite) TestPrayerOnly() {
+	replies := s.text("pray", "Jane", "1")
+
+	s.Equal([]string{
+		messaging.MsgNameRequest,
+		messaging.MsgMemberTypeRequest,
+		messaging.MsgPrayerInstructions + "\n\n" + messaging.MsgSignUpConfirmation,
+	}, replies)
+	s.Equal(domain.MemberSetupComplete, s.member.SetupStatus)
+	s.Equal(domain.MemberSignUpStepFinal, s.member.SetupStage)
+	s.Equal("Jane", s.member.Name)
+	s.False(s.member.Intercessor)
+	s.Empty(s.intercessors.Phones)
+	s.Equal(messaging.DisclosureVersion, s.member.ConsentDisclosure)
+	s.Require().Len(s.consent.Events, 1)
+	s.Equal(domain.ConsentOptIn, s.consent.Events[0].Type)
+	s.Equal(s.member.ConsentDate, s.consent.Events[0].Date)
+}
+
+func (s *SignUpFlowSuite) TestIntercessor_WrongInputs() {
+	replies := s.text("pray", "2", "3", "2", "five", "5")
+
+	s.Equal([]string{
+		messaging.MsgNameRequest,
+		messaging.MsgMemberTypeRequest,
+		messaging.MsgWrongInput,
+		messaging.MsgPrayerNumRequest,
+		messaging.MsgWrongInput,
+		messaging.MsgPrayerInstructions + "\n\n" + messaging.MsgIntercessorInstructions + "\n\n" +
+			messaging.MsgSignUpConfirmation,
+	}, replies)
+	s.Equal(domain.MemberSetupComplete, s.member.SetupStatus)
+	s.Equal("Anonymous", s.member.Name)
+	s.True(s.member.Intercessor)
+	s.Equal(5, s.member.WeeklyPrayerLimit)
+	s.Equal([]string{signUpPhone}, s.intercessors.Phones)
+}
+
+func (s *SignUpFlowSuite) TestRestartMidway() {
+	s.text("pray", "Jane", "2")
+	s.Equal(domain.MemberSignUpStepThree, s.member.SetupStage)
+
+	replies := s.text("restart", "John", "1")
+
+	s.Equal([]string{
+		messaging.MsgNameRequest,
+		messaging.MsgMemberTypeRequest,
+		messaging.MsgPrayerInstructions + "\n\n" + messaging.MsgSignUpConfirmation,
+	}, replies)
+	s.Equal("John", s.member.Name)
+	s.False(s.member.Intercessor)
+	s.Empty(s.intercessors.Phones)
+}
+
+func TestSignUpFlowSuite(t *testing.T) {
+	suite.Run(t, new(SignUpFlowSuite))
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/4JesusApps/prayertexter/internal/domain"
+	"github.com/4JesusApps/prayertexter/internal/messaging"
+)
+
+const (
+	throttleDay  = 24 * time.Hour
+	throttleWeek = daysPerWeek * throttleDay
+)
+
+// throttle refuses msg when mem has sent too many requests recently or has already sent one like it, and otherwise
+// records msg in mem's request history. Exempt requests, such as those in crisis, are always recorded and never
+// refused. Members who are refused too often are reported to admins. It reports whether msg was refused.
+func (s *PrayerService) throttle(
+	ctx context.Context,
+	msg domain.TextMessage,
+	mem domain.Member,
+	exempt bool,
+) (bool, error) {
+	history, err := s.history.Get(ctx, mem.Phone)
+	if err != nil {
+		return false, err
+	}
+	history.Phone = mem.Phone
+	now := time.Now()
+	history.Prune(now.Add(-throttleWeek))
+
+	var reply string
+	if !exempt {
+		if reply, err = s.throttleReply(history, msg.Body, now); err != nil {
+			return false, err
+		}
+	}
+	if reply == "" {
+		record := domain.RequestRecord{Date: now.Format(time.RFC3339), Text: msg.Body}
+		history.Requests = append(history.Requests, record)
+		return false, s.history.Save(ctx, history)
+	}
+
+	history.Strikes = append(history.Strikes, now.Format(time.RFC3339))
+	strikes := len(history.Strikes)
+	escalate := s.cfg.Throttle.EscalateAfter > 0 && strikes >= s.cfg.Throttle.EscalateAfter
+	if escalate {
+		history.Strikes = nil
+	}
+	if err = s.history.Save(ctx, history); err != nil {
+		return false, err
+	}
+	if err = s.sender.SendMessage(ctx, mem.Phone, reply); err != nil {
+		return false, err
+	}
+	if !escalate {
+		return true, nil
+	}
+
+	alert, err := messaging.Render(messaging.ThrottleAlertTmpl, struct {
+		Name, Phone, Request string
+		Count                int
+	}{mem.Name, mem.Phone, msg.Body, strikes})
+	if err != nil {
+		return false, err
+	}
+	return true, s.notifyModerators(ctx, alert)
+}
+
+// throttleReply returns the reply to send when request breaks one of the throttle limits given history, or an empty
+// string when it does not.
+func (s *PrayerService) throttleReply(history *domain.RequestHistory, request string, now time.Time) (string, error) {
+	limits := []struct {
+		limit  int
+		count  int
+		period string
+	}{
+		{s.cfg.Throttle.DailyRequests, history.CountSince(now.Add(-throttleDay)), "day"},
+		{s.cfg.Throttle.WeeklyRequests, len(history.Requests), "week"},
+	}
+	for _, l := range limits {
+		if l.limit > 0 && l.count >= l.limit {
+			return messaging.Render(messaging.RequestLimitTmpl, struct {
+				Limit  int
+				Period string
+			}{l.limit, l.period})
+		}
+	}
+
+	if s.cfg.Throttle.DuplicateSimilarity > 0 {
+		for _, previous := range history.Requests {
+			if messaging.Similarity(previous.Text, request) >= s.cfg.Throttle.DuplicateSimilarity {
+				return messaging.MsgDuplicateRequest, nil
+			}
+		}
+	}
+
+	return "", nil
+}